@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/config"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/handler"
+	"github.com/nadiannis/evento-api-fr-auth/internal/payment"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/storage"
+	"github.com/nadiannis/evento-api-fr-auth/internal/testutil"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+)
+
+func newTestApp(t *testing.T) (*application, *sql.DB) {
+	t.Helper()
+
+	return newTestAppWithProvider(t, nil)
+}
+
+func newTestAppWithProvider(t *testing.T, provider payment.PaymentProvider) (*application, *sql.DB) {
+	t.Helper()
+
+	db := testutil.OpenDB(t)
+
+	var cfg config.Config
+	cfg.Currency = "USD"
+	cfg.JWT.Secret = "test-jwt-secret"
+	cfg.TicketSigning.Seed = "test-ticket-signing-seed"
+	cfg.Storage.Dir = t.TempDir()
+	cfg.Payments.Timeout = 15 * time.Minute
+	cfg.Images.MaxSize = 5 << 20
+	cfg.Resale.MaxPriceRatio = 1.2
+	cfg.Resale.FeeRate = 0.1
+	cfg.Transfers.Cutoff = 24 * time.Hour
+
+	repos := repository.NewRepositories(db)
+	usecases := usecase.NewUsecases(&cfg, repos, storage.NewLocalStorage(cfg.Storage.Dir), provider)
+	handlers := handler.NewHandlers(&cfg, usecases)
+
+	gin.SetMode(gin.TestMode)
+
+	app := &application{
+		config:   &cfg,
+		usecases: usecases,
+		handlers: handlers,
+	}
+
+	return app, db
+}
+
+// newTestReplicas starts n more instances of the app on the same database, as
+// if it ran on several servers, so concurrent requests aren't serialized by
+// the locks of a single instance.
+func newTestReplicas(t *testing.T, app *application, db *sql.DB, n int) []*application {
+	t.Helper()
+
+	replicas := make([]*application, 0, n)
+	for range n {
+		repos := repository.NewRepositories(db)
+		usecases := usecase.NewUsecases(app.config, repos, storage.NewLocalStorage(app.config.Storage.Dir), nil)
+
+		replicas = append(replicas, &application{
+			config:   app.config,
+			usecases: usecases,
+			handlers: handler.NewHandlers(app.config, usecases),
+		})
+	}
+
+	return replicas
+}
+
+// runConcurrently releases fn on every replica at once and returns the error
+// each call ended with.
+func runConcurrently(replicas []*application, fn func(app *application) error) []error {
+	errs := make([]error, len(replicas))
+	start := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i, replica := range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs[i] = fn(replica)
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	return errs
+}
+
+// countSuccesses returns how many calls succeeded, failing the test on any error
+// other than the expected one.
+func countSuccesses(t *testing.T, errs []error, expected error) int {
+	t.Helper()
+
+	succeeded := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, expected):
+			t.Errorf("got %v, want nil or %v", err, expected)
+		}
+	}
+
+	return succeeded
+}
+
+func addTestTicket(t *testing.T, app *application, maxPerCustomer *int) *domain.Ticket {
+	t.Helper()
+
+	prepopulateTicketTypes(app.usecases.TicketTypes)
+
+	event, err := app.usecases.Events.Add(&request.EventRequest{
+		Name:     "Test Event",
+		StartsAt: time.Now().AddDate(0, 1, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ticket, err := app.usecases.Tickets.Add(&request.TicketRequest{
+		EventID:        event.ID,
+		Type:           domain.TicketTypeCAT1,
+		Quantity:       10,
+		MaxPerCustomer: maxPerCustomer,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ticket
+}
+
+func addTestCustomer(t *testing.T, app *application, username string, balance float64) (*domain.Customer, string) {
+	t.Helper()
+
+	input := &request.CustomerRequest{
+		Username: username,
+		Password: "password1234",
+	}
+
+	customer, err := app.usecases.Customers.Add(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if balance != 0 {
+		_, err = app.usecases.Customers.UpdateBalance(customer.ID, &request.CustomerBalanceRequest{
+			Action:  request.ActionAdd,
+			Balance: balance,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	token, err := app.usecases.Customers.Login(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return customer, *token
+}
+
+func addTestAdmin(t *testing.T, app *application, db *sql.DB, username string) (*domain.Customer, string) {
+	t.Helper()
+
+	customer, token := addTestCustomer(t, app, username, 0)
+
+	_, err := db.Exec("UPDATE customers SET role = $1 WHERE id = $2", domain.RoleAdmin, customer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return customer, token
+}
+
+func doTestRequest(t *testing.T, app *application, method string, path string, token string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		err := json.NewEncoder(&payload).Encode(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	app.routes().ServeHTTP(rec, req)
+
+	return rec
+}
+
+func expectStatus(t *testing.T, rec *httptest.ResponseRecorder, status int) {
+	t.Helper()
+
+	if rec.Code != status {
+		t.Fatalf("got status %d, want %d: %s", rec.Code, status, rec.Body.String())
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+func TestCheckInConcurrently(t *testing.T) {
+	app, db := newTestApp(t)
+
+	ticket := addTestTicket(t, app, nil)
+	buyer, _ := addTestCustomer(t, app, "buyer", 1000)
+	scanner, _ := addTestCustomer(t, app, "scanner", 0)
+
+	_, err := db.Exec("UPDATE customers SET role = $1 WHERE id = $2", domain.RoleScanner, scanner.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order, err := app.usecases.Orders.Add(&request.OrderRequest{
+		TicketID: ticket.ID,
+		Quantity: 1,
+	}, buyer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuedTickets, err := app.usecases.Orders.GetTickets(order.ID, buyer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu           sync.Mutex
+		checkedInAts []time.Time
+	)
+
+	errs := runConcurrently(newTestReplicas(t, app, db, 8), func(app *application) error {
+		issuedTicket, err := app.usecases.CheckIns.CheckIn(&request.CheckInRequest{
+			EventID: ticket.EventID,
+			Code:    issuedTickets[0].Code,
+		}, scanner.ID)
+		if issuedTicket != nil && issuedTicket.CheckedInAt != nil {
+			mu.Lock()
+			checkedInAts = append(checkedInAts, *issuedTicket.CheckedInAt)
+			mu.Unlock()
+		}
+		return err
+	})
+
+	if succeeded := countSuccesses(t, errs, utils.ErrTicketAlreadyCheckedIn); succeeded != 1 {
+		t.Fatalf("got %d check-ins, want 1", succeeded)
+	}
+
+	for _, checkedInAt := range checkedInAts {
+		if !checkedInAt.Equal(checkedInAts[0]) {
+			t.Fatalf("got check-in times %v, want them all the same", checkedInAts)
+		}
+	}
+}
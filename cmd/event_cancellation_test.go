@@ -0,0 +1,219 @@
+package main
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+func TestCancelEventAfterResale(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	ticket := addTestTicket(t, app, nil)
+	seller, _ := addTestCustomer(t, app, "seller", 1000)
+	buyer, _ := addTestCustomer(t, app, "buyer", 1000)
+
+	order, err := app.usecases.Orders.Add(&request.OrderRequest{
+		TicketID: ticket.ID,
+		Quantity: 2,
+	}, seller.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuedTickets, err := app.usecases.Orders.GetTickets(order.ID, seller.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listing, err := app.usecases.Resales.Add(&request.ResaleListingRequest{
+		TicketCode: issuedTickets[0].Code,
+		Price:      280,
+	}, seller.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.usecases.Resales.Purchase(listing.ID, buyer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.usecases.Events.Cancel(ticket.EventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	utils.WaitBackground()
+
+	unitPrice := order.TotalPrice / 2
+
+	tests := []struct {
+		name       string
+		customerID int64
+		want       float64
+	}{
+		{"seller", seller.ID, 1000 - order.TotalPrice + listing.Price - listing.Fee + unitPrice},
+		{"buyer", buyer.ID, 1000 - listing.Price + unitPrice},
+	}
+
+	for _, tt := range tests {
+		customer, err := app.usecases.Customers.GetByID(tt.customerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if customer.Balance != tt.want {
+			t.Errorf("%s: got balance %.2f, want %.2f", tt.name, customer.Balance, tt.want)
+		}
+	}
+}
+
+func TestCancelEventRefundedAmounts(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	ticket := addTestTicket(t, app, nil)
+
+	_, err := app.usecases.ExchangeRates.Set(&request.ExchangeRateRequest{
+		BaseCurrency:  "USD",
+		QuoteCurrency: "EUR",
+		Rate:          2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buyer, err := app.usecases.Customers.Add(&request.CustomerRequest{
+		Username: "buyer",
+		Password: "password1234",
+		Currency: "EUR",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.usecases.Customers.UpdateBalance(buyer.ID, &request.CustomerBalanceRequest{
+		Action:  request.ActionAdd,
+		Balance: 1000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order, err := app.usecases.Orders.Add(&request.OrderRequest{
+		TicketID: ticket.ID,
+		Quantity: 1,
+	}, buyer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.usecases.Events.Cancel(ticket.EventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	utils.WaitBackground()
+
+	cancellation, err := app.usecases.Events.GetCancellation(ticket.EventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]float64{order.ChargedCurrency: order.ChargedAmount}
+	if !maps.Equal(cancellation.RefundedAmounts, want) {
+		t.Fatalf("got refunded amounts %v, want %v", cancellation.RefundedAmounts, want)
+	}
+}
+
+func TestCancelEventAfterTransfer(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	ticket := addTestTicket(t, app, nil)
+	seller, _ := addTestCustomer(t, app, "seller", 1000)
+	buyer, _ := addTestCustomer(t, app, "buyer", 1000)
+	friend, _ := addTestCustomer(t, app, "friend", 0)
+
+	order, err := app.usecases.Orders.Add(&request.OrderRequest{
+		TicketID: ticket.ID,
+		Quantity: 2,
+	}, seller.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuedTickets, err := app.usecases.Orders.GetTickets(order.ID, seller.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listing, err := app.usecases.Resales.Add(&request.ResaleListingRequest{
+		TicketCode: issuedTickets[0].Code,
+		Price:      280,
+	}, seller.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.usecases.Resales.Purchase(listing.ID, buyer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resoldTickets, err := app.usecases.Orders.GetCustomerTickets(buyer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gifts := []struct {
+		senderID int64
+		code     string
+	}{
+		{seller.ID, issuedTickets[1].Code},
+		{buyer.ID, resoldTickets[0].Code},
+	}
+
+	for _, gift := range gifts {
+		transfer, err := app.usecases.Transfers.Add(&request.TicketTransferRequest{
+			RecipientUsername: friend.Username,
+			TicketCodes:       []string{gift.code},
+		}, gift.senderID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = app.usecases.Transfers.Accept(transfer.ID, friend.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err = app.usecases.Events.Cancel(ticket.EventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	utils.WaitBackground()
+
+	unitPrice := order.TotalPrice / 2
+
+	tests := []struct {
+		name       string
+		customerID int64
+		want       float64
+	}{
+		{"seller", seller.ID, 1000 - order.TotalPrice + listing.Price - listing.Fee + unitPrice},
+		{"buyer", buyer.ID, 1000 - listing.Price + unitPrice},
+		{"friend", friend.ID, 0},
+	}
+
+	for _, tt := range tests {
+		customer, err := app.usecases.Customers.GetByID(tt.customerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if customer.Balance != tt.want {
+			t.Errorf("%s: got balance %.2f, want %.2f", tt.name, customer.Balance, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+)
+
+func TestRenderResoldTicket(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	ticket := addTestTicket(t, app, nil)
+	seller, sellerToken := addTestCustomer(t, app, "seller", 1000)
+	buyer, buyerToken := addTestCustomer(t, app, "buyer", 1000)
+
+	order, err := app.usecases.Orders.Add(&request.OrderRequest{
+		TicketID: ticket.ID,
+		Quantity: 2,
+	}, seller.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuedTickets, err := app.usecases.Orders.GetTickets(order.ID, seller.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listing, err := app.usecases.Resales.Add(&request.ResaleListingRequest{
+		TicketCode: issuedTickets[0].Code,
+		Price:      250,
+	}, seller.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.usecases.Resales.Purchase(listing.ID, buyer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	heldTickets, err := app.usecases.Orders.GetCustomerTickets(buyer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(heldTickets) != 1 {
+		t.Fatalf("got %d tickets held by the buyer, want 1", len(heldTickets))
+	}
+
+	path := "/api/issued-tickets/" + heldTickets[0].Code + ".png"
+
+	rec := doTestRequest(t, app, http.MethodGet, path, buyerToken, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	if contentType := rec.Header().Get("Content-Type"); contentType != "image/png" {
+		t.Fatalf("got content type %q, want image/png", contentType)
+	}
+
+	rec = doTestRequest(t, app, http.MethodGet, "/api/issued-tickets.pdf", buyerToken, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	rec = doTestRequest(t, app, http.MethodGet, path, sellerToken, nil)
+	expectStatus(t, rec, http.StatusNotFound)
+
+	orderPath := fmt.Sprintf("/api/orders/%d/tickets", order.ID)
+
+	rec = doTestRequest(t, app, http.MethodGet, orderPath+".pdf", sellerToken, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	if contentType := rec.Header().Get("Content-Type"); contentType != "application/pdf" {
+		t.Fatalf("got content type %q, want application/pdf", contentType)
+	}
+
+	rec = doTestRequest(t, app, http.MethodGet, orderPath+"/"+issuedTickets[1].Code+".png", sellerToken, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	rec = doTestRequest(t, app, http.MethodGet, orderPath+"/"+issuedTickets[0].Code+".png", sellerToken, nil)
+	expectStatus(t, rec, http.StatusNotFound)
+
+	rec = doTestRequest(t, app, http.MethodGet, orderPath+".pdf", buyerToken, nil)
+	expectStatus(t, rec, http.StatusNotFound)
+}
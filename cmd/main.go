@@ -2,13 +2,20 @@ package main
 
 import (
 	"database/sql"
+	"errors"
 	"flag"
+	"fmt"
+	"strings"
+	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/nadiannis/evento-api-fr-auth/internal/config"
 	"github.com/nadiannis/evento-api-fr-auth/internal/handler"
+	"github.com/nadiannis/evento-api-fr-auth/internal/payment"
 	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/storage"
 	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
 	"github.com/rs/zerolog/log"
 )
 
@@ -22,11 +29,45 @@ func main() {
 	var cfg config.Config
 
 	flag.IntVar(&cfg.Port, "port", 8080, "API server port")
+	flag.StringVar(&cfg.Currency, "currency", "", "Default ISO 4217 currency of new ticket types, customer wallets, fixed promo discounts & booking fees")
 	flag.StringVar(&cfg.DB.DSN, "db-dsn", "", "PostgreSQL data source name")
 	flag.StringVar(&cfg.JWT.Secret, "jwt-secret", "", "JWT secret")
+	flag.StringVar(&cfg.TicketSigning.Seed, "ticket-signing-seed", "", "Seed of the key signing ticket tokens")
+	flag.StringVar(&cfg.Storage.Backend, "storage-backend", "local", "Storage backend of uploaded files (local|s3)")
+	flag.StringVar(&cfg.Storage.Dir, "storage-dir", "uploads", "Directory of uploaded files for the local storage backend")
+	flag.StringVar(&cfg.Storage.S3.Endpoint, "s3-endpoint", "", "Address of the S3-compatible object store, e.g. http://localhost:9000")
+	flag.StringVar(&cfg.Storage.S3.Bucket, "s3-bucket", "", "Bucket of uploaded files")
+	flag.StringVar(&cfg.Storage.S3.Region, "s3-region", "us-east-1", "Region of the bucket")
+	flag.StringVar(&cfg.Storage.S3.AccessKey, "s3-access-key", "", "Access key of the object store")
+	flag.StringVar(&cfg.Storage.S3.SecretKey, "s3-secret-key", "", "Secret key of the object store")
+	flag.StringVar(&cfg.Payments.Provider, "payment-provider", "", "Payment provider of top-ups & orders (fake), none by default")
+	flag.StringVar(&cfg.Payments.WebhookSecret, "payment-webhook-secret", "", "Secret signing the webhook callbacks of the payment provider")
+	flag.DurationVar(&cfg.Payments.Timeout, "payment-timeout", 15*time.Minute, "Time an order paid through the payment provider holds its tickets before it expires unpaid")
+	flag.BoolVar(&cfg.Payments.Simulation, "payment-simulation", false, "Let admins simulate payments of the fake provider, for local development only")
+	flag.Int64Var(&cfg.Images.MaxSize, "image-max-size", 5<<20, "Maximum size of an uploaded image in bytes")
+	flag.Float64Var(&cfg.Resale.MaxPriceRatio, "resale-max-price-ratio", 1.2, "Maximum resale price relative to the face value of a ticket")
+	flag.Float64Var(&cfg.Resale.FeeRate, "resale-fee-rate", 0.1, "Platform fee deducted from the resale price paid to the seller")
+	flag.DurationVar(&cfg.Transfers.Cutoff, "transfer-cutoff", 24*time.Hour, "Time before an event starts from when its tickets can no longer be transferred or resold")
 
 	flag.Parse()
 
+	if cfg.TicketSigning.Seed == "" {
+		log.Fatal().Msg("ticket signing seed is required")
+	}
+
+	if cfg.Payments.Provider != "" && cfg.Payments.WebhookSecret == "" {
+		log.Fatal().Msg("payment webhook secret is required")
+	}
+
+	if cfg.Currency == "" {
+		log.Fatal().Msg("currency is required")
+	}
+
+	cfg.Currency = strings.ToUpper(cfg.Currency)
+	if !utils.Matches(cfg.Currency, utils.CurrencyRX) {
+		log.Fatal().Msg("currency should be an ISO 4217 currency code")
+	}
+
 	db, err := openDB(&cfg)
 	if err != nil {
 		log.Fatal().Msg(err.Error())
@@ -34,9 +75,19 @@ func main() {
 	defer db.Close()
 	log.Info().Msg("connected to database successfully")
 
+	store, err := openStorage(&cfg)
+	if err != nil {
+		log.Fatal().Msg(err.Error())
+	}
+
+	provider, err := openPaymentProvider(&cfg)
+	if err != nil {
+		log.Fatal().Msg(err.Error())
+	}
+
 	repos := repository.NewRepositories(db)
-	usecases := usecase.NewUsecases(&cfg, repos)
-	handlers := handler.NewHandlers(usecases)
+	usecases := usecase.NewUsecases(&cfg, repos, store, provider)
+	handlers := handler.NewHandlers(&cfg, usecases)
 
 	app := &application{
 		config:   &cfg,
@@ -50,6 +101,12 @@ func main() {
 	log.Info().Msg("add events and tickets")
 	prepopulateEventsAndTickets(usecases.Events, usecases.Tickets)
 
+	log.Info().Msg("resume event cancellations")
+	err = usecases.Events.ResumeCancellations()
+	if err != nil {
+		log.Fatal().Msg(err.Error())
+	}
+
 	err = app.serve()
 	if err != nil {
 		log.Fatal().Msg(err.Error())
@@ -71,3 +128,34 @@ func openDB(cfg *config.Config) (*sql.DB, error) {
 
 	return db, nil
 }
+
+func openStorage(cfg *config.Config) (storage.Storage, error) {
+	switch cfg.Storage.Backend {
+	case "local":
+		return storage.NewLocalStorage(cfg.Storage.Dir), nil
+	case "s3":
+		if cfg.Storage.S3.Endpoint == "" || cfg.Storage.S3.Bucket == "" {
+			return nil, errors.New("s3 storage requires an endpoint and a bucket")
+		}
+		return storage.NewS3Storage(
+			cfg.Storage.S3.Endpoint,
+			cfg.Storage.S3.Bucket,
+			cfg.Storage.S3.Region,
+			cfg.Storage.S3.AccessKey,
+			cfg.Storage.S3.SecretKey,
+		), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage.Backend)
+	}
+}
+
+func openPaymentProvider(cfg *config.Config) (payment.PaymentProvider, error) {
+	switch cfg.Payments.Provider {
+	case "":
+		return nil, nil
+	case "fake":
+		return payment.NewFakeProvider(cfg.Payments.WebhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Payments.Provider)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
 	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
 	"github.com/rs/zerolog/log"
@@ -96,7 +97,20 @@ func (app *application) Authenticate() gin.HandlerFunc {
 			return
 		}
 
-		c.Set("customer", customer)
+		utils.SetCustomer(c, customer)
+		c.Next()
+	}
+}
+
+func (app *application) RequireRole(roles ...domain.CustomerRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		customer := utils.GetCustomer(c)
+		if customer == nil || !utils.PermittedValue(customer.Role, roles...) {
+			utils.NotPermittedResponse(c)
+			c.Abort()
+			return
+		}
+
 		c.Next()
 	}
 }
@@ -0,0 +1,480 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+func TestAddOrderOverPurchaseLimit(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	maxPerCustomer := 2
+	ticket := addTestTicket(t, app, &maxPerCustomer)
+	_, token := addTestCustomer(t, app, "buyer", 1000)
+
+	rec := doTestRequest(t, app, http.MethodPost, "/api/orders", token, request.OrderRequest{
+		TicketID: ticket.ID,
+		Quantity: 2,
+	})
+	expectStatus(t, rec, http.StatusCreated)
+
+	rec = doTestRequest(t, app, http.MethodPost, "/api/orders", token, request.OrderRequest{
+		TicketID: ticket.ID,
+		Quantity: 1,
+	})
+	expectStatus(t, rec, http.StatusConflict)
+
+	if !strings.Contains(rec.Body.String(), utils.ErrPurchaseLimitExceeded.Error()) {
+		t.Fatalf("got %s, want %q", rec.Body.String(), utils.ErrPurchaseLimitExceeded)
+	}
+}
+
+func TestAddOrderOverStock(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	ticket := addTestTicket(t, app, nil)
+	_, token := addTestCustomer(t, app, "buyer", 1000)
+
+	tests := []struct {
+		name  string
+		input request.OrderRequest
+	}{
+		{"huge quantity", request.OrderRequest{
+			TicketID: ticket.ID,
+			Quantity: math.MaxInt32,
+		}},
+		{"overflowing items", request.OrderRequest{
+			Items: []*request.OrderItemRequest{
+				{TicketID: ticket.ID, Quantity: math.MaxInt},
+				{TicketID: ticket.ID, Quantity: math.MaxInt},
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := time.Now()
+
+			rec := doTestRequest(t, app, http.MethodPost, "/api/orders", token, tt.input)
+			expectStatus(t, rec, http.StatusBadRequest)
+
+			if !strings.Contains(rec.Body.String(), utils.ErrInsufficientTicketQuantity.Error()) {
+				t.Fatalf("got %s, want %q", rec.Body.String(), utils.ErrInsufficientTicketQuantity)
+			}
+
+			if elapsed := time.Since(started); elapsed > time.Second {
+				t.Fatalf("took %s to reject the order", elapsed)
+			}
+		})
+	}
+}
+
+func TestDeleteAllOrders(t *testing.T) {
+	app, db := newTestApp(t)
+
+	ticket := addTestTicket(t, app, nil)
+	admin, adminToken := addTestAdmin(t, app, db, "admin")
+	buyer, buyerToken := addTestCustomer(t, app, "buyer", 1000)
+
+	giftCard, err := app.usecases.GiftCards.Add(&request.GiftCardRequest{Value: 100}, admin.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	promoCode, err := app.usecases.PromoCodes.Add(&request.PromoCodeRequest{
+		Code:          "SAVE10",
+		DiscountType:  domain.DiscountTypeFixed,
+		DiscountValue: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := doTestRequest(t, app, http.MethodPost, "/api/orders", buyerToken, request.OrderRequest{
+		TicketID:     ticket.ID,
+		Quantity:     2,
+		PromoCode:    promoCode.Code,
+		GiftCardCode: giftCard.Code,
+	})
+	expectStatus(t, rec, http.StatusCreated)
+
+	rec = doTestRequest(t, app, http.MethodDelete, "/api/orders", buyerToken, nil)
+	expectStatus(t, rec, http.StatusForbidden)
+
+	rec = doTestRequest(t, app, http.MethodDelete, "/api/orders", adminToken, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	customer, err := app.usecases.Customers.GetByID(buyer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if customer.Balance != 1000 {
+		t.Errorf("got balance %.2f, want 1000.00", customer.Balance)
+	}
+
+	ticketDetail, err := app.usecases.Tickets.GetByID(ticket.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticketDetail.Quantity != ticket.Quantity {
+		t.Errorf("got quantity %d, want %d", ticketDetail.Quantity, ticket.Quantity)
+	}
+
+	giftCard, err = app.usecases.GiftCards.GetByCode(giftCard.Code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if giftCard.Balance != giftCard.Value {
+		t.Errorf("got gift card balance %.2f, want %.2f", giftCard.Balance, giftCard.Value)
+	}
+
+	promoCode, err = app.usecases.PromoCodes.GetByID(promoCode.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if promoCode.TimesRedeemed != 0 {
+		t.Errorf("got promo code redeemed %d times, want 0", promoCode.TimesRedeemed)
+	}
+}
+
+func TestAddOrderWithForeignPromoCode(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	ticket := addTestTicket(t, app, nil)
+	_, token := addTestCustomer(t, app, "buyer", 1000)
+
+	promoCode, err := app.usecases.PromoCodes.Add(&request.PromoCodeRequest{
+		Code:          "EURO10",
+		DiscountType:  domain.DiscountTypeFixed,
+		DiscountValue: 10,
+		Currency:      "EUR",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := request.OrderRequest{
+		TicketID:  ticket.ID,
+		Quantity:  1,
+		PromoCode: promoCode.Code,
+	}
+
+	rec := doTestRequest(t, app, http.MethodPost, "/api/orders", token, input)
+	expectStatus(t, rec, http.StatusBadRequest)
+
+	if !strings.Contains(rec.Body.String(), utils.ErrCurrencyNotConvertible.Error()) {
+		t.Fatalf("got %s, want %q", rec.Body.String(), utils.ErrCurrencyNotConvertible)
+	}
+
+	_, err = app.usecases.ExchangeRates.Set(&request.ExchangeRateRequest{
+		BaseCurrency:  "EUR",
+		QuoteCurrency: "USD",
+		Rate:          2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec = doTestRequest(t, app, http.MethodPost, "/api/orders", token, input)
+	expectStatus(t, rec, http.StatusCreated)
+
+	orders, err := app.usecases.Orders.GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 1 || orders[0].Discount != 20 {
+		t.Fatalf("got orders %+v, want one with a discount of 20.00", orders)
+	}
+}
+
+func TestDeleteAllOrdersOffersWaitlist(t *testing.T) {
+	app, db := newTestApp(t)
+
+	ticket := addTestTicket(t, app, nil)
+	_, adminToken := addTestAdmin(t, app, db, "admin")
+	buyer, _ := addTestCustomer(t, app, "buyer", 100000)
+	waiter, _ := addTestCustomer(t, app, "waiter", 0)
+
+	_, err := app.usecases.Orders.Add(&request.OrderRequest{
+		TicketID: ticket.ID,
+		Quantity: ticket.Quantity,
+	}, buyer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.usecases.Waitlists.Join(ticket.ID, waiter.ID, &request.WaitlistRequest{Quantity: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := doTestRequest(t, app, http.MethodDelete, "/api/orders", adminToken, nil)
+	expectStatus(t, rec, http.StatusOK)
+	utils.WaitBackground()
+
+	entries, err := app.usecases.Waitlists.GetAll(waiter.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Status != domain.WaitlistEntryStatusOffered {
+		t.Fatalf("got waitlist entries %+v, want one offered", entries)
+	}
+}
+
+func TestAddOrdersConcurrentlyOverPurchaseLimit(t *testing.T) {
+	app, db := newTestApp(t)
+
+	maxPerCustomer := 2
+	ticket := addTestTicket(t, app, &maxPerCustomer)
+	buyer, _ := addTestCustomer(t, app, "buyer", 100000)
+
+	errs := runConcurrently(newTestReplicas(t, app, db, 8), func(app *application) error {
+		_, err := app.usecases.Orders.Add(&request.OrderRequest{
+			TicketID: ticket.ID,
+			Quantity: 1,
+		}, buyer.ID)
+		return err
+	})
+
+	if succeeded := countSuccesses(t, errs, utils.ErrPurchaseLimitExceeded); succeeded != maxPerCustomer {
+		t.Fatalf("got %d orders placed, want %d", succeeded, maxPerCustomer)
+	}
+}
+
+func TestAddOrdersConcurrentlyOverStock(t *testing.T) {
+	app, db := newTestApp(t)
+
+	ticket := addTestTicket(t, app, nil)
+	replicas := newTestReplicas(t, app, db, 8)
+
+	buyers := make(chan int64, len(replicas))
+	for i := range replicas {
+		buyer, _ := addTestCustomer(t, app, fmt.Sprintf("buyer%d", i), 100000)
+		buyers <- buyer.ID
+	}
+
+	errs := runConcurrently(replicas, func(app *application) error {
+		_, err := app.usecases.Orders.Add(&request.OrderRequest{
+			TicketID: ticket.ID,
+			Quantity: 2,
+		}, <-buyers)
+		return err
+	})
+
+	if succeeded := countSuccesses(t, errs, utils.ErrInsufficientTicketQuantity); succeeded != ticket.Quantity/2 {
+		t.Fatalf("got %d orders placed, want %d", succeeded, ticket.Quantity/2)
+	}
+
+	ticketDetail, err := app.usecases.Tickets.GetByID(ticket.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticketDetail.Quantity != 0 {
+		t.Errorf("got quantity %d, want 0", ticketDetail.Quantity)
+	}
+}
+
+func TestAddOrdersConcurrentlyForSameSeat(t *testing.T) {
+	app, db := newTestApp(t)
+
+	prepopulateTicketTypes(app.usecases.TicketTypes)
+
+	venue, err := app.usecases.Venues.Add(&request.VenueRequest{
+		Name:     "Test Hall",
+		Address:  "1 Test Street",
+		Timezone: "UTC",
+		Capacity: 10,
+		Sections: []*request.VenueSectionRequest{
+			{Name: "A", Rows: []*request.SeatRowRequest{{Label: "1", Seats: 10}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event, err := app.usecases.Events.Add(&request.EventRequest{
+		Name:     "Seated Event",
+		StartsAt: time.Now().AddDate(0, 1, 0),
+		VenueID:  &venue.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ticket, err := app.usecases.Tickets.Add(&request.TicketRequest{
+		EventID:  event.ID,
+		Type:     domain.TicketTypeCAT1,
+		Quantity: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.usecases.Tickets.AssignSeats(ticket.ID, &request.TicketSeatsRequest{
+		Blocks: []*request.SeatBlockRequest{{Section: "A"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seats, err := app.usecases.Events.GetSeats(event.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replicas := newTestReplicas(t, app, db, 8)
+
+	buyers := make(chan int64, len(replicas))
+	for i := range replicas {
+		buyer, _ := addTestCustomer(t, app, fmt.Sprintf("buyer%d", i), 100000)
+		buyers <- buyer.ID
+	}
+
+	errs := runConcurrently(replicas, func(app *application) error {
+		_, err := app.usecases.Orders.Add(&request.OrderRequest{
+			TicketID: ticket.ID,
+			Quantity: 1,
+			SeatIDs:  []int64{seats[0].ID},
+		}, <-buyers)
+		return err
+	})
+
+	if succeeded := countSuccesses(t, errs, utils.ErrSeatUnavailable); succeeded != 1 {
+		t.Fatalf("got %d orders for the seat, want 1", succeeded)
+	}
+}
+
+func TestRedeemPromoCodeConcurrently(t *testing.T) {
+	one := 1
+
+	tests := []struct {
+		name     string
+		limits   request.PromoCodeLimitsRequest
+		sameUser bool
+		wantErr  error
+	}{
+		{"single use", request.PromoCodeLimitsRequest{MaxRedemptions: &one}, false, utils.ErrPromoCodeUnavailable},
+		{"once per customer", request.PromoCodeLimitsRequest{MaxPerCustomer: &one}, true, utils.ErrPromoCodeLimitReached},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, db := newTestApp(t)
+
+			ticket := addTestTicket(t, app, nil)
+			replicas := newTestReplicas(t, app, db, 8)
+
+			promoCode, err := app.usecases.PromoCodes.Add(&request.PromoCodeRequest{
+				Code:                   "ONCE",
+				DiscountType:           domain.DiscountTypePercentage,
+				DiscountValue:          10,
+				PromoCodeLimitsRequest: tt.limits,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			buyers := make(chan int64, len(replicas))
+			buyer, _ := addTestCustomer(t, app, "buyer", 100000)
+			for i := range replicas {
+				if !tt.sameUser && i > 0 {
+					buyer, _ = addTestCustomer(t, app, fmt.Sprintf("buyer%d", i), 100000)
+				}
+				buyers <- buyer.ID
+			}
+
+			errs := runConcurrently(replicas, func(app *application) error {
+				_, err := app.usecases.Orders.Add(&request.OrderRequest{
+					TicketID:  ticket.ID,
+					Quantity:  1,
+					PromoCode: promoCode.Code,
+				}, <-buyers)
+				return err
+			})
+
+			if succeeded := countSuccesses(t, errs, tt.wantErr); succeeded != 1 {
+				t.Fatalf("got %d orders with the promo code, want 1", succeeded)
+			}
+
+			promoCode, err = app.usecases.PromoCodes.GetByID(promoCode.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if promoCode.TimesRedeemed != 1 {
+				t.Errorf("got promo code redeemed %d times, want 1", promoCode.TimesRedeemed)
+			}
+		})
+	}
+}
+
+func TestSpendGiftCardConcurrently(t *testing.T) {
+	app, db := newTestApp(t)
+
+	ticket := addTestTicket(t, app, nil)
+	admin, _ := addTestAdmin(t, app, db, "admin")
+	replicas := newTestReplicas(t, app, db, 8)
+
+	giftCard, err := app.usecases.GiftCards.Add(&request.GiftCardRequest{Value: 100}, admin.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buyers := make(chan int64, len(replicas))
+	for i := range replicas {
+		buyer, _ := addTestCustomer(t, app, fmt.Sprintf("buyer%d", i), 100000)
+		buyers <- buyer.ID
+	}
+
+	// Half of the buyers spend the card at checkout, the other half redeem it
+	// into their wallet.
+	spends := make(chan bool, len(replicas))
+	for i := range replicas {
+		spends <- i%2 == 0
+	}
+
+	errs := runConcurrently(replicas, func(app *application) error {
+		buyerID := <-buyers
+
+		if <-spends {
+			_, err := app.usecases.Orders.Add(&request.OrderRequest{
+				TicketID:     ticket.ID,
+				Quantity:     1,
+				GiftCardCode: giftCard.Code,
+			}, buyerID)
+			return err
+		}
+
+		_, err := app.usecases.GiftCards.Redeem(&request.GiftCardRedemptionRequest{
+			Code: giftCard.Code,
+		}, buyerID)
+		return err
+	})
+
+	if succeeded := countSuccesses(t, errs, utils.ErrGiftCardUnavailable); succeeded != 1 {
+		t.Fatalf("got the gift card spent %d times, want 1", succeeded)
+	}
+
+	var balance, ledger float64
+
+	err = db.QueryRow("SELECT balance FROM gift_cards WHERE id = $1", giftCard.ID).Scan(&balance)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.QueryRow("SELECT SUM(amount) FROM gift_card_transactions WHERE gift_card_id = $1", giftCard.ID).Scan(&ledger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if balance != 0 || ledger != 0 {
+		t.Errorf("got balance %.2f and ledger %.2f, want both 0.00", balance, ledger)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/payment"
+)
+
+func TestRefundTopUp(t *testing.T) {
+	app, db := newTestAppWithProvider(t, payment.NewFakeProvider("test-webhook-secret"))
+
+	_, adminToken := addTestAdmin(t, app, db, "admin")
+	customer, _ := addTestCustomer(t, app, "customer", 0)
+
+	topUp, err := app.usecases.Payments.AddTopUp(&request.TopUpRequest{Amount: 50}, customer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = app.usecases.Payments.Simulate(&request.PaymentSimulationRequest{
+		IntentID: topUp.IntentID,
+		Outcome:  payment.EventPaymentSucceeded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := doTestRequest(t, app, http.MethodPost, fmt.Sprintf("/api/top-ups/%d/refund", topUp.ID), adminToken, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	refunded, err := app.usecases.Customers.GetByID(customer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refunded.Balance != 0 {
+		t.Errorf("got balance %.2f, want 0.00", refunded.Balance)
+	}
+
+	var status domain.PaymentRefundStatus
+
+	query := "SELECT status FROM payment_refunds WHERE top_up_id = $1"
+
+	err = db.QueryRow(query, topUp.ID).Scan(&status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != domain.PaymentRefundStatusPending {
+		t.Fatalf("got refund %s, want %s", status, domain.PaymentRefundStatusPending)
+	}
+
+	err = app.usecases.Payments.ProcessRefunds()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.QueryRow(query, topUp.ID).Scan(&status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != domain.PaymentRefundStatusSucceeded {
+		t.Fatalf("got refund %s, want %s", status, domain.PaymentRefundStatusSucceeded)
+	}
+
+	rec = doTestRequest(t, app, http.MethodPost, fmt.Sprintf("/api/top-ups/%d/refund", topUp.ID), adminToken, nil)
+	expectStatus(t, rec, http.StatusBadRequest)
+}
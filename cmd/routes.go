@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
 	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
 )
 
@@ -14,6 +15,9 @@ func (app *application) routes() *gin.Engine {
 	r.Use(app.RequestLogger())
 	r.Use(gin.Recovery())
 
+	requireAdmin := app.RequireRole(domain.RoleAdmin)
+	requireScanner := app.RequireRole(domain.RoleScanner, domain.RoleAdmin)
+
 	r.GET("/api", func(c *gin.Context) {
 		message := fmt.Sprintf("API is running on port %d", app.config.Port)
 		c.String(http.StatusOK, message)
@@ -24,18 +28,107 @@ func (app *application) routes() *gin.Engine {
 	r.POST("/api/customers", app.handlers.Customers.Add)
 	r.GET("/api/customers", app.Authenticate(), app.handlers.Customers.GetAll)
 	r.GET("/api/customers/:id", app.Authenticate(), app.handlers.Customers.GetByID)
-	r.PATCH("/api/customers/:id/balances", app.Authenticate(), app.handlers.Customers.UpdateBalance)
+	r.PATCH("/api/customers/:id/balances", app.Authenticate(), requireAdmin, app.handlers.Customers.UpdateBalance)
+
+	r.GET("/api/top-ups", app.Authenticate(), app.handlers.Payments.GetTopUps)
+	r.POST("/api/top-ups", app.Authenticate(), app.handlers.Payments.AddTopUp)
+	r.POST("/api/top-ups/:id/refund", app.Authenticate(), requireAdmin, app.handlers.Payments.RefundTopUp)
+
+	r.GET("/api/gift-cards", app.Authenticate(), app.handlers.GiftCards.GetAll)
+	r.POST("/api/gift-cards", app.Authenticate(), app.handlers.GiftCards.Add)
+	r.GET("/api/gift-cards/:code", app.Authenticate(), app.handlers.GiftCards.GetByCode)
+	r.POST("/api/gift-cards/redemption", app.Authenticate(), app.handlers.GiftCards.Redeem)
+
+	r.POST("/api/payments/webhook", app.handlers.Payments.Webhook)
+	if app.config.Payments.Simulation {
+		r.POST("/api/payments/simulation", app.Authenticate(), requireAdmin, app.handlers.Payments.Simulate) // Intended solely for local development
+	}
+
+	r.GET("/api/categories", app.handlers.Events.GetCategories)
+	r.GET("/api/images/:id/:variant", app.handlers.EventImages.Serve)
 
 	r.GET("/api/events", app.handlers.Events.GetAll)
+	r.GET("/api/events/search", app.handlers.Events.Search)
 	r.GET("/api/events/:id", app.handlers.Events.GetByID)
+	r.PATCH("/api/events/:id/purchase-limit", app.Authenticate(), requireAdmin, app.handlers.Events.UpdatePurchaseLimit)
+	r.PATCH("/api/events/:id/booking-fee", app.Authenticate(), requireAdmin, app.handlers.Events.UpdateBookingFee)
+	r.PATCH("/api/events/:id/venue", app.Authenticate(), requireAdmin, app.handlers.Events.UpdateVenue)
+	r.PATCH("/api/events/:id/schedule", app.Authenticate(), requireAdmin, app.handlers.Events.UpdateSchedule)
+	r.PATCH("/api/events/:id/details", app.Authenticate(), requireAdmin, app.handlers.Events.UpdateDetails)
+	r.GET("/api/events/:id/images", app.handlers.EventImages.GetAll)
+	r.POST("/api/events/:id/images", app.Authenticate(), requireAdmin, app.handlers.EventImages.Add)
+	r.DELETE("/api/events/:id/images/:image_id", app.Authenticate(), requireAdmin, app.handlers.EventImages.Delete)
+	r.GET("/api/events/:id/seats", app.handlers.Events.GetSeats)
+	r.POST("/api/events/:id/cancellation", app.Authenticate(), requireAdmin, app.handlers.Events.Cancel)
+	r.GET("/api/events/:id/cancellation", app.Authenticate(), requireAdmin, app.handlers.Events.GetCancellation)
+	r.GET("/api/events/:id/check-ins", app.Authenticate(), requireScanner, app.handlers.CheckIns.GetStats)
+
+	r.GET("/api/event-series/:id", app.handlers.EventSeries.GetByID)
+	r.POST("/api/event-series", app.Authenticate(), requireAdmin, app.handlers.EventSeries.Add)
+	r.PATCH("/api/event-series/:id", app.Authenticate(), requireAdmin, app.handlers.EventSeries.Update)
+
+	r.GET("/api/venues", app.handlers.Venues.GetAll)
+	r.GET("/api/venues/:id", app.handlers.Venues.GetByID)
+	r.POST("/api/venues", app.Authenticate(), requireAdmin, app.handlers.Venues.Add)
+	r.PATCH("/api/venues/:id", app.Authenticate(), requireAdmin, app.handlers.Venues.Update)
+	r.DELETE("/api/venues/:id", app.Authenticate(), requireAdmin, app.handlers.Venues.Delete)
+
+	r.GET("/api/promo-codes", app.Authenticate(), requireAdmin, app.handlers.PromoCodes.GetAll)
+	r.GET("/api/promo-codes/:id", app.Authenticate(), requireAdmin, app.handlers.PromoCodes.GetByID)
+	r.POST("/api/promo-codes", app.Authenticate(), requireAdmin, app.handlers.PromoCodes.Add)
+	r.PATCH("/api/promo-codes/:id/limits", app.Authenticate(), requireAdmin, app.handlers.PromoCodes.UpdateLimits)
+
+	r.GET("/api/tax-rates", app.Authenticate(), requireAdmin, app.handlers.TaxRates.GetAll)
+	r.POST("/api/tax-rates", app.Authenticate(), requireAdmin, app.handlers.TaxRates.Add)
+	r.PATCH("/api/tax-rates/:id", app.Authenticate(), requireAdmin, app.handlers.TaxRates.Update)
+	r.DELETE("/api/tax-rates/:id", app.Authenticate(), requireAdmin, app.handlers.TaxRates.Delete)
+
+	r.GET("/api/exchange-rates", app.Authenticate(), requireAdmin, app.handlers.ExchangeRates.GetAll)
+	r.POST("/api/exchange-rates", app.Authenticate(), requireAdmin, app.handlers.ExchangeRates.Set)
+	r.DELETE("/api/exchange-rates/:id", app.Authenticate(), requireAdmin, app.handlers.ExchangeRates.Delete)
 
 	r.GET("/api/tickets", app.handlers.Tickets.GetAll)
 	r.GET("/api/tickets/:id", app.handlers.Tickets.GetByID)
-	r.PATCH("/api/tickets/:id/quantities", app.Authenticate(), app.handlers.Tickets.UpdateQuantity) // Intended solely for concurrency testing purpose
+	r.PATCH("/api/tickets/:id/sales-window", app.Authenticate(), requireAdmin, app.handlers.Tickets.UpdateSalesWindow)
+	r.PATCH("/api/tickets/:id/purchase-limit", app.Authenticate(), requireAdmin, app.handlers.Tickets.UpdatePurchaseLimit)
+	r.PATCH("/api/tickets/:id/seats", app.Authenticate(), requireAdmin, app.handlers.Tickets.AssignSeats)
+	r.GET("/api/tickets/:id/presales", app.Authenticate(), requireAdmin, app.handlers.Tickets.GetPresales)
+	r.POST("/api/tickets/:id/presales", app.Authenticate(), requireAdmin, app.handlers.Tickets.AddPresale)
+	r.GET("/api/tickets/:id/price", app.handlers.Tickets.GetPrice)
+	r.GET("/api/tickets/:id/price-tiers", app.Authenticate(), requireAdmin, app.handlers.Tickets.GetPriceTiers)
+	r.POST("/api/tickets/:id/price-tiers", app.Authenticate(), requireAdmin, app.handlers.Tickets.AddPriceTier)
+	r.DELETE("/api/tickets/:id/price-tiers/:tier_id", app.Authenticate(), requireAdmin, app.handlers.Tickets.DeletePriceTier)
+	r.POST("/api/tickets/:id/waitlist", app.Authenticate(), app.handlers.Waitlists.Join)
+	r.DELETE("/api/tickets/:id/waitlist", app.Authenticate(), app.handlers.Waitlists.Leave)
+	r.PATCH("/api/tickets/:id/quantities", app.Authenticate(), requireAdmin, app.handlers.Tickets.UpdateQuantity) // Intended solely for concurrency testing purpose
 
 	r.GET("/api/orders", app.Authenticate(), app.handlers.Orders.GetAll)
 	r.POST("/api/orders", app.Authenticate(), app.handlers.Orders.Add)
-	r.DELETE("/api/orders", app.Authenticate(), app.handlers.Orders.DeleteAll) // Intended solely for concurrency testing purpose
+	r.GET("/api/orders/:id/tickets", app.Authenticate(), app.handlers.Orders.GetTickets)
+	r.GET("/api/orders/:id/tickets.pdf", app.Authenticate(), app.handlers.Orders.RenderOrderTickets)
+	r.GET("/api/orders/:id/tickets/:code", app.Authenticate(), app.handlers.Orders.RenderOrderTicket)
+	r.POST("/api/orders/:id/cancellation", app.Authenticate(), app.handlers.Orders.Cancel)
+	r.DELETE("/api/orders", app.Authenticate(), requireAdmin, app.handlers.Orders.DeleteAll) // Intended solely for concurrency testing purpose
+
+	r.GET("/api/check-ins/signing-key", app.Authenticate(), requireScanner, app.handlers.CheckIns.GetSigningKey)
+	r.POST("/api/check-ins", app.Authenticate(), requireScanner, app.handlers.CheckIns.CheckIn)
+
+	r.GET("/api/issued-tickets", app.Authenticate(), app.handlers.Orders.GetCustomerTickets)
+	r.GET("/api/issued-tickets.pdf", app.Authenticate(), app.handlers.Orders.RenderTickets)
+	r.GET("/api/issued-tickets/:code", app.Authenticate(), app.handlers.Orders.RenderTicket)
+
+	r.GET("/api/waitlist", app.Authenticate(), app.handlers.Waitlists.GetAll)
+	r.GET("/api/reminders", app.Authenticate(), app.handlers.Events.GetReminders)
+
+	r.GET("/api/transfers", app.Authenticate(), app.handlers.Transfers.GetAll)
+	r.POST("/api/transfers", app.Authenticate(), app.handlers.Transfers.Add)
+	r.POST("/api/transfers/:id/acceptance", app.Authenticate(), app.handlers.Transfers.Accept)
+	r.POST("/api/transfers/:id/cancellation", app.Authenticate(), app.handlers.Transfers.Cancel)
+
+	r.GET("/api/resale-listings", app.handlers.Resales.GetAll)
+	r.POST("/api/resale-listings", app.Authenticate(), app.handlers.Resales.Add)
+	r.POST("/api/resale-listings/:id/purchase", app.Authenticate(), app.handlers.Resales.Purchase)
+	r.POST("/api/resale-listings/:id/cancellation", app.Authenticate(), app.handlers.Resales.Cancel)
 
 	return r
 }
@@ -22,24 +22,24 @@ var ticketTypeInputs = []*request.TicketTypeRequest{
 
 var eventInputs = []*request.EventRequest{
 	{
-		Name: "Event 1",
-		Date: time.Now().AddDate(0, 0, 14),
+		Name:     "Event 1",
+		StartsAt: time.Now().AddDate(0, 0, 14),
 	},
 	{
-		Name: "Event 2",
-		Date: time.Now().AddDate(0, 1, 0),
+		Name:     "Event 2",
+		StartsAt: time.Now().AddDate(0, 1, 0),
 	},
 	{
-		Name: "Event 3",
-		Date: time.Now().AddDate(0, 1, 14),
+		Name:     "Event 3",
+		StartsAt: time.Now().AddDate(0, 1, 14),
 	},
 	{
-		Name: "Event 4",
-		Date: time.Now().AddDate(0, 2, 0),
+		Name:     "Event 4",
+		StartsAt: time.Now().AddDate(0, 2, 0),
 	},
 	{
-		Name: "Event 5",
-		Date: time.Now().AddDate(0, 2, 14),
+		Name:     "Event 5",
+		StartsAt: time.Now().AddDate(0, 2, 14),
 	},
 }
 
@@ -55,7 +55,7 @@ func prepopulateTicketTypes(usecase usecase.ITicketTypeUsecase) {
 }
 
 func prepopulateEventsAndTickets(eventUsecase usecase.IEventUsecase, ticketUsecase usecase.ITicketUsecase) {
-	events, _ := eventUsecase.GetAll()
+	events, _, _ := eventUsecase.GetAll(&request.EventFilterRequest{Limit: 1})
 	if len(events) != 0 {
 		return
 	}
@@ -10,6 +10,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
 	"github.com/rs/zerolog/log"
 )
 
@@ -20,6 +21,12 @@ func (app *application) serve() error {
 	}
 
 	shutdownError := make(chan error)
+	stopJobs := make(chan struct{})
+
+	app.processWaitlistOffers(stopJobs)
+	app.expireUnpaidOrders(stopJobs)
+	app.processRefunds(stopJobs)
+	app.resumeCancellations(stopJobs)
 
 	go func() {
 		quit := make(chan os.Signal, 1)
@@ -31,7 +38,17 @@ func (app *application) serve() error {
 		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 		defer cancel()
 
-		shutdownError <- srv.Shutdown(ctx)
+		err := srv.Shutdown(ctx)
+		if err != nil {
+			shutdownError <- err
+			return
+		}
+
+		log.Info().Msg("completing background tasks")
+
+		close(stopJobs)
+		utils.WaitBackground()
+		shutdownError <- nil
 	}()
 
 	log.Info().Msg("starting server on " + srv.Addr)
@@ -49,3 +66,79 @@ func (app *application) serve() error {
 	log.Info().Msg("stopped server")
 	return nil
 }
+
+func (app *application) processWaitlistOffers(stop <-chan struct{}) {
+	utils.Background(func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				err := app.usecases.Waitlists.ProcessOffers()
+				if err != nil {
+					log.Error().Msg(err.Error())
+				}
+			}
+		}
+	})
+}
+
+func (app *application) expireUnpaidOrders(stop <-chan struct{}) {
+	utils.Background(func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				err := app.usecases.Orders.ExpireUnpaid()
+				if err != nil {
+					log.Error().Msg(err.Error())
+				}
+			}
+		}
+	})
+}
+
+func (app *application) processRefunds(stop <-chan struct{}) {
+	utils.Background(func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				err := app.usecases.Payments.ProcessRefunds()
+				if err != nil {
+					log.Error().Msg(err.Error())
+				}
+			}
+		}
+	})
+}
+
+func (app *application) resumeCancellations(stop <-chan struct{}) {
+	utils.Background(func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				err := app.usecases.Events.ResumeCancellations()
+				if err != nil {
+					log.Error().Msg(err.Error())
+				}
+			}
+		}
+	})
+}
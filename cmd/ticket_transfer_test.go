@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+func TestAcceptTransferAfterReschedule(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	ticket := addTestTicket(t, app, nil)
+	sender, _ := addTestCustomer(t, app, "sender", 1000)
+	recipient, _ := addTestCustomer(t, app, "recipient", 0)
+
+	order, err := app.usecases.Orders.Add(&request.OrderRequest{
+		TicketID: ticket.ID,
+		Quantity: 1,
+	}, sender.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transfer, err := app.usecases.Transfers.Add(&request.TicketTransferRequest{
+		RecipientUsername: recipient.Username,
+		OrderID:           order.ID,
+	}, sender.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.usecases.Events.UpdateSchedule(ticket.EventID, &request.EventScheduleRequest{
+		StartsAt: time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.usecases.Transfers.Accept(transfer.ID, recipient.ID)
+	if !errors.Is(err, utils.ErrTicketTransferClosed) {
+		t.Fatalf("got %v, want %v", err, utils.ErrTicketTransferClosed)
+	}
+}
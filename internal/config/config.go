@@ -1,11 +1,44 @@
 package config
 
+import "time"
+
 type Config struct {
-	Port int
-	DB   struct {
+	Port     int
+	Currency string
+	DB       struct {
 		DSN string
 	}
 	JWT struct {
 		Secret string
 	}
+	TicketSigning struct {
+		Seed string
+	}
+	Storage struct {
+		Backend string
+		Dir     string
+		S3      struct {
+			Endpoint  string
+			Bucket    string
+			Region    string
+			AccessKey string
+			SecretKey string
+		}
+	}
+	Payments struct {
+		Provider      string
+		WebhookSecret string
+		Timeout       time.Duration
+		Simulation    bool
+	}
+	Images struct {
+		MaxSize int64
+	}
+	Resale struct {
+		MaxPriceRatio float64
+		FeeRate       float64
+	}
+	Transfers struct {
+		Cutoff time.Duration
+	}
 }
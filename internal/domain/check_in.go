@@ -0,0 +1,16 @@
+package domain
+
+type CheckInStats struct {
+	EventID   int64                 `json:"event_id"`
+	Issued    int                   `json:"issued"`
+	CheckedIn int                   `json:"checked_in"`
+	Remaining int                   `json:"remaining"`
+	Tickets   []*TicketCheckInStats `json:"tickets"`
+}
+
+type TicketCheckInStats struct {
+	TicketID  int64          `json:"ticket_id"`
+	Type      TicketTypeName `json:"type"`
+	Issued    int            `json:"issued"`
+	CheckedIn int            `json:"checked_in"`
+}
@@ -6,11 +6,21 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+type CustomerRole string
+
+var (
+	RoleCustomer CustomerRole = "customer"
+	RoleScanner  CustomerRole = "scanner"
+	RoleAdmin    CustomerRole = "admin"
+)
+
 type Customer struct {
-	ID       int64    `json:"id"`
-	Username string   `json:"username"`
-	Password password `json:"-"`
-	Balance  float64  `json:"balance"`
+	ID       int64        `json:"id"`
+	Username string       `json:"username"`
+	Password password     `json:"-"`
+	Balance  float64      `json:"balance"`
+	Currency string       `json:"currency"`
+	Role     CustomerRole `json:"role"`
 }
 
 type password []byte
@@ -2,8 +2,170 @@ package domain
 
 import "time"
 
+type EventStatus string
+
+var (
+	EventStatusScheduled EventStatus = "scheduled"
+	EventStatusCancelled EventStatus = "cancelled"
+)
+
+type EventCategory string
+
+var (
+	EventCategoryConcert    EventCategory = "concert"
+	EventCategorySport      EventCategory = "sport"
+	EventCategoryTheatre    EventCategory = "theatre"
+	EventCategoryComedy     EventCategory = "comedy"
+	EventCategoryFestival   EventCategory = "festival"
+	EventCategoryConference EventCategory = "conference"
+	EventCategoryExhibition EventCategory = "exhibition"
+	EventCategoryOther      EventCategory = "other"
+)
+
+var EventCategories = []EventCategory{
+	EventCategoryConcert,
+	EventCategorySport,
+	EventCategoryTheatre,
+	EventCategoryComedy,
+	EventCategoryFestival,
+	EventCategoryConference,
+	EventCategoryExhibition,
+	EventCategoryOther,
+}
+
+const reminderHour = 9
+
 type Event struct {
-	ID   int64     `json:"id"`
-	Name string    `json:"name"`
-	Date time.Time `json:"date"`
+	ID                    int64            `json:"id"`
+	Name                  string           `json:"name"`
+	StartsAt              time.Time        `json:"starts_at"`
+	EndsAt                *time.Time       `json:"ends_at"`
+	DoorsOpenAt           *time.Time       `json:"doors_open_at"`
+	Timezone              string           `json:"timezone"`
+	Local                 *EventLocalTimes `json:"local"`
+	Date                  time.Time        `json:"date"` // Deprecated: use StartsAt.
+	Status                EventStatus      `json:"status"`
+	MaxTicketsPerCustomer *int             `json:"max_tickets_per_customer"`
+	VenueID               *int64           `json:"venue_id"`
+	SeriesID              *int64           `json:"series_id"`
+	Description           string           `json:"description"`
+	DescriptionHTML       string           `json:"description_html"`
+	Category              *EventCategory   `json:"category"`
+	Tags                  []string         `json:"tags"`
+	MinAge                *int             `json:"min_age"`
+	Organizer             Organizer        `json:"organizer"`
+	BookingFee            BookingFee       `json:"booking_fee"`
+}
+
+type Organizer struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Website string `json:"website"`
+}
+
+type BookingFee struct {
+	Fixed      float64 `json:"fixed"`
+	Percentage float64 `json:"percentage"`
+	Currency   *string `json:"currency"`
+}
+
+type CategoryCount struct {
+	Name       EventCategory `json:"name"`
+	EventCount int           `json:"event_count"`
+}
+
+type EventLocalTimes struct {
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	DoorsOpenAt *time.Time `json:"doors_open_at"`
+}
+
+func (e *Event) Location() *time.Location {
+	location, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+func (e *Event) Localize() {
+	location := e.Location()
+
+	e.StartsAt = e.StartsAt.UTC()
+	e.Date = e.StartsAt
+	e.Local = &EventLocalTimes{
+		StartsAt: e.StartsAt.In(location),
+	}
+
+	if e.EndsAt != nil {
+		endsAt := e.EndsAt.UTC()
+		localEndsAt := endsAt.In(location)
+		e.EndsAt = &endsAt
+		e.Local.EndsAt = &localEndsAt
+	}
+
+	if e.DoorsOpenAt != nil {
+		doorsOpenAt := e.DoorsOpenAt.UTC()
+		localDoorsOpenAt := doorsOpenAt.In(location)
+		e.DoorsOpenAt = &doorsOpenAt
+		e.Local.DoorsOpenAt = &localDoorsOpenAt
+	}
+}
+
+func (e *Event) SalesCutoff() time.Time {
+	return e.StartsAt
+}
+
+func (e *Event) ReminderAt() time.Time {
+	start := e.StartsAt.In(e.Location())
+	return time.Date(start.Year(), start.Month(), start.Day()-1, reminderHour, 0, 0, 0, start.Location())
+}
+
+type EventDetail struct {
+	Event
+	Venue   *Venue
+	Tickets []*TicketDetail
+}
+
+var EventSorts = []string{"starts_at", "-starts_at", "name", "-name"}
+
+type EventFilters struct {
+	Query     string
+	From      *time.Time
+	To        *time.Time
+	VenueID   *int64
+	Category  *EventCategory
+	Tag       string
+	Available bool
+	Sort      string
+	Cursor    *EventCursor
+	Limit     int
+}
+
+type EventCursor struct {
+	StartsAt time.Time `json:"starts_at"`
+	Name     string    `json:"name"`
+	ID       int64     `json:"id"`
+}
+
+func NewEventCursor(event *Event) *EventCursor {
+	return &EventCursor{
+		StartsAt: event.StartsAt,
+		Name:     event.Name,
+		ID:       event.ID,
+	}
+}
+
+const (
+	HeadlineStart = "[["
+	HeadlineStop  = "]]"
+)
+
+type EventMatch struct {
+	Event
+	Rank                float64
+	NameHeadline        string
+	VenueHeadline       *string
+	DescriptionHeadline *string
+	TagsHeadline        *string
 }
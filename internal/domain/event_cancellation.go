@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+type EventCancellationStatus string
+
+var (
+	EventCancellationStatusInProgress EventCancellationStatus = "in_progress"
+	EventCancellationStatusCompleted  EventCancellationStatus = "completed"
+)
+
+type EventCancellation struct {
+	ID              int64                   `json:"id"`
+	EventID         int64                   `json:"event_id"`
+	Status          EventCancellationStatus `json:"status"`
+	RefundedOrders  int                     `json:"refunded_orders"`
+	RefundedAmounts map[string]float64      `json:"refunded_amounts"`
+	LastOrderID     int64                   `json:"last_order_id"`
+	CreatedAt       time.Time               `json:"created_at"`
+	CompletedAt     *time.Time              `json:"completed_at"`
+}
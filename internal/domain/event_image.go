@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type ImageVariant string
+
+var (
+	ImageVariantOriginal  ImageVariant = "original"
+	ImageVariantLarge     ImageVariant = "large"
+	ImageVariantThumbnail ImageVariant = "thumbnail"
+)
+
+var ImageVariants = []ImageVariant{
+	ImageVariantOriginal,
+	ImageVariantLarge,
+	ImageVariantThumbnail,
+}
+
+var ImageBounds = map[ImageVariant]int{
+	ImageVariantLarge:     1280,
+	ImageVariantThumbnail: 320,
+}
+
+type EventImage struct {
+	ID          int64                   `json:"id"`
+	EventID     int64                   `json:"event_id"`
+	Key         string                  `json:"-"`
+	ContentType string                  `json:"content_type"`
+	Width       int                     `json:"width"`
+	Height      int                     `json:"height"`
+	Size        int64                   `json:"size"`
+	CreatedAt   time.Time               `json:"created_at"`
+	URLs        map[ImageVariant]string `json:"urls"`
+}
+
+func (i *EventImage) VariantKey(variant ImageVariant) string {
+	return fmt.Sprintf("%s/%s", i.Key, variant)
+}
+
+func (i *EventImage) VariantContentType(variant ImageVariant) string {
+	if variant != ImageVariantOriginal && i.ContentType == "image/gif" {
+		return "image/png"
+	}
+	return i.ContentType
+}
+
+func (i *EventImage) SetURLs() {
+	i.URLs = make(map[ImageVariant]string, len(ImageVariants))
+	for _, variant := range ImageVariants {
+		i.URLs[variant] = fmt.Sprintf("/api/images/%d/%s", i.ID, variant)
+	}
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+var (
+	RecurrenceFrequencyDaily  RecurrenceFrequency = "daily"
+	RecurrenceFrequencyWeekly RecurrenceFrequency = "weekly"
+)
+
+var Weekdays = []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+
+type EventSeries struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Timezone   string     `json:"timezone"`
+	Recurrence Recurrence `json:"recurrence"`
+	Events     []*Event   `json:"events"`
+}
+
+type Recurrence struct {
+	Frequency RecurrenceFrequency `json:"frequency"`
+	Interval  int                 `json:"interval"`
+	ByDay     []string            `json:"by_day"`
+	Until     *time.Time          `json:"until"`
+	Count     *int                `json:"count"`
+}
+
+func (r *Recurrence) Occurrences(start time.Time, limit int) ([]time.Time, bool) {
+	occurrences := []time.Time{start}
+
+	interval := max(r.Interval, 1)
+	year, month, day := start.Date()
+	hour, minute, second := start.Clock()
+	at := func(days int) time.Time {
+		return time.Date(year, month, day+days, hour, minute, second, 0, start.Location())
+	}
+
+	var offsets []int
+	switch r.Frequency {
+	case RecurrenceFrequencyWeekly:
+		monday := -weekdayIndex(start.Weekday())
+		for i, code := range Weekdays {
+			if len(r.ByDay) == 0 && i == -monday || slices.Contains(r.ByDay, code) {
+				offsets = append(offsets, monday+i)
+			}
+		}
+	default:
+		offsets = []int{0}
+	}
+
+	if len(offsets) == 0 {
+		return occurrences, true
+	}
+
+	period := interval
+	if r.Frequency == RecurrenceFrequencyWeekly {
+		period *= 7
+	}
+
+	for n := 0; ; n++ {
+		for _, offset := range offsets {
+			occurrence := at(n*period + offset)
+			if !occurrence.After(start) {
+				continue
+			}
+
+			if r.Until != nil && occurrence.After(*r.Until) {
+				return occurrences, true
+			}
+			if r.Count != nil && len(occurrences) >= *r.Count {
+				return occurrences, true
+			}
+			if len(occurrences) >= limit {
+				return nil, false
+			}
+
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+}
+
+func weekdayIndex(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+type ExchangeRate struct {
+	ID            int64     `json:"id"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          float64   `json:"rate"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (r *ExchangeRate) Convert(amount float64) float64 {
+	return RoundCents(amount * r.Rate)
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+type GiftCardTransactionType string
+
+var (
+	GiftCardTransactionPurchase   GiftCardTransactionType = "purchase"
+	GiftCardTransactionIssue      GiftCardTransactionType = "issue"
+	GiftCardTransactionRedemption GiftCardTransactionType = "redemption"
+	GiftCardTransactionCheckout   GiftCardTransactionType = "checkout"
+	GiftCardTransactionRefund     GiftCardTransactionType = "refund"
+)
+
+type GiftCard struct {
+	ID           int64                  `json:"id"`
+	Code         string                 `json:"code"`
+	Value        float64                `json:"value"`
+	Balance      float64                `json:"balance"`
+	Currency     string                 `json:"currency"`
+	PurchaserID  int64                  `json:"purchaser_id"`
+	ExpiresAt    *time.Time             `json:"expires_at"`
+	CreatedAt    time.Time              `json:"created_at"`
+	Transactions []*GiftCardTransaction `json:"transactions"`
+}
+
+func (g *GiftCard) Expired(at time.Time) bool {
+	return g.ExpiresAt != nil && !at.Before(*g.ExpiresAt)
+}
+
+func (g *GiftCard) Cover(due float64, rate *ExchangeRate) (covered float64, spent float64) {
+	available := rate.Convert(g.Balance)
+	if available <= due {
+		return available, g.Balance
+	}
+	return due, min(RoundCents(due/rate.Rate), g.Balance)
+}
+
+type GiftCardTransaction struct {
+	ID         int64                   `json:"id"`
+	GiftCardID int64                   `json:"gift_card_id"`
+	CustomerID int64                   `json:"customer_id"`
+	OrderID    *int64                  `json:"order_id"`
+	Type       GiftCardTransactionType `json:"type"`
+	Amount     float64                 `json:"amount"`
+	CreatedAt  time.Time               `json:"created_at"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+type IssuedTicketStatus string
+
+var (
+	IssuedTicketStatusValid   IssuedTicketStatus = "valid"
+	IssuedTicketStatusVoid    IssuedTicketStatus = "void"
+	IssuedTicketStatusPending IssuedTicketStatus = "pending"
+)
+
+type IssuedTicket struct {
+	ID          int64              `json:"id"`
+	OrderID     int64              `json:"order_id"`
+	OrderItemID int64              `json:"order_item_id"`
+	TicketID    int64              `json:"ticket_id"`
+	EventID     int64              `json:"event_id"`
+	CustomerID  int64              `json:"customer_id"`
+	Code        string             `json:"code"`
+	Status      IssuedTicketStatus `json:"status"`
+	CreatedAt   time.Time          `json:"created_at"`
+	CheckedInAt *time.Time         `json:"checked_in_at"`
+	CheckedInBy *int64             `json:"checked_in_by"`
+	SeatID      *int64             `json:"seat_id"`
+	Token       string             `json:"token,omitempty"`
+}
@@ -1,12 +1,123 @@
 package domain
 
-import "time"
+import (
+	"math"
+	"time"
+)
+
+type OrderStatus string
+
+var (
+	OrderStatusPending       OrderStatus = "pending"
+	OrderStatusCompleted     OrderStatus = "completed"
+	OrderStatusRefunded      OrderStatus = "refunded"
+	OrderStatusCancelled     OrderStatus = "cancelled"
+	OrderStatusFailed        OrderStatus = "failed"
+	OrderStatusExpired       OrderStatus = "expired"
+	OrderStatusRefundPending OrderStatus = "refund_pending"
+)
+
+type PaymentMethod string
+
+var (
+	PaymentMethodBalance  PaymentMethod = "balance"
+	PaymentMethodProvider PaymentMethod = "provider"
+)
 
 type Order struct {
-	ID         int64     `json:"id"`
-	CustomerID int64     `json:"customer_id"`
-	TicketID   int64     `json:"ticket_id"`
-	Quantity   int       `json:"quantity"`
-	TotalPrice float64   `json:"total_price"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID              int64         `json:"id"`
+	CustomerID      int64         `json:"customer_id"`
+	EventID         int64         `json:"event_id"`
+	Currency        string        `json:"currency"`
+	Subtotal        float64       `json:"subtotal"`
+	Discount        float64       `json:"discount"`
+	PromoCodeID     *int64        `json:"promo_code_id"`
+	BookingFee      float64       `json:"booking_fee"`
+	Tax             float64       `json:"tax"`
+	TaxRate         float64       `json:"tax_rate"`
+	TaxJurisdiction *string       `json:"tax_jurisdiction"`
+	TotalPrice      float64       `json:"total_price"`
+	ChargedCurrency string        `json:"charged_currency"`
+	ExchangeRate    float64       `json:"exchange_rate"`
+	GiftCardID      *int64        `json:"gift_card_id"`
+	GiftCardAmount  float64       `json:"gift_card_amount"`
+	ChargedAmount   float64       `json:"charged_amount"`
+	PaymentMethod   PaymentMethod `json:"payment_method"`
+	PaymentProvider *string       `json:"payment_provider"`
+	PaymentIntentID *string       `json:"payment_intent_id"`
+	ClientSecret    string        `json:"client_secret,omitempty"`
+	Status          OrderStatus   `json:"status"`
+	CreatedAt       time.Time     `json:"created_at"`
+	ExpiresAt       *time.Time    `json:"expires_at"`
+	Items           []*OrderItem  `json:"items"`
+}
+
+type OrderItem struct {
+	ID         int64             `json:"id"`
+	OrderID    int64             `json:"order_id"`
+	TicketID   int64             `json:"ticket_id"`
+	Quantity   int               `json:"quantity"`
+	UnitPrice  float64           `json:"unit_price"`
+	TotalPrice float64           `json:"total_price"`
+	SeatIDs    []int64           `json:"seat_ids,omitempty"`
+	Prices     []*OrderItemPrice `json:"prices"`
+
+	IssuedTickets []*IssuedTicket `json:"-"`
+}
+
+type OrderItemPrice struct {
+	TierID    *int64  `json:"tier_id"`
+	Name      string  `json:"name"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+}
+
+func (i *OrderItem) SetPrices(prices []*OrderItemPrice) {
+	i.Prices = prices
+	i.TotalPrice = 0
+	for _, price := range prices {
+		i.TotalPrice += float64(price.Quantity) * price.UnitPrice
+	}
+	i.TotalPrice = RoundCents(i.TotalPrice)
+	i.UnitPrice = RoundCents(i.TotalPrice / float64(i.Quantity))
+	if len(prices) == 1 {
+		i.UnitPrice = prices[0].UnitPrice
+	}
+}
+
+func (o *Order) SetTotal(fee BookingFee, taxRate *TaxRate) {
+	net := o.Subtotal - o.Discount
+
+	o.BookingFee = 0
+	if net > 0 {
+		o.BookingFee = RoundCents(fee.Fixed*float64(o.Quantity()) + net*fee.Percentage/100)
+	}
+
+	o.Tax = 0
+	o.TaxRate = 0
+	o.TaxJurisdiction = nil
+	if taxRate != nil {
+		o.Tax = RoundCents((net + o.BookingFee) * taxRate.Rate / 100)
+		o.TaxRate = taxRate.Rate
+		o.TaxJurisdiction = &taxRate.Jurisdiction
+	}
+
+	o.TotalPrice = RoundCents(net + o.BookingFee + o.Tax)
+}
+
+func (o *Order) Quantity() int {
+	quantity := 0
+	for _, item := range o.Items {
+		quantity += item.Quantity
+	}
+	return quantity
+}
+
+type PurchaseLimit struct {
+	MaxPerTicket map[int64]int
+	MaxPerEvent  *int
+}
+
+func RoundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
 }
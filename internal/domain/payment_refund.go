@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type PaymentRefundStatus string
+
+var (
+	PaymentRefundStatusPending   PaymentRefundStatus = "pending"
+	PaymentRefundStatusSucceeded PaymentRefundStatus = "succeeded"
+)
+
+type PaymentRefund struct {
+	ID         int64               `json:"id"`
+	OrderID    *int64              `json:"order_id"`
+	TopUpID    *int64              `json:"top_up_id"`
+	Provider   string              `json:"provider"`
+	IntentID   string              `json:"intent_id"`
+	Amount     float64             `json:"amount"`
+	Currency   string              `json:"currency"`
+	Status     PaymentRefundStatus `json:"status"`
+	Attempts   int                 `json:"attempts"`
+	LastError  *string             `json:"last_error"`
+	CreatedAt  time.Time           `json:"created_at"`
+	RefundedAt *time.Time          `json:"refunded_at"`
+}
+
+// IdempotencyKey is resent with a refund retried after a crash, so the provider
+// only pays it once.
+func (r *PaymentRefund) IdempotencyKey() string {
+	return fmt.Sprintf("refund-%d", r.ID)
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+type Presale struct {
+	ID          int64     `json:"id"`
+	TicketID    int64     `json:"ticket_id"`
+	Name        string    `json:"name"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	AccessCode  *string   `json:"access_code"`
+	CustomerIDs []int64   `json:"customer_ids"`
+}
+
+func (p *Presale) Allows(customerID int64, accessCode string) bool {
+	if p.AccessCode != nil && accessCode != "" && *p.AccessCode == accessCode {
+		return true
+	}
+
+	for _, id := range p.CustomerIDs {
+		if id == customerID {
+			return true
+		}
+	}
+
+	return false
+}
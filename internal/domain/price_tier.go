@@ -0,0 +1,104 @@
+package domain
+
+import "time"
+
+const RegularPriceName = "regular"
+
+type PriceTier struct {
+	ID       int64      `json:"id"`
+	TicketID int64      `json:"ticket_id"`
+	Name     string     `json:"name"`
+	Price    float64    `json:"price"`
+	EndsAt   *time.Time `json:"ends_at"`
+	MaxSold  *int       `json:"max_sold"`
+	Sold     int        `json:"sold"`
+	Position int        `json:"position"`
+}
+
+func (t *PriceTier) Open(at time.Time) bool {
+	if t.EndsAt != nil && !at.Before(*t.EndsAt) {
+		return false
+	}
+	return t.MaxSold == nil || t.Sold < *t.MaxSold
+}
+
+func (t *PriceTier) Remaining() *int {
+	if t.MaxSold == nil {
+		return nil
+	}
+	remaining := *t.MaxSold - t.Sold
+	return &remaining
+}
+
+type PricePoint struct {
+	TierID    *int64     `json:"tier_id"`
+	Name      string     `json:"name"`
+	Price     float64    `json:"price"`
+	EndsAt    *time.Time `json:"ends_at"`
+	Remaining *int       `json:"remaining"`
+}
+
+func CurrentPrices(tiers []*PriceTier, regularPrice float64, at time.Time) (*PricePoint, *PricePoint) {
+	points := make([]*PricePoint, 0, 2)
+	for _, tier := range tiers {
+		if len(points) == 2 {
+			break
+		}
+		if !tier.Open(at) {
+			continue
+		}
+		points = append(points, &PricePoint{
+			TierID:    &tier.ID,
+			Name:      tier.Name,
+			Price:     tier.Price,
+			EndsAt:    tier.EndsAt,
+			Remaining: tier.Remaining(),
+		})
+	}
+
+	if len(points) < 2 {
+		points = append(points, &PricePoint{Name: RegularPriceName, Price: regularPrice})
+	}
+	if len(points) < 2 {
+		points = append(points, nil)
+	}
+
+	return points[0], points[1]
+}
+
+func AllocatePrices(tiers []*PriceTier, regularPrice float64, quantity int, at time.Time) []*OrderItemPrice {
+	prices := make([]*OrderItemPrice, 0, 1)
+
+	for _, tier := range tiers {
+		if quantity == 0 {
+			break
+		}
+		if !tier.Open(at) {
+			continue
+		}
+
+		taken := quantity
+		if remaining := tier.Remaining(); remaining != nil {
+			taken = min(taken, *remaining)
+		}
+
+		tier.Sold += taken
+		quantity -= taken
+		prices = append(prices, &OrderItemPrice{
+			TierID:    &tier.ID,
+			Name:      tier.Name,
+			Quantity:  taken,
+			UnitPrice: tier.Price,
+		})
+	}
+
+	if quantity > 0 {
+		prices = append(prices, &OrderItemPrice{
+			Name:      RegularPriceName,
+			Quantity:  quantity,
+			UnitPrice: regularPrice,
+		})
+	}
+
+	return prices
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+type DiscountType string
+
+var (
+	DiscountTypePercentage DiscountType = "percentage"
+	DiscountTypeFixed      DiscountType = "fixed"
+)
+
+type PromoCode struct {
+	ID             int64        `json:"id"`
+	Code           string       `json:"code"`
+	DiscountType   DiscountType `json:"discount_type"`
+	DiscountValue  float64      `json:"discount_value"`
+	Currency       *string      `json:"currency"`
+	EventID        *int64       `json:"event_id"`
+	MaxRedemptions *int         `json:"max_redemptions"`
+	MaxPerCustomer *int         `json:"max_per_customer"`
+	ValidFrom      *time.Time   `json:"valid_from"`
+	ValidUntil     *time.Time   `json:"valid_until"`
+	TimesRedeemed  int          `json:"times_redeemed"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+func (p *PromoCode) Valid(at time.Time) bool {
+	if p.ValidFrom != nil && at.Before(*p.ValidFrom) {
+		return false
+	}
+	if p.ValidUntil != nil && !at.Before(*p.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+func (p *PromoCode) AppliesTo(eventID int64) bool {
+	return p.EventID == nil || *p.EventID == eventID
+}
+
+func (p *PromoCode) Discount(subtotal float64) float64 {
+	discount := p.DiscountValue
+	if p.DiscountType == DiscountTypePercentage {
+		discount = subtotal * p.DiscountValue / 100
+	}
+	return RoundCents(min(discount, subtotal))
+}
@@ -0,0 +1,7 @@
+package request
+
+type CheckInRequest struct {
+	EventID int64  `json:"event_id"`
+	Code    string `json:"code"`
+	Token   string `json:"token"`
+}
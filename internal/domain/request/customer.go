@@ -3,6 +3,7 @@ package request
 type CustomerRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	Currency string `json:"currency"`
 }
 
 type CustomerBalanceRequest struct {
@@ -1,8 +1,66 @@
 package request
 
-import "time"
+import (
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+)
+
+type EventFilterRequest struct {
+	Query     string
+	From      *time.Time
+	To        *time.Time
+	VenueID   *int64
+	Category  *domain.EventCategory
+	Tag       string
+	Available bool
+	Sort      string
+	Cursor    string
+	Limit     int
+}
+
+type EventSearchRequest struct {
+	Query string
+	Limit int
+}
 
 type EventRequest struct {
-	Name string    `json:"name"`
-	Date time.Time `json:"date"`
+	Name                  string     `json:"name"`
+	StartsAt              time.Time  `json:"starts_at"`
+	Date                  *time.Time `json:"date"` // Deprecated: use StartsAt.
+	EndsAt                *time.Time `json:"ends_at"`
+	DoorsOpenAt           *time.Time `json:"doors_open_at"`
+	Timezone              string     `json:"timezone"`
+	MaxTicketsPerCustomer *int       `json:"max_tickets_per_customer"`
+	VenueID               *int64     `json:"venue_id"`
+	EventDetailsRequest
+}
+
+type EventDetailsRequest struct {
+	Description string                `json:"description"`
+	Category    *domain.EventCategory `json:"category"`
+	Tags        []string              `json:"tags"`
+	MinAge      *int                  `json:"min_age"`
+	Organizer   domain.Organizer      `json:"organizer"`
+}
+
+type EventScheduleRequest struct {
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	DoorsOpenAt *time.Time `json:"doors_open_at"`
+	Timezone    string     `json:"timezone"`
+}
+
+type EventPurchaseLimitRequest struct {
+	MaxTicketsPerCustomer *int `json:"max_tickets_per_customer"`
+}
+
+type EventBookingFeeRequest struct {
+	Fixed      float64 `json:"fixed"`
+	Percentage float64 `json:"percentage"`
+	Currency   string  `json:"currency"`
+}
+
+type EventVenueRequest struct {
+	VenueID int64 `json:"venue_id"`
 }
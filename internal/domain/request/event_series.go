@@ -0,0 +1,27 @@
+package request
+
+import (
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+)
+
+type EventSeriesRequest struct {
+	EventID    int64             `json:"event_id"`
+	Name       string            `json:"name"`
+	Recurrence RecurrenceRequest `json:"recurrence"`
+}
+
+type RecurrenceRequest struct {
+	Frequency domain.RecurrenceFrequency `json:"frequency"`
+	Interval  int                        `json:"interval"`
+	ByDay     []string                   `json:"by_day"`
+	Until     *time.Time                 `json:"until"`
+	Count     *int                       `json:"count"`
+}
+
+type EventSeriesUpdateRequest struct {
+	Name                  *string `json:"name"`
+	StartTime             *string `json:"start_time"`
+	MaxTicketsPerCustomer *int    `json:"max_tickets_per_customer"`
+}
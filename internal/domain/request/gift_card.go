@@ -0,0 +1,14 @@
+package request
+
+import "time"
+
+type GiftCardRequest struct {
+	Value     float64    `json:"value"`
+	Currency  string     `json:"currency"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type GiftCardRedemptionRequest struct {
+	Code   string   `json:"code"`
+	Amount *float64 `json:"amount"`
+}
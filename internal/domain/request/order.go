@@ -1,7 +1,27 @@
 package request
 
+import "github.com/nadiannis/evento-api-fr-auth/internal/domain"
+
 type OrderRequest struct {
-	CustomerID int64 `json:"customer_id"`
-	TicketID   int64 `json:"ticket_id"`
-	Quantity   int   `json:"quantity"`
+	TicketID      int64                `json:"ticket_id"`
+	Quantity      int                  `json:"quantity"`
+	SeatIDs       []int64              `json:"seat_ids"`
+	Items         []*OrderItemRequest  `json:"items"`
+	AccessCode    string               `json:"access_code"`
+	PromoCode     string               `json:"promo_code"`
+	GiftCardCode  string               `json:"gift_card_code"`
+	PaymentMethod domain.PaymentMethod `json:"payment_method"`
+}
+
+type OrderItemRequest struct {
+	TicketID int64   `json:"ticket_id"`
+	Quantity int     `json:"quantity"`
+	SeatIDs  []int64 `json:"seat_ids"`
+}
+
+func (r *OrderRequest) LineItems() []*OrderItemRequest {
+	if len(r.Items) == 0 && (r.TicketID != 0 || r.Quantity != 0) {
+		return []*OrderItemRequest{{TicketID: r.TicketID, Quantity: r.Quantity, SeatIDs: r.SeatIDs}}
+	}
+	return r.Items
 }
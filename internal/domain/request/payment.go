@@ -0,0 +1,12 @@
+package request
+
+import "github.com/nadiannis/evento-api-fr-auth/internal/payment"
+
+type TopUpRequest struct {
+	Amount float64 `json:"amount"`
+}
+
+type PaymentSimulationRequest struct {
+	IntentID string            `json:"intent_id"`
+	Outcome  payment.EventType `json:"outcome"`
+}
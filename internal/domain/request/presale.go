@@ -0,0 +1,11 @@
+package request
+
+import "time"
+
+type PresaleRequest struct {
+	Name        string    `json:"name"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	AccessCode  *string   `json:"access_code"`
+	CustomerIDs []int64   `json:"customer_ids"`
+}
@@ -0,0 +1,10 @@
+package request
+
+import "time"
+
+type PriceTierRequest struct {
+	Name    string     `json:"name"`
+	Price   float64    `json:"price"`
+	EndsAt  *time.Time `json:"ends_at"`
+	MaxSold *int       `json:"max_sold"`
+}
@@ -0,0 +1,23 @@
+package request
+
+import (
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+)
+
+type PromoCodeRequest struct {
+	Code          string              `json:"code"`
+	DiscountType  domain.DiscountType `json:"discount_type"`
+	DiscountValue float64             `json:"discount_value"`
+	Currency      string              `json:"currency"`
+	EventID       *int64              `json:"event_id"`
+	PromoCodeLimitsRequest
+}
+
+type PromoCodeLimitsRequest struct {
+	MaxRedemptions *int       `json:"max_redemptions"`
+	MaxPerCustomer *int       `json:"max_per_customer"`
+	ValidFrom      *time.Time `json:"valid_from"`
+	ValidUntil     *time.Time `json:"valid_until"`
+}
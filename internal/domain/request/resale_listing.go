@@ -0,0 +1,6 @@
+package request
+
+type ResaleListingRequest struct {
+	TicketCode string  `json:"ticket_code"`
+	Price      float64 `json:"price"`
+}
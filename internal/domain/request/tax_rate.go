@@ -0,0 +1,11 @@
+package request
+
+type TaxRateRequest struct {
+	Jurisdiction string `json:"jurisdiction"`
+	TaxRateUpdateRequest
+}
+
+type TaxRateUpdateRequest struct {
+	Name string  `json:"name"`
+	Rate float64 `json:"rate"`
+}
@@ -1,14 +1,39 @@
 package request
 
-import "github.com/nadiannis/evento-api-fr-auth/internal/domain"
+import (
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+)
 
 type TicketRequest struct {
-	EventID  int64                 `json:"event_id"`
-	Type     domain.TicketTypeName `json:"type"`
-	Quantity int                   `json:"quantity"`
+	EventID        int64                 `json:"event_id"`
+	Type           domain.TicketTypeName `json:"type"`
+	Quantity       int                   `json:"quantity"`
+	SalesStart     *time.Time            `json:"sales_start"`
+	SalesEnd       *time.Time            `json:"sales_end"`
+	MaxPerCustomer *int                  `json:"max_per_customer"`
 }
 
 type TicketQuantityRequest struct {
 	Action   UpdateNumberAction `json:"action"`
 	Quantity int                `json:"quantity"`
 }
+
+type TicketSalesWindowRequest struct {
+	SalesStart *time.Time `json:"sales_start"`
+	SalesEnd   *time.Time `json:"sales_end"`
+}
+
+type TicketPurchaseLimitRequest struct {
+	MaxPerCustomer *int `json:"max_per_customer"`
+}
+
+type TicketSeatsRequest struct {
+	Blocks []*SeatBlockRequest `json:"blocks"`
+}
+
+type SeatBlockRequest struct {
+	Section string   `json:"section"`
+	Rows    []string `json:"rows"`
+}
@@ -0,0 +1,7 @@
+package request
+
+type TicketTransferRequest struct {
+	RecipientUsername string   `json:"recipient_username"`
+	OrderID           int64    `json:"order_id"`
+	TicketCodes       []string `json:"ticket_codes"`
+}
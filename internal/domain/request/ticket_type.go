@@ -3,6 +3,7 @@ package request
 import "github.com/nadiannis/evento-api-fr-auth/internal/domain"
 
 type TicketTypeRequest struct {
-	Name  domain.TicketTypeName `json:"name"`
-	Price float64               `json:"price"`
+	Name     domain.TicketTypeName `json:"name"`
+	Price    float64               `json:"price"`
+	Currency string                `json:"currency"`
 }
@@ -0,0 +1,32 @@
+package request
+
+type VenueRequest struct {
+	Name            string                 `json:"name"`
+	Address         string                 `json:"address"`
+	Timezone        string                 `json:"timezone"`
+	Capacity        int                    `json:"capacity"`
+	Latitude        *float64               `json:"latitude"`
+	Longitude       *float64               `json:"longitude"`
+	TaxJurisdiction string                 `json:"tax_jurisdiction"`
+	Sections        []*VenueSectionRequest `json:"sections"`
+}
+
+type VenueUpdateRequest struct {
+	Name            *string  `json:"name"`
+	Address         *string  `json:"address"`
+	Timezone        *string  `json:"timezone"`
+	Capacity        *int     `json:"capacity"`
+	Latitude        *float64 `json:"latitude"`
+	Longitude       *float64 `json:"longitude"`
+	TaxJurisdiction *string  `json:"tax_jurisdiction"`
+}
+
+type VenueSectionRequest struct {
+	Name string            `json:"name"`
+	Rows []*SeatRowRequest `json:"rows"`
+}
+
+type SeatRowRequest struct {
+	Label string `json:"label"`
+	Seats int    `json:"seats"`
+}
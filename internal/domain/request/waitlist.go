@@ -0,0 +1,5 @@
+package request
+
+type WaitlistRequest struct {
+	Quantity int `json:"quantity"`
+}
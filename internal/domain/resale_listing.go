@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+type ResaleListingStatus string
+
+var (
+	ResaleListingStatusListed    ResaleListingStatus = "listed"
+	ResaleListingStatusSold      ResaleListingStatus = "sold"
+	ResaleListingStatusCancelled ResaleListingStatus = "cancelled"
+)
+
+type ResaleListing struct {
+	ID             int64               `json:"id"`
+	IssuedTicketID int64               `json:"issued_ticket_id"`
+	EventID        int64               `json:"event_id"`
+	TicketID       int64               `json:"ticket_id"`
+	SellerID       int64               `json:"seller_id"`
+	BuyerID        *int64              `json:"buyer_id"`
+	Price          float64             `json:"price"`
+	Fee            float64             `json:"fee"`
+	Currency       string              `json:"currency"`
+	Status         ResaleListingStatus `json:"status"`
+	CreatedAt      time.Time           `json:"created_at"`
+	SoldAt         *time.Time          `json:"sold_at"`
+}
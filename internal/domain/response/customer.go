@@ -3,8 +3,10 @@ package response
 import "github.com/nadiannis/evento-api-fr-auth/internal/domain"
 
 type CustomerResponse struct {
-	ID       int64           `json:"id"`
-	Username string          `json:"username"`
-	Balance  float64         `json:"balance"`
-	Orders   []*domain.Order `json:"orders"`
+	ID       int64               `json:"id"`
+	Username string              `json:"username"`
+	Balance  float64             `json:"balance"`
+	Currency string              `json:"currency"`
+	Role     domain.CustomerRole `json:"role"`
+	Orders   []*domain.Order     `json:"orders"`
 }
@@ -7,8 +7,36 @@ import (
 )
 
 type EventResponse struct {
-	ID      int64                  `json:"id"`
-	Name    string                 `json:"name"`
-	Date    time.Time              `json:"date"`
-	Tickets []*domain.TicketDetail `json:"tickets"`
+	ID                    int64                   `json:"id"`
+	Name                  string                  `json:"name"`
+	StartsAt              time.Time               `json:"starts_at"`
+	EndsAt                *time.Time              `json:"ends_at"`
+	DoorsOpenAt           *time.Time              `json:"doors_open_at"`
+	Timezone              string                  `json:"timezone"`
+	Local                 *domain.EventLocalTimes `json:"local"`
+	Date                  time.Time               `json:"date"` // Deprecated: use StartsAt.
+	Status                domain.EventStatus      `json:"status"`
+	MaxTicketsPerCustomer *int                    `json:"max_tickets_per_customer"`
+	Venue                 *domain.Venue           `json:"venue"`
+	SeriesID              *int64                  `json:"series_id"`
+	Description           string                  `json:"description"`
+	DescriptionHTML       string                  `json:"description_html"`
+	Category              *domain.EventCategory   `json:"category"`
+	Tags                  []string                `json:"tags"`
+	MinAge                *int                    `json:"min_age"`
+	Organizer             domain.Organizer        `json:"organizer"`
+	BookingFee            domain.BookingFee       `json:"booking_fee"`
+	Images                []*domain.EventImage    `json:"images,omitempty"`
+	Tickets               []*domain.TicketDetail  `json:"tickets"`
+}
+
+type EventReminderResponse struct {
+	Event    *domain.Event `json:"event"`
+	RemindAt time.Time     `json:"remind_at"`
+}
+
+type EventSearchResponse struct {
+	Event      *domain.Event     `json:"event"`
+	Rank       float64           `json:"rank"`
+	Highlights map[string]string `json:"highlights"`
 }
@@ -11,9 +11,15 @@ type Response interface {
 }
 
 type SuccessResponse struct {
-	Status  ResponseStatus `json:"status"`
-	Message string         `json:"message"`
-	Data    any            `json:"data"`
+	Status   ResponseStatus `json:"status"`
+	Message  string         `json:"message"`
+	Data     any            `json:"data"`
+	Metadata *Metadata      `json:"metadata,omitempty"`
+}
+
+type Metadata struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -0,0 +1,9 @@
+package response
+
+import "github.com/nadiannis/evento-api-fr-auth/internal/domain"
+
+type TicketPriceResponse struct {
+	TicketID int64              `json:"ticket_id"`
+	Current  *domain.PricePoint `json:"current"`
+	Next     *domain.PricePoint `json:"next"`
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+type TaxRate struct {
+	ID           int64     `json:"id"`
+	Jurisdiction string    `json:"jurisdiction"`
+	Name         string    `json:"name"`
+	Rate         float64   `json:"rate"`
+	CreatedAt    time.Time `json:"created_at"`
+}
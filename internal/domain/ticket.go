@@ -1,15 +1,33 @@
 package domain
 
+import "time"
+
 type Ticket struct {
-	ID           int64 `json:"id"`
-	EventID      int64 `json:"event_id"`
-	TicketTypeID int64 `json:"ticket_type_id"`
-	Quantity     int   `json:"quantity"`
+	ID             int64      `json:"id"`
+	EventID        int64      `json:"event_id"`
+	TicketTypeID   int64      `json:"ticket_type_id"`
+	Quantity       int        `json:"quantity"`
+	SalesStart     *time.Time `json:"sales_start"`
+	SalesEnd       *time.Time `json:"sales_end"`
+	MaxPerCustomer *int       `json:"max_per_customer"`
 }
 
 type TicketDetail struct {
-	ID       int64      `json:"id"`
-	EventID  int64      `json:"event_id"`
-	Quantity int        `json:"quantity"`
-	Type     TicketType `json:"type"`
+	ID             int64      `json:"id"`
+	EventID        int64      `json:"event_id"`
+	Quantity       int        `json:"quantity"`
+	SalesStart     *time.Time `json:"sales_start"`
+	SalesEnd       *time.Time `json:"sales_end"`
+	MaxPerCustomer *int       `json:"max_per_customer"`
+	Type           TicketType `json:"type"`
+}
+
+func (t *TicketDetail) OnSale(at time.Time) bool {
+	if t.SalesStart != nil && at.Before(*t.SalesStart) {
+		return false
+	}
+	if t.SalesEnd != nil && !at.Before(*t.SalesEnd) {
+		return false
+	}
+	return true
 }
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+type TicketTransferStatus string
+
+var (
+	TicketTransferStatusPending   TicketTransferStatus = "pending"
+	TicketTransferStatusAccepted  TicketTransferStatus = "accepted"
+	TicketTransferStatusDeclined  TicketTransferStatus = "declined"
+	TicketTransferStatusCancelled TicketTransferStatus = "cancelled"
+)
+
+type TicketTransfer struct {
+	ID              int64                `json:"id"`
+	SenderID        int64                `json:"sender_id"`
+	RecipientID     int64                `json:"recipient_id"`
+	Status          TicketTransferStatus `json:"status"`
+	CreatedAt       time.Time            `json:"created_at"`
+	RespondedAt     *time.Time           `json:"responded_at"`
+	IssuedTicketIDs []int64              `json:"issued_ticket_ids"`
+}
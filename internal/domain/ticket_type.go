@@ -8,7 +8,8 @@ var (
 )
 
 type TicketType struct {
-	ID    int64          `json:"id"`
-	Name  TicketTypeName `json:"name"`
-	Price float64        `json:"price"`
+	ID       int64          `json:"id"`
+	Name     TicketTypeName `json:"name"`
+	Price    float64        `json:"price"`
+	Currency string         `json:"currency"`
 }
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+type TopUpStatus string
+
+var (
+	TopUpStatusPending   TopUpStatus = "pending"
+	TopUpStatusSucceeded TopUpStatus = "succeeded"
+	TopUpStatusFailed    TopUpStatus = "failed"
+	TopUpStatusRefunded  TopUpStatus = "refunded"
+)
+
+type TopUp struct {
+	ID           int64       `json:"id"`
+	CustomerID   int64       `json:"customer_id"`
+	Amount       float64     `json:"amount"`
+	Currency     string      `json:"currency"`
+	Provider     string      `json:"provider"`
+	IntentID     string      `json:"intent_id"`
+	ClientSecret string      `json:"client_secret,omitempty"`
+	Status       TopUpStatus `json:"status"`
+	CreatedAt    time.Time   `json:"created_at"`
+	ConfirmedAt  *time.Time  `json:"confirmed_at"`
+	RefundedAt   *time.Time  `json:"refunded_at"`
+}
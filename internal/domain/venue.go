@@ -0,0 +1,42 @@
+package domain
+
+type Venue struct {
+	ID              int64           `json:"id"`
+	Name            string          `json:"name"`
+	Address         string          `json:"address"`
+	Timezone        string          `json:"timezone"`
+	Capacity        int             `json:"capacity"`
+	Latitude        *float64        `json:"latitude"`
+	Longitude       *float64        `json:"longitude"`
+	TaxJurisdiction *string         `json:"tax_jurisdiction"`
+	Sections        []*VenueSection `json:"sections,omitempty"`
+}
+
+func (v *Venue) SeatCount() int {
+	count := 0
+	for _, section := range v.Sections {
+		count += len(section.Seats)
+	}
+	return count
+}
+
+type VenueSection struct {
+	ID      int64   `json:"id"`
+	VenueID int64   `json:"venue_id"`
+	Name    string  `json:"name"`
+	Seats   []*Seat `json:"seats"`
+}
+
+type Seat struct {
+	ID        int64  `json:"id"`
+	SectionID int64  `json:"section_id"`
+	Section   string `json:"section"`
+	Row       string `json:"row"`
+	Number    int    `json:"number"`
+}
+
+type EventSeat struct {
+	Seat
+	TicketID  *int64 `json:"ticket_id"`
+	Available bool   `json:"available"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+type WaitlistEntryStatus string
+
+var (
+	WaitlistEntryStatusWaiting   WaitlistEntryStatus = "waiting"
+	WaitlistEntryStatusOffered   WaitlistEntryStatus = "offered"
+	WaitlistEntryStatusClaimed   WaitlistEntryStatus = "claimed"
+	WaitlistEntryStatusExpired   WaitlistEntryStatus = "expired"
+	WaitlistEntryStatusCancelled WaitlistEntryStatus = "cancelled"
+)
+
+type WaitlistEntry struct {
+	ID             int64               `json:"id"`
+	TicketID       int64               `json:"ticket_id"`
+	CustomerID     int64               `json:"customer_id"`
+	Quantity       int                 `json:"quantity"`
+	Status         WaitlistEntryStatus `json:"status"`
+	CreatedAt      time.Time           `json:"created_at"`
+	OfferedAt      *time.Time          `json:"offered_at"`
+	OfferExpiresAt *time.Time          `json:"offer_expires_at"`
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type CheckInHandler struct {
+	usecase usecase.ICheckInUsecase
+}
+
+func NewCheckInHandler(usecase usecase.ICheckInUsecase) ICheckInHandler {
+	return &CheckInHandler{
+		usecase: usecase,
+	}
+}
+
+func (h *CheckInHandler) GetSigningKey(c *gin.Context) {
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "ticket signing key retrieved successfully",
+		Data: map[string]string{
+			"algorithm":  "ed25519",
+			"public_key": h.usecase.GetSigningKey(),
+		},
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *CheckInHandler) GetStats(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	stats, err := h.usecase.GetStats(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "check-in statistics retrieved successfully",
+		Data:    stats,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *CheckInHandler) CheckIn(c *gin.Context) {
+	var input request.CheckInRequest
+
+	err := utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(input.EventID != 0, "event_id", "event_id is required")
+	v.Check(input.Code != "" || input.Token != "", "code", "code or token is required")
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	scanner := utils.GetCustomer(c)
+
+	issuedTicket, err := h.usecase.CheckIn(&input, scanner.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrIssuedTicketNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrTicketAlreadyCheckedIn):
+			utils.AlreadyCheckedInResponse(c, err, issuedTicket.CheckedInAt)
+		case errors.Is(err, utils.ErrInvalidTicketToken) || errors.Is(err, utils.ErrTicketEventMismatch) || errors.Is(err, utils.ErrIssuedTicketVoid):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "ticket checked in successfully",
+		Data:    issuedTicket,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
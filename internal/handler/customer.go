@@ -99,6 +99,9 @@ func (h *CustomerHandler) Add(c *gin.Context) {
 	v.Check(input.Password != "", "password", "password is required")
 	v.Check(len(input.Password) >= 8, "password", "password must be at least 8 characters long")
 	v.Check(len(input.Password) <= 72, "password", "password must not be more than 72 characters long")
+	if input.Currency != "" {
+		v.Check(utils.Matches(input.Currency, utils.CurrencyRX), "currency", "currency should be an ISO 4217 currency code")
+	}
 
 	if !v.Valid() {
 		utils.FailedValidationResponse(c, v.Errors)
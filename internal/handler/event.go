@@ -3,8 +3,11 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
 	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
 	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
@@ -21,16 +24,52 @@ func NewEventHandler(usecase usecase.IEventUsecase) IEventHandler {
 }
 
 func (h *EventHandler) GetAll(c *gin.Context) {
-	events, err := h.usecase.GetAll()
+	v := utils.NewValidator()
+
+	input := request.EventFilterRequest{
+		Query:     utils.ReadStringQuery(c, "q", ""),
+		From:      utils.ReadTimeQuery(c, "from", v),
+		To:        utils.ReadTimeQuery(c, "to", v),
+		VenueID:   utils.ReadIDQuery(c, "venue_id", v),
+		Tag:       utils.ReadStringQuery(c, "tag", ""),
+		Available: utils.ReadBoolQuery(c, "available", v),
+		Sort:      utils.ReadStringQuery(c, "sort", "starts_at"),
+		Cursor:    utils.ReadStringQuery(c, "cursor", ""),
+		Limit:     utils.ReadIntQuery(c, "limit", 20, v),
+	}
+
+	if category := utils.ReadStringQuery(c, "category", ""); category != "" {
+		input.Category = (*domain.EventCategory)(&category)
+		v.Check(utils.PermittedValue(*input.Category, domain.EventCategories...), "category", "category should be a known event category")
+	}
+
+	v.Check(utils.PermittedValue(input.Sort, domain.EventSorts...), "sort", "sort should be one of starts_at, -starts_at, name, or -name")
+	v.Check(input.Limit > 0 && input.Limit <= 100, "limit", "limit should be between 1 and 100")
+	if input.From != nil && input.To != nil {
+		v.Check(input.From.Before(*input.To), "to", "to should be after from")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	events, metadata, err := h.usecase.GetAll(&input)
 	if err != nil {
-		utils.ServerErrorResponse(c, err)
+		switch {
+		case errors.Is(err, utils.ErrInvalidCursor):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
 		return
 	}
 
 	res := response.SuccessResponse{
-		Status:  response.Success,
-		Message: "events retrieved successfully",
-		Data:    events,
+		Status:   response.Success,
+		Message:  "events retrieved successfully",
+		Data:     events,
+		Metadata: metadata,
 	}
 
 	utils.WriteJSON(c, http.StatusOK, res)
@@ -62,3 +101,412 @@ func (h *EventHandler) GetByID(c *gin.Context) {
 
 	utils.WriteJSON(c, http.StatusOK, res)
 }
+
+func (h *EventHandler) Search(c *gin.Context) {
+	v := utils.NewValidator()
+
+	input := request.EventSearchRequest{
+		Query: strings.TrimSpace(utils.ReadStringQuery(c, "q", "")),
+		Limit: utils.ReadIntQuery(c, "limit", 20, v),
+	}
+
+	v.Check(input.Query != "", "q", "q is required")
+	v.Check(input.Limit > 0 && input.Limit <= 100, "limit", "limit should be between 1 and 100")
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	results, err := h.usecase.Search(&input)
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "events searched successfully",
+		Data:    results,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *EventHandler) UpdatePurchaseLimit(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.EventPurchaseLimitRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	if input.MaxTicketsPerCustomer != nil {
+		v.Check(*input.MaxTicketsPerCustomer > 0, "max_tickets_per_customer", "max_tickets_per_customer should be a positive number")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	event, err := h.usecase.UpdatePurchaseLimit(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event purchase limit updated successfully",
+		Data:    event,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *EventHandler) UpdateBookingFee(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.EventBookingFeeRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(input.Fixed >= 0, "fixed", "fixed should not be negative")
+	v.Check(input.Percentage >= 0 && input.Percentage <= 100, "percentage", "percentage should be between 0 and 100")
+	if input.Currency != "" {
+		v.Check(utils.Matches(input.Currency, utils.CurrencyRX), "currency", "currency should be an ISO 4217 currency code")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	event, err := h.usecase.UpdateBookingFee(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event booking fee updated successfully",
+		Data:    event,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *EventHandler) Cancel(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	cancellation, err := h.usecase.Cancel(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrEventAlreadyCancelled):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event cancellation started successfully",
+		Data:    cancellation,
+	}
+
+	utils.WriteJSON(c, http.StatusAccepted, res)
+}
+
+func (h *EventHandler) GetCancellation(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	cancellation, err := h.usecase.GetCancellation(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound) || errors.Is(err, utils.ErrEventCancellationNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event cancellation retrieved successfully",
+		Data:    cancellation,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *EventHandler) UpdateVenue(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.EventVenueRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(input.VenueID != 0, "venue_id", "venue_id is required")
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	event, err := h.usecase.UpdateVenue(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound) || errors.Is(err, utils.ErrVenueNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrEventSeatsAssigned):
+			utils.ConflictResponse(c, err)
+		case errors.Is(err, utils.ErrVenueCapacityExceeded):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event venue updated successfully",
+		Data:    event,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *EventHandler) GetSeats(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	seats, err := h.usecase.GetSeats(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrEventWithoutVenue):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event seats retrieved successfully",
+		Data:    seats,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *EventHandler) UpdateSchedule(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.EventScheduleRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(!input.StartsAt.IsZero(), "starts_at", "starts_at is required")
+	if input.EndsAt != nil {
+		v.Check(input.EndsAt.After(input.StartsAt), "ends_at", "ends_at should be after starts_at")
+	}
+	if input.DoorsOpenAt != nil {
+		v.Check(!input.DoorsOpenAt.After(input.StartsAt), "doors_open_at", "doors_open_at should not be after starts_at")
+	}
+	if input.Timezone != "" {
+		v.Check(utils.ValidTimezone(input.Timezone), "timezone", "timezone should be a valid IANA time zone")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	event, err := h.usecase.UpdateSchedule(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event schedule updated successfully",
+		Data:    event,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *EventHandler) UpdateDetails(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.EventDetailsRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	validateEventDetails(v, &input)
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	event, err := h.usecase.UpdateDetails(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event details updated successfully",
+		Data:    event,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *EventHandler) GetCategories(c *gin.Context) {
+	categories, err := h.usecase.GetCategories()
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event categories retrieved successfully",
+		Data:    categories,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *EventHandler) GetReminders(c *gin.Context) {
+	customer := utils.GetCustomer(c)
+
+	reminders, err := h.usecase.GetReminders(customer.ID)
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event reminders retrieved successfully",
+		Data:    reminders,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func validateEventDetails(v *utils.Validator, input *request.EventDetailsRequest) {
+	v.Check(len(input.Description) <= 10000, "description", "description should not be more than 10000 bytes long")
+	if input.Category != nil {
+		v.Check(utils.PermittedValue(*input.Category, domain.EventCategories...), "category", "category should be a known event category")
+	}
+	v.Check(len(input.Tags) <= 10, "tags", "tags should not contain more than 10 tags")
+	for _, tag := range input.Tags {
+		v.Check(len(strings.TrimSpace(tag)) <= 30, "tags", "each tag should not be more than 30 bytes long")
+	}
+	if input.MinAge != nil {
+		v.Check(*input.MinAge >= 0, "min_age", "min_age should not be negative")
+	}
+	if email := strings.TrimSpace(input.Organizer.Email); email != "" {
+		v.Check(utils.Matches(email, utils.EmailRX), "organizer.email", "organizer.email should be a valid email address")
+	}
+	if website := strings.TrimSpace(input.Organizer.Website); website != "" {
+		v.Check(utils.Matches(website, utils.WebsiteRX), "organizer.website", "organizer.website should be an http or https URL")
+	}
+}
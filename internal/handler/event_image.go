@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/config"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type EventImageHandler struct {
+	usecase usecase.IEventImageUsecase
+	maxSize int64
+}
+
+func NewEventImageHandler(config *config.Config, usecase usecase.IEventImageUsecase) IEventImageHandler {
+	return &EventImageHandler{
+		usecase: usecase,
+		maxSize: config.Images.MaxSize,
+	}
+}
+
+func (h *EventImageHandler) GetAll(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	images, err := h.usecase.GetAll(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event images retrieved successfully",
+		Data:    images,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *EventImageHandler) Serve(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	variant := domain.ImageVariant(c.Param("variant"))
+	if !utils.PermittedValue(variant, domain.ImageVariants...) {
+		utils.NotFoundResponse(c, utils.ErrEventImageNotFound)
+		return
+	}
+
+	image, err := h.usecase.GetByID(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventImageNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	etag := fmt.Sprintf(`"%d-%s"`, image.ID, variant)
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("ETag", etag)
+
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		utils.SetLogMessage(c, "event image not modified")
+		return
+	}
+
+	data, err := h.usecase.Read(image, variant)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventImageNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	c.Header("Content-Length", strconv.Itoa(len(data)))
+	c.Data(http.StatusOK, image.VariantContentType(variant), data)
+	utils.SetLogMessage(c, "event image served successfully")
+}
+
+func (h *EventImageHandler) Add(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+1<<20)
+
+	header, err := c.FormFile("image")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			utils.ContentTooLargeResponse(c, utils.ErrImageTooLarge)
+		case errors.Is(err, http.ErrMissingFile):
+			utils.BadRequestResponse(c, utils.ErrImageMissing)
+		default:
+			utils.BadRequestResponse(c, err)
+		}
+		return
+	}
+
+	if header.Size > h.maxSize {
+		utils.ContentTooLargeResponse(c, utils.ErrImageTooLarge)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	image, err := h.usecase.Add(id, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrUnsupportedImageType):
+			utils.UnsupportedMediaTypeResponse(c, err)
+		case errors.Is(err, utils.ErrImageTooLarge):
+			utils.ContentTooLargeResponse(c, err)
+		case errors.Is(err, utils.ErrInvalidImage):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event image uploaded successfully",
+		Data:    image,
+	}
+
+	utils.WriteJSON(c, http.StatusCreated, res)
+}
+
+func (h *EventImageHandler) Delete(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	imageID, err := utils.ReadNamedIDParam(c, "image_id")
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	err = h.usecase.Delete(id, imageID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventImageNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event image deleted successfully",
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type EventSeriesHandler struct {
+	usecase usecase.IEventSeriesUsecase
+}
+
+func NewEventSeriesHandler(usecase usecase.IEventSeriesUsecase) IEventSeriesHandler {
+	return &EventSeriesHandler{
+		usecase: usecase,
+	}
+}
+
+func (h *EventSeriesHandler) GetByID(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	series, err := h.usecase.GetByID(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventSeriesNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event series retrieved successfully",
+		Data:    series,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *EventSeriesHandler) Add(c *gin.Context) {
+	var input request.EventSeriesRequest
+
+	err := utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	recurrence := input.Recurrence
+
+	v.Check(input.EventID != 0, "event_id", "event_id is required")
+	v.Check(utils.PermittedValue(recurrence.Frequency, domain.RecurrenceFrequencyDaily, domain.RecurrenceFrequencyWeekly), "recurrence.frequency", "frequency should be daily or weekly")
+	v.Check(recurrence.Interval >= 0, "recurrence.interval", "interval should be a positive number")
+	v.Check(recurrence.Until != nil || recurrence.Count != nil, "recurrence", "until or count is required")
+	if recurrence.Count != nil {
+		v.Check(*recurrence.Count > 0, "recurrence.count", "count should be a positive number")
+	}
+	if recurrence.Frequency != domain.RecurrenceFrequencyWeekly {
+		v.Check(len(recurrence.ByDay) == 0, "recurrence.by_day", "by_day is only for weekly recurrences")
+	}
+	for i, day := range recurrence.ByDay {
+		v.Check(utils.PermittedValue(day, domain.Weekdays...), fmt.Sprintf("recurrence.by_day[%d]", i), "day should be one of MO, TU, WE, TH, FR, SA, or SU")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	series, err := h.usecase.Add(&input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrEventInSeries):
+			utils.ConflictResponse(c, err)
+		case errors.Is(err, utils.ErrEventCancelled),
+			errors.Is(err, utils.ErrTooManyOccurrences):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event series added successfully",
+		Data:    series,
+	}
+
+	utils.WriteJSON(c, http.StatusCreated, res)
+}
+
+func (h *EventSeriesHandler) Update(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.EventSeriesUpdateRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	if input.Name != nil {
+		v.Check(*input.Name != "", "name", "name should not be empty")
+	}
+	if input.StartTime != nil {
+		_, err := time.Parse("15:04", *input.StartTime)
+		v.Check(err == nil, "start_time", "start_time should be a time of day like 19:30")
+	}
+	if input.MaxTicketsPerCustomer != nil {
+		v.Check(*input.MaxTicketsPerCustomer > 0, "max_tickets_per_customer", "max_tickets_per_customer should be a positive number")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	series, err := h.usecase.Update(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventSeriesNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "event series updated successfully",
+		Data:    series,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type ExchangeRateHandler struct {
+	usecase usecase.IExchangeRateUsecase
+}
+
+func NewExchangeRateHandler(usecase usecase.IExchangeRateUsecase) IExchangeRateHandler {
+	return &ExchangeRateHandler{
+		usecase: usecase,
+	}
+}
+
+func (h *ExchangeRateHandler) GetAll(c *gin.Context) {
+	rates, err := h.usecase.GetAll()
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "exchange rates retrieved successfully",
+		Data:    rates,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *ExchangeRateHandler) Set(c *gin.Context) {
+	var input request.ExchangeRateRequest
+
+	err := utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	baseCurrency := strings.TrimSpace(input.BaseCurrency)
+	quoteCurrency := strings.TrimSpace(input.QuoteCurrency)
+	v.Check(baseCurrency != "", "base_currency", "base_currency is required")
+	v.Check(utils.Matches(baseCurrency, utils.CurrencyRX), "base_currency", "base_currency should be an ISO 4217 currency code")
+	v.Check(quoteCurrency != "", "quote_currency", "quote_currency is required")
+	v.Check(utils.Matches(quoteCurrency, utils.CurrencyRX), "quote_currency", "quote_currency should be an ISO 4217 currency code")
+	v.Check(!strings.EqualFold(baseCurrency, quoteCurrency), "quote_currency", "quote_currency should be different from base_currency")
+	v.Check(input.Rate > 0, "rate", "rate should be greater than 0")
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	rate, err := h.usecase.Set(&input)
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "exchange rate set successfully",
+		Data:    rate,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *ExchangeRateHandler) Delete(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	err = h.usecase.Delete(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrExchangeRateNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "exchange rate deleted successfully",
+		Data:    nil,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
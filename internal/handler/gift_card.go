@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type GiftCardHandler struct {
+	usecase usecase.IGiftCardUsecase
+}
+
+func NewGiftCardHandler(usecase usecase.IGiftCardUsecase) IGiftCardHandler {
+	return &GiftCardHandler{
+		usecase: usecase,
+	}
+}
+
+func (h *GiftCardHandler) GetAll(c *gin.Context) {
+	customer := utils.GetCustomer(c)
+
+	giftCards, err := h.usecase.GetByPurchaserID(customer.ID)
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "gift cards retrieved successfully",
+		Data:    giftCards,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *GiftCardHandler) GetByCode(c *gin.Context) {
+	giftCard, err := h.usecase.GetByCode(c.Param("code"))
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrGiftCardNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "gift card retrieved successfully",
+		Data:    giftCard,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *GiftCardHandler) Add(c *gin.Context) {
+	var input request.GiftCardRequest
+
+	err := utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(input.Value != 0, "value", "value is required")
+	v.Check(input.Value >= 0.01, "value", "value should be at least 0.01")
+	if input.Currency != "" {
+		v.Check(utils.Matches(input.Currency, utils.CurrencyRX), "currency", "currency should be an ISO 4217 currency code")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	giftCard, err := h.usecase.Add(&input, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrCustomerNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrGiftCardOptionsRestricted):
+			utils.ForbiddenResponse(c, err)
+		case errors.Is(err, utils.ErrInsufficientBalance):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "gift card added successfully",
+		Data:    giftCard,
+	}
+
+	utils.WriteJSON(c, http.StatusCreated, res)
+}
+
+func (h *GiftCardHandler) Redeem(c *gin.Context) {
+	var input request.GiftCardRedemptionRequest
+
+	err := utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(input.Code != "", "code", "code is required")
+	if input.Amount != nil {
+		v.Check(*input.Amount >= 0.01, "amount", "amount should be at least 0.01")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	giftCard, err := h.usecase.Redeem(&input, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrGiftCardNotFound) || errors.Is(err, utils.ErrCustomerNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrGiftCardUnavailable) || errors.Is(err, utils.ErrGiftCardBalanceExceeded):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrCurrencyNotConvertible):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "gift card redeemed successfully",
+		Data:    giftCard,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
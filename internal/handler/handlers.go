@@ -1,19 +1,46 @@
 package handler
 
-import "github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+import (
+	"github.com/nadiannis/evento-api-fr-auth/internal/config"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+)
 
 type Handlers struct {
-	Customers ICustomerHandler
-	Events    IEventHandler
-	Tickets   ITicketHandler
-	Orders    IOrderHandler
+	Customers     ICustomerHandler
+	Events        IEventHandler
+	EventSeries   IEventSeriesHandler
+	EventImages   IEventImageHandler
+	Tickets       ITicketHandler
+	Orders        IOrderHandler
+	CheckIns      ICheckInHandler
+	Transfers     ITicketTransferHandler
+	Resales       IResaleListingHandler
+	Waitlists     IWaitlistHandler
+	Venues        IVenueHandler
+	PromoCodes    IPromoCodeHandler
+	TaxRates      ITaxRateHandler
+	ExchangeRates IExchangeRateHandler
+	Payments      IPaymentHandler
+	GiftCards     IGiftCardHandler
 }
 
-func NewHandlers(usecases usecase.Usecases) Handlers {
+func NewHandlers(config *config.Config, usecases usecase.Usecases) Handlers {
 	return Handlers{
-		Customers: NewCustomerHandler(usecases.Customers),
-		Events:    NewEventHandler(usecases.Events),
-		Tickets:   NewTicketHandler(usecases.Tickets),
-		Orders:    NewOrderHandler(usecases.Orders),
+		Customers:     NewCustomerHandler(usecases.Customers),
+		Events:        NewEventHandler(usecases.Events),
+		EventSeries:   NewEventSeriesHandler(usecases.EventSeries),
+		EventImages:   NewEventImageHandler(config, usecases.EventImages),
+		Tickets:       NewTicketHandler(usecases.Tickets),
+		Orders:        NewOrderHandler(usecases.Orders),
+		CheckIns:      NewCheckInHandler(usecases.CheckIns),
+		Transfers:     NewTicketTransferHandler(usecases.Transfers),
+		Resales:       NewResaleListingHandler(usecases.Resales),
+		Waitlists:     NewWaitlistHandler(usecases.Waitlists),
+		Venues:        NewVenueHandler(usecases.Venues),
+		PromoCodes:    NewPromoCodeHandler(usecases.PromoCodes),
+		TaxRates:      NewTaxRateHandler(usecases.TaxRates),
+		ExchangeRates: NewExchangeRateHandler(usecases.ExchangeRates),
+		Payments:      NewPaymentHandler(usecases.Payments),
+		GiftCards:     NewGiftCardHandler(usecases.GiftCards),
 	}
 }
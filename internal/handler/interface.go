@@ -23,19 +23,72 @@ type ICustomerHandler interface {
 type EventReader interface {
 	GetAll(c *gin.Context)
 	GetByID(c *gin.Context)
+	Search(c *gin.Context)
+	GetCancellation(c *gin.Context)
+	GetSeats(c *gin.Context)
+	GetReminders(c *gin.Context)
+	GetCategories(c *gin.Context)
+}
+
+type EventWriter interface {
+	UpdatePurchaseLimit(c *gin.Context)
+	UpdateBookingFee(c *gin.Context)
+	UpdateVenue(c *gin.Context)
+	UpdateSchedule(c *gin.Context)
+	UpdateDetails(c *gin.Context)
+	Cancel(c *gin.Context)
 }
 
 type IEventHandler interface {
 	EventReader
+	EventWriter
+}
+
+type EventImageReader interface {
+	GetAll(c *gin.Context)
+	Serve(c *gin.Context)
+}
+
+type EventImageWriter interface {
+	Add(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type IEventImageHandler interface {
+	EventImageReader
+	EventImageWriter
+}
+
+type EventSeriesReader interface {
+	GetByID(c *gin.Context)
+}
+
+type EventSeriesWriter interface {
+	Add(c *gin.Context)
+	Update(c *gin.Context)
+}
+
+type IEventSeriesHandler interface {
+	EventSeriesReader
+	EventSeriesWriter
 }
 
 type TicketReader interface {
 	GetAll(c *gin.Context)
 	GetByID(c *gin.Context)
+	GetPresales(c *gin.Context)
+	GetPrice(c *gin.Context)
+	GetPriceTiers(c *gin.Context)
 }
 
 type TicketWriter interface {
 	UpdateQuantity(c *gin.Context)
+	UpdateSalesWindow(c *gin.Context)
+	UpdatePurchaseLimit(c *gin.Context)
+	AssignSeats(c *gin.Context)
+	AddPresale(c *gin.Context)
+	AddPriceTier(c *gin.Context)
+	DeletePriceTier(c *gin.Context)
 }
 
 type ITicketHandler interface {
@@ -45,10 +98,17 @@ type ITicketHandler interface {
 
 type OrderReader interface {
 	GetAll(c *gin.Context)
+	GetTickets(c *gin.Context)
+	GetCustomerTickets(c *gin.Context)
+	RenderOrderTicket(c *gin.Context)
+	RenderOrderTickets(c *gin.Context)
+	RenderTicket(c *gin.Context)
+	RenderTickets(c *gin.Context)
 }
 
 type OrderWriter interface {
 	Add(c *gin.Context)
+	Cancel(c *gin.Context)
 	DeleteAll(c *gin.Context)
 }
 
@@ -56,3 +116,152 @@ type IOrderHandler interface {
 	OrderReader
 	OrderWriter
 }
+
+type CheckInReader interface {
+	GetSigningKey(c *gin.Context)
+	GetStats(c *gin.Context)
+}
+
+type CheckInWriter interface {
+	CheckIn(c *gin.Context)
+}
+
+type ICheckInHandler interface {
+	CheckInReader
+	CheckInWriter
+}
+
+type TicketTransferReader interface {
+	GetAll(c *gin.Context)
+}
+
+type TicketTransferWriter interface {
+	Add(c *gin.Context)
+	Accept(c *gin.Context)
+	Cancel(c *gin.Context)
+}
+
+type ITicketTransferHandler interface {
+	TicketTransferReader
+	TicketTransferWriter
+}
+
+type ResaleListingReader interface {
+	GetAll(c *gin.Context)
+}
+
+type ResaleListingWriter interface {
+	Add(c *gin.Context)
+	Purchase(c *gin.Context)
+	Cancel(c *gin.Context)
+}
+
+type IResaleListingHandler interface {
+	ResaleListingReader
+	ResaleListingWriter
+}
+
+type WaitlistReader interface {
+	GetAll(c *gin.Context)
+}
+
+type WaitlistWriter interface {
+	Join(c *gin.Context)
+	Leave(c *gin.Context)
+}
+
+type IWaitlistHandler interface {
+	WaitlistReader
+	WaitlistWriter
+}
+
+type VenueReader interface {
+	GetAll(c *gin.Context)
+	GetByID(c *gin.Context)
+}
+
+type VenueWriter interface {
+	Add(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type IVenueHandler interface {
+	VenueReader
+	VenueWriter
+}
+
+type PromoCodeReader interface {
+	GetAll(c *gin.Context)
+	GetByID(c *gin.Context)
+}
+
+type PromoCodeWriter interface {
+	Add(c *gin.Context)
+	UpdateLimits(c *gin.Context)
+}
+
+type IPromoCodeHandler interface {
+	PromoCodeReader
+	PromoCodeWriter
+}
+
+type PaymentReader interface {
+	GetTopUps(c *gin.Context)
+}
+
+type PaymentWriter interface {
+	AddTopUp(c *gin.Context)
+	RefundTopUp(c *gin.Context)
+	Webhook(c *gin.Context)
+	Simulate(c *gin.Context)
+}
+
+type IPaymentHandler interface {
+	PaymentReader
+	PaymentWriter
+}
+
+type GiftCardReader interface {
+	GetAll(c *gin.Context)
+	GetByCode(c *gin.Context)
+}
+
+type GiftCardWriter interface {
+	Add(c *gin.Context)
+	Redeem(c *gin.Context)
+}
+
+type IGiftCardHandler interface {
+	GiftCardReader
+	GiftCardWriter
+}
+
+type ExchangeRateReader interface {
+	GetAll(c *gin.Context)
+}
+
+type ExchangeRateWriter interface {
+	Set(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type IExchangeRateHandler interface {
+	ExchangeRateReader
+	ExchangeRateWriter
+}
+
+type TaxRateReader interface {
+	GetAll(c *gin.Context)
+}
+
+type TaxRateWriter interface {
+	Add(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type ITaxRateHandler interface {
+	TaxRateReader
+	TaxRateWriter
+}
@@ -2,9 +2,12 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
 	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
@@ -48,22 +51,63 @@ func (h *OrderHandler) Add(c *gin.Context) {
 
 	v := utils.NewValidator()
 
-	v.Check(input.CustomerID != 0, "customer_id", "customer_id is required")
-	v.Check(input.TicketID != 0, "ticket_id", "ticket_id is required")
-	v.Check(input.Quantity != 0, "quantity", "quantity is required")
-	v.Check(input.Quantity > 0, "quantity", "quantity should not be a negative number")
+	if len(input.Items) == 0 {
+		v.Check(input.TicketID != 0, "ticket_id", "ticket_id is required")
+		v.Check(input.Quantity != 0, "quantity", "quantity is required")
+		v.Check(input.Quantity > 0, "quantity", "quantity should not be a negative number")
+		v.Check(len(input.SeatIDs) == 0 || len(input.SeatIDs) == input.Quantity, "seat_ids", "seat_ids should have one seat for each ticket")
+	} else {
+		v.Check(len(input.SeatIDs) == 0, "seat_ids", "seat_ids should be given per item")
+		v.Check(input.TicketID == 0 && input.Quantity == 0, "items", "items should not be combined with ticket_id & quantity")
+
+		for i, item := range input.Items {
+			v.Check(item != nil, fmt.Sprintf("items[%d]", i), "item is required")
+			if item == nil {
+				continue
+			}
+
+			v.Check(item.TicketID != 0, fmt.Sprintf("items[%d].ticket_id", i), "ticket_id is required")
+			v.Check(item.Quantity != 0, fmt.Sprintf("items[%d].quantity", i), "quantity is required")
+			v.Check(item.Quantity > 0, fmt.Sprintf("items[%d].quantity", i), "quantity should not be a negative number")
+			v.Check(len(item.SeatIDs) == 0 || len(item.SeatIDs) == item.Quantity, fmt.Sprintf("items[%d].seat_ids", i), "seat_ids should have one seat for each ticket")
+		}
+	}
+
+	v.Check(input.PaymentMethod == "" || utils.PermittedValue(input.PaymentMethod, domain.PaymentMethodBalance, domain.PaymentMethodProvider), "payment_method", "payment_method should be 'balance' or 'provider'")
 
 	if !v.Valid() {
 		utils.FailedValidationResponse(c, v.Errors)
 		return
 	}
 
-	order, err := h.usecase.Add(&input)
+	customer := utils.GetCustomer(c)
+
+	order, err := h.usecase.Add(&input, customer.ID)
 	if err != nil {
 		switch {
-		case errors.Is(err, utils.ErrCustomerNotFound) || errors.Is(err, utils.ErrTicketNotFound) || errors.Is(err, utils.ErrTicketTypeNotFound):
+		case errors.Is(err, utils.ErrCustomerNotFound) || errors.Is(err, utils.ErrTicketNotFound) || errors.Is(err, utils.ErrTicketTypeNotFound) || errors.Is(err, utils.ErrEventNotFound):
 			utils.NotFoundResponse(c, err)
-		case errors.Is(err, utils.ErrInsufficientTicketQuantity) || errors.Is(err, utils.ErrInsufficientBalance):
+		case errors.Is(err, utils.ErrInsufficientTicketQuantity) || errors.Is(err, utils.ErrInsufficientBalance) || errors.Is(err, utils.ErrEventCancelled):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrOrderItemsAcrossEvents) || errors.Is(err, utils.ErrOrderItemsAcrossCurrencies):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrCurrencyNotConvertible) || errors.Is(err, utils.ErrPaymentProviderDisabled):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrSeatNotFound) || errors.Is(err, utils.ErrTicketNotSeated) || errors.Is(err, utils.ErrInvalidSeatSelection):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrSeatUnavailable):
+			utils.ConflictResponse(c, err)
+		case errors.Is(err, utils.ErrTicketSalesNotStarted) || errors.Is(err, utils.ErrTicketSalesEnded):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrPresaleAccessDenied):
+			utils.ForbiddenResponse(c, err)
+		case errors.Is(err, utils.ErrPurchaseLimitExceeded):
+			utils.ConflictResponse(c, err)
+		case errors.Is(err, utils.ErrPromoCodeNotFound) || errors.Is(err, utils.ErrPromoCodeNotApplicable) || errors.Is(err, utils.ErrPromoCodeUnavailable):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrPromoCodeLimitReached):
+			utils.ConflictResponse(c, err)
+		case errors.Is(err, utils.ErrGiftCardNotFound) || errors.Is(err, utils.ErrGiftCardUnavailable):
 			utils.BadRequestResponse(c, err)
 		default:
 			utils.ServerErrorResponse(c, err)
@@ -80,10 +124,209 @@ func (h *OrderHandler) Add(c *gin.Context) {
 	utils.WriteJSON(c, http.StatusCreated, res)
 }
 
+func (h *OrderHandler) GetTickets(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	issuedTickets, err := h.usecase.GetTickets(id, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrOrderNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "order tickets retrieved successfully",
+		Data:    issuedTickets,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *OrderHandler) GetCustomerTickets(c *gin.Context) {
+	customer := utils.GetCustomer(c)
+
+	issuedTickets, err := h.usecase.GetCustomerTickets(customer.ID)
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "tickets retrieved successfully",
+		Data:    issuedTickets,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *OrderHandler) RenderOrderTicket(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	code, ok := strings.CutSuffix(c.Param("code"), ".png")
+	if !ok {
+		utils.NotFoundResponse(c, utils.ErrIssuedTicketNotFound)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	image, err := h.usecase.RenderOrderTicket(id, code, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrOrderNotFound),
+			errors.Is(err, utils.ErrIssuedTicketNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrIssuedTicketVoid) || errors.Is(err, utils.ErrOrderPaymentPending):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", image)
+	utils.SetLogMessage(c, "ticket image rendered successfully")
+}
+
+func (h *OrderHandler) RenderOrderTickets(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	document, err := h.usecase.RenderOrderTickets(id, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrOrderNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrIssuedTicketVoid) || errors.Is(err, utils.ErrOrderPaymentPending):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"order-%d-tickets.pdf\"", id))
+	c.Data(http.StatusOK, "application/pdf", document)
+	utils.SetLogMessage(c, "order tickets document rendered successfully")
+}
+
+func (h *OrderHandler) RenderTicket(c *gin.Context) {
+	// The router can't match a suffix inside a segment, so the extension is
+	// checked here.
+	code, ok := strings.CutSuffix(c.Param("code"), ".png")
+	if !ok {
+		utils.NotFoundResponse(c, utils.ErrIssuedTicketNotFound)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	image, err := h.usecase.RenderTicket(code, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrIssuedTicketNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrIssuedTicketVoid) || errors.Is(err, utils.ErrOrderPaymentPending):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", image)
+	utils.SetLogMessage(c, "ticket image rendered successfully")
+}
+
+func (h *OrderHandler) RenderTickets(c *gin.Context) {
+	v := utils.NewValidator()
+
+	eventID := utils.ReadIDQuery(c, "event_id", v)
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	document, err := h.usecase.RenderTickets(customer.ID, eventID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrIssuedTicketNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", "inline; filename=\"tickets.pdf\"")
+	c.Data(http.StatusOK, "application/pdf", document)
+	utils.SetLogMessage(c, "tickets document rendered successfully")
+}
+
+func (h *OrderHandler) Cancel(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	order, err := h.usecase.Cancel(id, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrOrderNotFound) || errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrOrderNotCancellable):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "order cancelled successfully",
+		Data:    order,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
 func (h *OrderHandler) DeleteAll(c *gin.Context) {
 	err := h.usecase.DeleteAll()
 	if err != nil {
-		utils.ServerErrorResponse(c, err)
+		switch {
+		case errors.Is(err, utils.ErrOrdersNotDeletable):
+			utils.ConflictResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
 		return
 	}
 
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/payment"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+const maxWebhookSize = 1 << 20
+
+type PaymentHandler struct {
+	usecase usecase.IPaymentUsecase
+}
+
+func NewPaymentHandler(usecase usecase.IPaymentUsecase) IPaymentHandler {
+	return &PaymentHandler{
+		usecase: usecase,
+	}
+}
+
+func (h *PaymentHandler) GetTopUps(c *gin.Context) {
+	customer := utils.GetCustomer(c)
+
+	topUps, err := h.usecase.GetTopUps(customer.ID)
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "top-ups retrieved successfully",
+		Data:    topUps,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *PaymentHandler) AddTopUp(c *gin.Context) {
+	var input request.TopUpRequest
+
+	err := utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(input.Amount != 0, "amount", "amount is required")
+	v.Check(input.Amount >= 0.01, "amount", "amount should be at least 0.01")
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	topUp, err := h.usecase.AddTopUp(&input, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrCustomerNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrPaymentProviderDisabled):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "top-up started successfully, the balance is credited once the payment is confirmed",
+		Data:    topUp,
+	}
+
+	utils.WriteJSON(c, http.StatusCreated, res)
+}
+
+func (h *PaymentHandler) RefundTopUp(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	topUp, err := h.usecase.RefundTopUp(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTopUpNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrTopUpNotRefundable) || errors.Is(err, utils.ErrInsufficientBalance):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "top-up refunded successfully",
+		Data:    topUp,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+// The body is read as it is, since the signature covers its exact bytes.
+func (h *PaymentHandler) Webhook(c *gin.Context) {
+	payload, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookSize))
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	err = h.usecase.HandleWebhook(payload, c.GetHeader("Payment-Signature"))
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrInvalidWebhookSignature):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrPaymentNotFound) || errors.Is(err, utils.ErrPaymentProviderDisabled):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrPaymentMismatch):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrTopUpNotPending) || errors.Is(err, utils.ErrOrderNotPending):
+			utils.ConflictResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "webhook processed successfully",
+		Data:    nil,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *PaymentHandler) Simulate(c *gin.Context) {
+	var input request.PaymentSimulationRequest
+
+	err := utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(input.IntentID != "", "intent_id", "intent_id is required")
+	v.Check(input.Outcome != "", "outcome", "outcome is required")
+	v.Check(utils.PermittedValue(input.Outcome, payment.EventPaymentSucceeded, payment.EventPaymentFailed), "outcome", "outcome should be 'payment.succeeded' or 'payment.failed'")
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	err = h.usecase.Simulate(&input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrPaymentNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrPaymentSimulationDisabled) || errors.Is(err, utils.ErrPaymentMismatch):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrPaymentSettled) || errors.Is(err, utils.ErrTopUpNotPending) || errors.Is(err, utils.ErrOrderNotPending):
+			utils.ConflictResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "payment simulated successfully",
+		Data:    nil,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
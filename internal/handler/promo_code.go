@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type PromoCodeHandler struct {
+	usecase usecase.IPromoCodeUsecase
+}
+
+func NewPromoCodeHandler(usecase usecase.IPromoCodeUsecase) IPromoCodeHandler {
+	return &PromoCodeHandler{
+		usecase: usecase,
+	}
+}
+
+func (h *PromoCodeHandler) GetAll(c *gin.Context) {
+	promoCodes, err := h.usecase.GetAll()
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "promo codes retrieved successfully",
+		Data:    promoCodes,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *PromoCodeHandler) GetByID(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	promoCode, err := h.usecase.GetByID(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrPromoCodeNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "promo code retrieved successfully",
+		Data:    promoCode,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *PromoCodeHandler) Add(c *gin.Context) {
+	var input request.PromoCodeRequest
+
+	err := utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	code := strings.TrimSpace(input.Code)
+	v.Check(code != "", "code", "code is required")
+	v.Check(len(code) <= 50, "code", "code should not be more than 50 bytes long")
+	v.Check(!strings.ContainsAny(code, " \t\n"), "code", "code should not contain spaces")
+	v.Check(utils.PermittedValue(input.DiscountType, domain.DiscountTypePercentage, domain.DiscountTypeFixed), "discount_type", "discount_type should be either percentage or fixed")
+	v.Check(input.DiscountValue > 0, "discount_value", "discount_value should be a positive number")
+	if input.DiscountType == domain.DiscountTypePercentage {
+		v.Check(input.DiscountValue <= 100, "discount_value", "discount_value should not be more than 100 percent")
+		v.Check(input.Currency == "", "currency", "currency should only be set for fixed discounts")
+	} else if input.Currency != "" {
+		v.Check(utils.Matches(input.Currency, utils.CurrencyRX), "currency", "currency should be an ISO 4217 currency code")
+	}
+	validatePromoCodeLimits(v, &input.PromoCodeLimitsRequest)
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	promoCode, err := h.usecase.Add(&input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrPromoCodeAlreadyExists):
+			utils.ConflictResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "promo code added successfully",
+		Data:    promoCode,
+	}
+
+	utils.WriteJSON(c, http.StatusCreated, res)
+}
+
+func (h *PromoCodeHandler) UpdateLimits(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.PromoCodeLimitsRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	validatePromoCodeLimits(v, &input)
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	promoCode, err := h.usecase.UpdateLimits(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrPromoCodeNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "promo code limits updated successfully",
+		Data:    promoCode,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func validatePromoCodeLimits(v *utils.Validator, input *request.PromoCodeLimitsRequest) {
+	if input.MaxRedemptions != nil {
+		v.Check(*input.MaxRedemptions > 0, "max_redemptions", "max_redemptions should be a positive number")
+	}
+	if input.MaxPerCustomer != nil {
+		v.Check(*input.MaxPerCustomer > 0, "max_per_customer", "max_per_customer should be a positive number")
+	}
+	if input.ValidFrom != nil && input.ValidUntil != nil {
+		v.Check(input.ValidFrom.Before(*input.ValidUntil), "valid_until", "valid_until should be after valid_from")
+	}
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type ResaleListingHandler struct {
+	usecase usecase.IResaleListingUsecase
+}
+
+func NewResaleListingHandler(usecase usecase.IResaleListingUsecase) IResaleListingHandler {
+	return &ResaleListingHandler{
+		usecase: usecase,
+	}
+}
+
+func (h *ResaleListingHandler) GetAll(c *gin.Context) {
+	listings, err := h.usecase.GetAll()
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "resale listings retrieved successfully",
+		Data:    listings,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *ResaleListingHandler) Add(c *gin.Context) {
+	var input request.ResaleListingRequest
+
+	err := utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(input.TicketCode != "", "ticket_code", "ticket_code is required")
+	v.Check(input.Price != 0, "price", "price is required")
+	v.Check(input.Price > 0, "price", "price should not be a negative number")
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	listing, err := h.usecase.Add(&input, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrIssuedTicketNotFound) || errors.Is(err, utils.ErrTicketNotFound) || errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrTicketListedForResale) || errors.Is(err, utils.ErrTicketTransferPending):
+			utils.ConflictResponse(c, err)
+		case errors.Is(err, utils.ErrResalePriceTooHigh) || errors.Is(err, utils.ErrTicketNotTransferable) || errors.Is(err, utils.ErrIssuedTicketVoid):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrEventCancelled) || errors.Is(err, utils.ErrTicketTransferClosed):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "ticket listed for resale successfully",
+		Data:    listing,
+	}
+
+	utils.WriteJSON(c, http.StatusCreated, res)
+}
+
+func (h *ResaleListingHandler) Purchase(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	listing, err := h.usecase.Purchase(id, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrResaleListingNotFound) || errors.Is(err, utils.ErrIssuedTicketNotFound) || errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrResaleListingNotAvailable):
+			utils.ConflictResponse(c, err)
+		case errors.Is(err, utils.ErrInsufficientBalance) || errors.Is(err, utils.ErrResaleOwnListing) || errors.Is(err, utils.ErrCurrencyNotConvertible):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrTicketNotTransferable) || errors.Is(err, utils.ErrIssuedTicketVoid):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrEventCancelled) || errors.Is(err, utils.ErrTicketTransferClosed):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "resale ticket purchased successfully",
+		Data:    listing,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *ResaleListingHandler) Cancel(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	listing, err := h.usecase.Cancel(id, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrResaleListingNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrResaleListingNotAvailable):
+			utils.ConflictResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "resale listing cancelled successfully",
+		Data:    listing,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
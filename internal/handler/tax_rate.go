@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type TaxRateHandler struct {
+	usecase usecase.ITaxRateUsecase
+}
+
+func NewTaxRateHandler(usecase usecase.ITaxRateUsecase) ITaxRateHandler {
+	return &TaxRateHandler{
+		usecase: usecase,
+	}
+}
+
+func (h *TaxRateHandler) GetAll(c *gin.Context) {
+	taxRates, err := h.usecase.GetAll()
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "tax rates retrieved successfully",
+		Data:    taxRates,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *TaxRateHandler) Add(c *gin.Context) {
+	var input request.TaxRateRequest
+
+	err := utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	jurisdiction := strings.TrimSpace(input.Jurisdiction)
+	v.Check(jurisdiction != "", "jurisdiction", "jurisdiction is required")
+	v.Check(utils.Matches(jurisdiction, utils.JurisdictionRX), "jurisdiction", "jurisdiction should be an ISO 3166 country or subdivision code")
+	validateTaxRate(v, &input.TaxRateUpdateRequest)
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	taxRate, err := h.usecase.Add(&input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTaxRateAlreadyExists):
+			utils.ConflictResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "tax rate added successfully",
+		Data:    taxRate,
+	}
+
+	utils.WriteJSON(c, http.StatusCreated, res)
+}
+
+func (h *TaxRateHandler) Update(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.TaxRateUpdateRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	validateTaxRate(v, &input)
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	taxRate, err := h.usecase.Update(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTaxRateNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "tax rate updated successfully",
+		Data:    taxRate,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *TaxRateHandler) Delete(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	err = h.usecase.Delete(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTaxRateNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "tax rate deleted successfully",
+		Data:    nil,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func validateTaxRate(v *utils.Validator, input *request.TaxRateUpdateRequest) {
+	v.Check(strings.TrimSpace(input.Name) != "", "name", "name is required")
+	v.Check(input.Rate >= 0 && input.Rate <= 100, "rate", "rate should be between 0 and 100 percent")
+}
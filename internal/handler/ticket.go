@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -96,7 +97,7 @@ func (h *TicketHandler) UpdateQuantity(c *gin.Context) {
 		switch {
 		case errors.Is(err, utils.ErrTicketNotFound):
 			utils.NotFoundResponse(c, err)
-		case errors.Is(err, utils.ErrInsufficientTicketQuantity):
+		case errors.Is(err, utils.ErrInsufficientTicketQuantity) || errors.Is(err, utils.ErrVenueCapacityExceeded):
 			utils.BadRequestResponse(c, err)
 		default:
 			utils.ServerErrorResponse(c, err)
@@ -112,3 +113,371 @@ func (h *TicketHandler) UpdateQuantity(c *gin.Context) {
 
 	utils.WriteJSON(c, http.StatusOK, res)
 }
+
+func (h *TicketHandler) UpdateSalesWindow(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.TicketSalesWindowRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	if input.SalesStart != nil && input.SalesEnd != nil {
+		v.Check(input.SalesStart.Before(*input.SalesEnd), "sales_end", "sales_end should be after sales_start")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	ticket, err := h.usecase.UpdateSalesWindow(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTicketNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "ticket sales window updated successfully",
+		Data:    ticket,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *TicketHandler) UpdatePurchaseLimit(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.TicketPurchaseLimitRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	if input.MaxPerCustomer != nil {
+		v.Check(*input.MaxPerCustomer > 0, "max_per_customer", "max_per_customer should be a positive number")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	ticket, err := h.usecase.UpdatePurchaseLimit(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTicketNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "ticket purchase limit updated successfully",
+		Data:    ticket,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *TicketHandler) GetPresales(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	presales, err := h.usecase.GetPresales(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTicketNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "presales retrieved successfully",
+		Data:    presales,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *TicketHandler) AddPresale(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.PresaleRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(input.Name != "", "name", "name is required")
+	v.Check(!input.StartsAt.IsZero(), "starts_at", "starts_at is required")
+	v.Check(!input.EndsAt.IsZero(), "ends_at", "ends_at is required")
+	v.Check(input.StartsAt.Before(input.EndsAt), "ends_at", "ends_at should be after starts_at")
+	v.Check(input.AccessCode != nil || len(input.CustomerIDs) != 0, "access_code", "access_code or customer_ids is required")
+	if input.AccessCode != nil {
+		v.Check(*input.AccessCode != "", "access_code", "access_code should not be empty")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	presale, err := h.usecase.AddPresale(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTicketNotFound) || errors.Is(err, utils.ErrCustomerNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "presale added successfully",
+		Data:    presale,
+	}
+
+	utils.WriteJSON(c, http.StatusCreated, res)
+}
+
+func (h *TicketHandler) GetPrice(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	price, err := h.usecase.GetPrice(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTicketNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "ticket price retrieved successfully",
+		Data:    price,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *TicketHandler) GetPriceTiers(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	tiers, err := h.usecase.GetPriceTiers(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTicketNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "price tiers retrieved successfully",
+		Data:    tiers,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *TicketHandler) AddPriceTier(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.PriceTierRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(input.Name != "", "name", "name is required")
+	v.Check(input.Price >= 0, "price", "price should not be negative")
+	v.Check(input.EndsAt != nil || input.MaxSold != nil, "ends_at", "ends_at or max_sold is required")
+	if input.EndsAt != nil {
+		v.Check(!input.EndsAt.IsZero(), "ends_at", "ends_at should not be empty")
+	}
+	if input.MaxSold != nil {
+		v.Check(*input.MaxSold > 0, "max_sold", "max_sold should be greater than 0")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	tier, err := h.usecase.AddPriceTier(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTicketNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "price tier added successfully",
+		Data:    tier,
+	}
+
+	utils.WriteJSON(c, http.StatusCreated, res)
+}
+
+func (h *TicketHandler) DeletePriceTier(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	tierID, err := utils.ReadNamedIDParam(c, "tier_id")
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	tier, err := h.usecase.DeletePriceTier(id, tierID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrPriceTierNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "price tier deleted successfully",
+		Data:    tier,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *TicketHandler) AssignSeats(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.TicketSeatsRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(len(input.Blocks) > 0, "blocks", "blocks is required")
+
+	for i, block := range input.Blocks {
+		v.Check(block != nil, fmt.Sprintf("blocks[%d]", i), "block is required")
+		if block == nil {
+			continue
+		}
+
+		v.Check(block.Section != "", fmt.Sprintf("blocks[%d].section", i), "section is required")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	ticket, err := h.usecase.AssignSeats(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTicketNotFound) || errors.Is(err, utils.ErrEventNotFound) || errors.Is(err, utils.ErrVenueNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrSeatNotFound) || errors.Is(err, utils.ErrEventWithoutVenue):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrVenueCapacityExceeded):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrSeatAlreadyAssigned) || errors.Is(err, utils.ErrTicketSeatsLocked):
+			utils.ConflictResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "ticket seats assigned successfully",
+		Data:    ticket,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type TicketTransferHandler struct {
+	usecase usecase.ITicketTransferUsecase
+}
+
+func NewTicketTransferHandler(usecase usecase.ITicketTransferUsecase) ITicketTransferHandler {
+	return &TicketTransferHandler{
+		usecase: usecase,
+	}
+}
+
+func (h *TicketTransferHandler) GetAll(c *gin.Context) {
+	customer := utils.GetCustomer(c)
+
+	transfers, err := h.usecase.GetAll(customer.ID)
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "ticket transfers retrieved successfully",
+		Data:    transfers,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *TicketTransferHandler) Add(c *gin.Context) {
+	var input request.TicketTransferRequest
+
+	err := utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	input.RecipientUsername = strings.TrimSpace(input.RecipientUsername)
+
+	v := utils.NewValidator()
+
+	v.Check(input.RecipientUsername != "", "recipient_username", "recipient_username is required")
+	v.Check(input.OrderID != 0 || len(input.TicketCodes) != 0, "ticket_codes", "order_id or ticket_codes is required")
+	v.Check(input.OrderID == 0 || len(input.TicketCodes) == 0, "ticket_codes", "ticket_codes should not be combined with order_id")
+	v.Check(input.OrderID >= 0, "order_id", "order_id should not be a negative number")
+
+	for i, code := range input.TicketCodes {
+		v.Check(code != "", fmt.Sprintf("ticket_codes[%d]", i), "ticket code is required")
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	transfer, err := h.usecase.Add(&input, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrCustomerNotFound) || errors.Is(err, utils.ErrOrderNotFound) || errors.Is(err, utils.ErrIssuedTicketNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrTicketTransferPending) || errors.Is(err, utils.ErrTicketListedForResale):
+			utils.ConflictResponse(c, err)
+		case errors.Is(err, utils.ErrTicketTransferToSelf) || errors.Is(err, utils.ErrTicketNotTransferable) || errors.Is(err, utils.ErrIssuedTicketVoid):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrEventCancelled) || errors.Is(err, utils.ErrTicketTransferClosed):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "ticket transfer created successfully",
+		Data:    transfer,
+	}
+
+	utils.WriteJSON(c, http.StatusCreated, res)
+}
+
+func (h *TicketTransferHandler) Accept(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	transfer, err := h.usecase.Accept(id, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTicketTransferNotFound) || errors.Is(err, utils.ErrIssuedTicketNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrTicketTransferNotPending):
+			utils.ConflictResponse(c, err)
+		case errors.Is(err, utils.ErrTicketNotTransferable) || errors.Is(err, utils.ErrIssuedTicketVoid):
+			utils.BadRequestResponse(c, err)
+		case errors.Is(err, utils.ErrEventCancelled) || errors.Is(err, utils.ErrTicketTransferClosed):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "ticket transfer accepted successfully",
+		Data:    transfer,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *TicketTransferHandler) Cancel(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	transfer, err := h.usecase.Cancel(id, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTicketTransferNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrTicketTransferNotPending):
+			utils.ConflictResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "ticket transfer " + string(transfer.Status) + " successfully",
+		Data:    transfer,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
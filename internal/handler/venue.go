@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type VenueHandler struct {
+	usecase usecase.IVenueUsecase
+}
+
+func NewVenueHandler(usecase usecase.IVenueUsecase) IVenueHandler {
+	return &VenueHandler{
+		usecase: usecase,
+	}
+}
+
+func (h *VenueHandler) GetAll(c *gin.Context) {
+	venues, err := h.usecase.GetAll()
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "venues retrieved successfully",
+		Data:    venues,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *VenueHandler) GetByID(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	venue, err := h.usecase.GetByID(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrVenueNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "venue retrieved successfully",
+		Data:    venue,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *VenueHandler) Add(c *gin.Context) {
+	var input request.VenueRequest
+
+	err := utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(input.Name != "", "name", "name is required")
+	v.Check(input.Capacity > 0, "capacity", "capacity should be a positive number")
+	if input.Timezone != "" {
+		v.Check(utils.ValidTimezone(input.Timezone), "timezone", "timezone should be a valid IANA time zone")
+	}
+	validateCoordinates(v, input.Latitude, input.Longitude)
+	validateTaxJurisdiction(v, input.TaxJurisdiction)
+
+	sectionNames := make(map[string]bool)
+	for i, section := range input.Sections {
+		v.Check(section != nil, fmt.Sprintf("sections[%d]", i), "section is required")
+		if section == nil {
+			continue
+		}
+
+		v.Check(section.Name != "", fmt.Sprintf("sections[%d].name", i), "name is required")
+		v.Check(!sectionNames[section.Name], fmt.Sprintf("sections[%d].name", i), "name should be unique within the venue")
+		sectionNames[section.Name] = true
+
+		rowLabels := make(map[string]bool)
+		for j, row := range section.Rows {
+			v.Check(row != nil, fmt.Sprintf("sections[%d].rows[%d]", i, j), "row is required")
+			if row == nil {
+				continue
+			}
+
+			v.Check(row.Label != "", fmt.Sprintf("sections[%d].rows[%d].label", i, j), "label is required")
+			v.Check(!rowLabels[row.Label], fmt.Sprintf("sections[%d].rows[%d].label", i, j), "label should be unique within the section")
+			v.Check(row.Seats > 0, fmt.Sprintf("sections[%d].rows[%d].seats", i, j), "seats should be a positive number")
+			rowLabels[row.Label] = true
+		}
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	venue, err := h.usecase.Add(&input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrVenueCapacityExceeded):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "venue added successfully",
+		Data:    venue,
+	}
+
+	utils.WriteJSON(c, http.StatusCreated, res)
+}
+
+func (h *VenueHandler) Update(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.VenueUpdateRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	if input.Name != nil {
+		v.Check(*input.Name != "", "name", "name should not be empty")
+	}
+	if input.Capacity != nil {
+		v.Check(*input.Capacity > 0, "capacity", "capacity should be a positive number")
+	}
+	if input.Timezone != nil {
+		v.Check(utils.ValidTimezone(*input.Timezone), "timezone", "timezone should be a valid IANA time zone")
+	}
+	validateCoordinates(v, input.Latitude, input.Longitude)
+	if input.TaxJurisdiction != nil {
+		validateTaxJurisdiction(v, *input.TaxJurisdiction)
+	}
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	venue, err := h.usecase.Update(id, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrVenueNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrVenueCapacityExceeded):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "venue updated successfully",
+		Data:    venue,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *VenueHandler) Delete(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	err = h.usecase.Delete(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrVenueNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrVenueInUse):
+			utils.ConflictResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "venue deleted successfully",
+		Data:    nil,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func validateCoordinates(v *utils.Validator, latitude *float64, longitude *float64) {
+	v.Check((latitude == nil) == (longitude == nil), "coordinates", "latitude and longitude should be given together")
+	if latitude != nil {
+		v.Check(*latitude >= -90 && *latitude <= 90, "latitude", "latitude should be between -90 and 90")
+	}
+	if longitude != nil {
+		v.Check(*longitude >= -180 && *longitude <= 180, "longitude", "longitude should be between -180 and 180")
+	}
+}
+
+func validateTaxJurisdiction(v *utils.Validator, jurisdiction string) {
+	if jurisdiction = strings.TrimSpace(jurisdiction); jurisdiction != "" {
+		v.Check(utils.Matches(jurisdiction, utils.JurisdictionRX), "tax_jurisdiction", "tax_jurisdiction should be an ISO 3166 country or subdivision code")
+	}
+}
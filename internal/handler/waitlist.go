@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/usecase"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type WaitlistHandler struct {
+	usecase usecase.IWaitlistUsecase
+}
+
+func NewWaitlistHandler(usecase usecase.IWaitlistUsecase) IWaitlistHandler {
+	return &WaitlistHandler{
+		usecase: usecase,
+	}
+}
+
+func (h *WaitlistHandler) GetAll(c *gin.Context) {
+	customer := utils.GetCustomer(c)
+
+	entries, err := h.usecase.GetAll(customer.ID)
+	if err != nil {
+		utils.ServerErrorResponse(c, err)
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "waitlist entries retrieved successfully",
+		Data:    entries,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
+
+func (h *WaitlistHandler) Join(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	var input request.WaitlistRequest
+
+	err = utils.ReadJSON(c, &input)
+	if err != nil {
+		utils.BadRequestResponse(c, err)
+		return
+	}
+
+	v := utils.NewValidator()
+
+	v.Check(input.Quantity != 0, "quantity", "quantity is required")
+	v.Check(input.Quantity > 0, "quantity", "quantity should not be a negative number")
+
+	if !v.Valid() {
+		utils.FailedValidationResponse(c, v.Errors)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	entry, err := h.usecase.Join(id, customer.ID, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTicketNotFound) || errors.Is(err, utils.ErrEventNotFound):
+			utils.NotFoundResponse(c, err)
+		case errors.Is(err, utils.ErrAlreadyWaitlisted) || errors.Is(err, utils.ErrTicketAvailable):
+			utils.ConflictResponse(c, err)
+		case errors.Is(err, utils.ErrEventCancelled) || errors.Is(err, utils.ErrTicketSalesEnded):
+			utils.BadRequestResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "joined the waitlist successfully",
+		Data:    entry,
+	}
+
+	utils.WriteJSON(c, http.StatusCreated, res)
+}
+
+func (h *WaitlistHandler) Leave(c *gin.Context) {
+	id, err := utils.ReadIDParam(c)
+	if err != nil {
+		utils.BadRequestResponse(c, utils.ErrInvalidID)
+		return
+	}
+
+	customer := utils.GetCustomer(c)
+
+	entry, err := h.usecase.Leave(id, customer.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrWaitlistEntryNotFound):
+			utils.NotFoundResponse(c, err)
+		default:
+			utils.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	res := response.SuccessResponse{
+		Status:  response.Success,
+		Message: "left the waitlist successfully",
+		Data:    entry,
+	}
+
+	utils.WriteJSON(c, http.StatusOK, res)
+}
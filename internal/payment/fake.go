@@ -0,0 +1,155 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"sync"
+)
+
+// Intents are kept in memory, so the ones made before a restart can no longer
+// be completed, though they can still be refunded.
+type FakeProvider struct {
+	secret  []byte
+	mu      sync.Mutex
+	intents map[string]*fakeIntent
+}
+
+type fakeIntent struct {
+	amount   float64
+	currency string
+	outcome  EventType
+	refunded float64
+	refunds  map[string]bool
+}
+
+func NewFakeProvider(secret string) PaymentProvider {
+	return &FakeProvider{
+		secret:  []byte(secret),
+		intents: make(map[string]*fakeIntent),
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakeProvider) CreateIntent(amount float64, currency string, description string) (*Intent, error) {
+	id, err := randomID("pi_fake_")
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := randomID(id + "_secret_")
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.intents[id] = &fakeIntent{
+		amount:   amount,
+		currency: currency,
+		refunds:  make(map[string]bool),
+	}
+
+	return &Intent{ID: id, ClientSecret: secret}, nil
+}
+
+func (p *FakeProvider) ParseWebhook(payload []byte, signature string) (*Event, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, p.sign(payload)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event Event
+	err = json.Unmarshal(payload, &event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func (p *FakeProvider) Refund(intentID string, amount float64, idempotencyKey string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return nil
+	}
+
+	if intent.refunds[idempotencyKey] {
+		return nil
+	}
+
+	if intent.outcome != EventPaymentSucceeded {
+		return ErrIntentNotPaid
+	}
+
+	if math.Round((intent.refunded+amount)*100) > math.Round(intent.amount*100) {
+		return ErrRefundExceeded
+	}
+
+	intent.refunded += amount
+	intent.refunds[idempotencyKey] = true
+
+	return nil
+}
+
+func (p *FakeProvider) Simulate(intentID string, eventType EventType) ([]byte, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return nil, "", ErrIntentNotFound
+	}
+
+	if intent.outcome != "" && intent.outcome != eventType {
+		return nil, "", ErrIntentCompleted
+	}
+
+	id, err := randomID("evt_fake_")
+	if err != nil {
+		return nil, "", err
+	}
+
+	event := &Event{
+		ID:       id,
+		Type:     eventType,
+		IntentID: intentID,
+		Amount:   intent.amount,
+		Currency: intent.currency,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, "", err
+	}
+
+	intent.outcome = eventType
+
+	return payload, hex.EncodeToString(p.sign(payload)), nil
+}
+
+func (p *FakeProvider) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func randomID(prefix string) (string, error) {
+	b := make([]byte, 12)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return prefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,43 @@
+package payment
+
+import "errors"
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrIntentNotFound   = errors.New("payment intent not found")
+	ErrIntentCompleted  = errors.New("payment intent already completed")
+	ErrIntentNotPaid    = errors.New("payment intent has not been paid")
+	ErrRefundExceeded   = errors.New("refund exceeds the amount paid")
+)
+
+type PaymentProvider interface {
+	Name() string
+	CreateIntent(amount float64, currency string, description string) (*Intent, error)
+	ParseWebhook(payload []byte, signature string) (*Event, error)
+	// A refund sent again with the same idempotency key is only paid once.
+	Refund(intentID string, amount float64, idempotencyKey string) error
+}
+
+type Simulator interface {
+	Simulate(intentID string, eventType EventType) (payload []byte, signature string, err error)
+}
+
+type Intent struct {
+	ID           string
+	ClientSecret string
+}
+
+type EventType string
+
+var (
+	EventPaymentSucceeded EventType = "payment.succeeded"
+	EventPaymentFailed    EventType = "payment.failed"
+)
+
+type Event struct {
+	ID       string    `json:"id"`
+	Type     EventType `json:"type"`
+	IntentID string    `json:"intent_id"`
+	Amount   float64   `json:"amount"`
+	Currency string    `json:"currency"`
+}
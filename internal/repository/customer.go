@@ -26,7 +26,7 @@ func (r *CustomerRepository) GetAll() ([]*domain.Customer, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	query := "SELECT id, username, balance FROM customers"
+	query := "SELECT id, username, balance, currency, role FROM customers"
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -47,7 +47,7 @@ func (r *CustomerRepository) GetAll() ([]*domain.Customer, error) {
 	for rows.Next() {
 		var customer domain.Customer
 
-		err = rows.Scan(&customer.ID, &customer.Username, &customer.Balance)
+		err = rows.Scan(&customer.ID, &customer.Username, &customer.Balance, &customer.Currency, &customer.Role)
 		if err != nil {
 			return nil, err
 		}
@@ -67,11 +67,11 @@ func (r *CustomerRepository) Add(customer *domain.Customer) error {
 	defer r.mu.Unlock()
 
 	query := `
-		INSERT INTO customers (username, password_hash, balance)
-		VALUES ($1, $2, $3)
-		RETURNING id
+		INSERT INTO customers (username, password_hash, balance, currency)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, role
 	`
-	args := []any{customer.Username, customer.Password, customer.Balance}
+	args := []any{customer.Username, customer.Password, customer.Balance, customer.Currency}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -82,7 +82,7 @@ func (r *CustomerRepository) Add(customer *domain.Customer) error {
 	}
 	defer stmt.Close()
 
-	err = stmt.QueryRowContext(ctx, args...).Scan(&customer.ID)
+	err = stmt.QueryRowContext(ctx, args...).Scan(&customer.ID, &customer.Role)
 	if err != nil {
 		switch {
 		case err.Error() == `ERROR: duplicate key value violates unique constraint "customers_username_key" (SQLSTATE 23505)`:
@@ -100,7 +100,7 @@ func (r *CustomerRepository) GetByID(customerID int64) (*domain.Customer, error)
 	defer r.mu.Unlock()
 
 	query := `
-		SELECT id, username, password_hash, balance 
+		SELECT id, username, password_hash, balance, currency, role
 		FROM customers 
 		WHERE id = $1
 	`
@@ -121,6 +121,8 @@ func (r *CustomerRepository) GetByID(customerID int64) (*domain.Customer, error)
 		&customer.Username,
 		&customer.Password,
 		&customer.Balance,
+		&customer.Currency,
+		&customer.Role,
 	)
 
 	if err != nil {
@@ -140,7 +142,7 @@ func (r *CustomerRepository) GetByUsername(username string) (*domain.Customer, e
 	defer r.mu.Unlock()
 
 	query := `
-		SELECT id, username, password_hash, balance
+		SELECT id, username, password_hash, balance, currency, role
 		FROM customers
 		WHERE username = $1
 	`
@@ -161,6 +163,8 @@ func (r *CustomerRepository) GetByUsername(username string) (*domain.Customer, e
 		&customer.Username,
 		&customer.Password,
 		&customer.Balance,
+		&customer.Currency,
+		&customer.Role,
 	)
 	if err != nil {
 		switch {
@@ -182,7 +186,7 @@ func (r *CustomerRepository) AddBalance(customerID int64, amount float64) (*doma
 		UPDATE customers
 		SET balance = balance + $1
 		WHERE id = $2
-		RETURNING id, username, balance
+		RETURNING id, username, balance, currency, role
 	`
 	args := []any{amount, customerID}
 
@@ -201,6 +205,8 @@ func (r *CustomerRepository) AddBalance(customerID int64, amount float64) (*doma
 		&customer.ID,
 		&customer.Username,
 		&customer.Balance,
+		&customer.Currency,
+		&customer.Role,
 	)
 	if err != nil {
 		return nil, err
@@ -217,7 +223,7 @@ func (r *CustomerRepository) DeductBalance(customerID int64, amount float64) (*d
 		UPDATE customers
 		SET balance = balance - $1
 		WHERE id = $2
-		RETURNING id, username, balance
+		RETURNING id, username, balance, currency, role
 	`
 	args := []any{amount, customerID}
 
@@ -236,6 +242,8 @@ func (r *CustomerRepository) DeductBalance(customerID int64, amount float64) (*d
 		&customer.ID,
 		&customer.Username,
 		&customer.Balance,
+		&customer.Currency,
+		&customer.Role,
 	)
 	if err != nil {
 		switch {
@@ -3,14 +3,23 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
 	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
 )
 
+// Only letters and digits are kept, so a search text can't carry tsquery
+// operators.
+var searchWordRX = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
 type EventRepository struct {
 	db *sql.DB
 	mu sync.Mutex
@@ -22,11 +31,367 @@ func NewEventRepository(db *sql.DB) IEventRepository {
 	}
 }
 
-func (r *EventRepository) GetAll() ([]*domain.Event, error) {
+func (r *EventRepository) GetAll(filters *domain.EventFilters) ([]*domain.EventDetail, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	typeMap := pgtype.NewMap()
+
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filters.Query != "" {
+		conditions = append(conditions, fmt.Sprintf("E.name ILIKE '%%' || %s || '%%'", arg(escapeLike(filters.Query))))
+	}
+	if filters.From != nil {
+		conditions = append(conditions, "E.starts_at >= "+arg(*filters.From))
+	}
+	if filters.To != nil {
+		conditions = append(conditions, "E.starts_at < "+arg(*filters.To))
+	}
+	if filters.VenueID != nil {
+		conditions = append(conditions, "E.venue_id = "+arg(*filters.VenueID))
+	}
+	if filters.Category != nil {
+		conditions = append(conditions, "E.category = "+arg(*filters.Category))
+	}
+	if filters.Tag != "" {
+		conditions = append(conditions, fmt.Sprintf("E.tags @> ARRAY[%s]::TEXT[]", arg(filters.Tag)))
+	}
+	if filters.Available {
+		conditions = append(conditions, fmt.Sprintf(
+			"E.status = %s AND E.starts_at > NOW() AND EXISTS (SELECT 1 FROM tickets T WHERE T.event_id = E.id AND T.quantity > 0)",
+			arg(domain.EventStatusScheduled),
+		))
+	}
+
+	column := "E.starts_at"
+	if strings.TrimPrefix(filters.Sort, "-") == "name" {
+		column = "E.name"
+	}
+	direction, comparison := "ASC", ">"
+	if strings.HasPrefix(filters.Sort, "-") {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filters.Cursor != nil {
+		var position any = filters.Cursor.StartsAt
+		if column == "E.name" {
+			position = filters.Cursor.Name
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, E.id) %s (%s, %s)", column, comparison, arg(position), arg(filters.Cursor.ID)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT E.id, E.name, E.starts_at, E.ends_at, E.doors_open_at, E.timezone, E.status, E.max_tickets_per_customer, E.venue_id, E.series_id,
+			E.description, E.description_html, E.category, E.tags, E.min_age, E.organizer_name, E.organizer_email, E.organizer_website,
+			E.booking_fee_fixed, E.booking_fee_percentage, E.booking_fee_currency,
+			V.name, V.address, V.timezone, V.capacity, V.latitude, V.longitude, V.tax_jurisdiction,
+			COALESCE((
+				SELECT JSON_AGG(JSON_BUILD_OBJECT(
+					'id', T.id,
+					'event_id', T.event_id,
+					'quantity', T.quantity,
+					'sales_start', T.sales_start,
+					'sales_end', T.sales_end,
+					'max_per_customer', T.max_per_customer,
+					'type', JSON_BUILD_OBJECT('id', TT.id, 'name', TT.name, 'price', TT.price, 'currency', TT.currency)
+				) ORDER BY T.id)
+				FROM tickets T
+				JOIN ticket_types TT ON T.ticket_type_id = TT.id
+				WHERE T.event_id = E.id
+			), '[]')
+		FROM events E
+		LEFT JOIN venues V ON E.venue_id = V.id
+		%s
+		ORDER BY %s %s, E.id %s
+		LIMIT %s
+	`, where, column, direction, direction, arg(filters.Limit))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*domain.EventDetail, 0)
+	for rows.Next() {
+		var event domain.EventDetail
+		var venueName, venueAddress, venueTimezone, venueTaxJurisdiction *string
+		var venueCapacity *int
+		var venueLatitude, venueLongitude *float64
+		var tickets []byte
+
+		err := rows.Scan(
+			&event.ID,
+			&event.Name,
+			&event.StartsAt,
+			&event.EndsAt,
+			&event.DoorsOpenAt,
+			&event.Timezone,
+			&event.Status,
+			&event.MaxTicketsPerCustomer,
+			&event.VenueID,
+			&event.SeriesID,
+			&event.Description,
+			&event.DescriptionHTML,
+			&event.Category,
+			typeMap.SQLScanner(&event.Tags),
+			&event.MinAge,
+			&event.Organizer.Name,
+			&event.Organizer.Email,
+			&event.Organizer.Website,
+			&event.BookingFee.Fixed,
+			&event.BookingFee.Percentage,
+			&event.BookingFee.Currency,
+			&venueName,
+			&venueAddress,
+			&venueTimezone,
+			&venueCapacity,
+			&venueLatitude,
+			&venueLongitude,
+			&venueTaxJurisdiction,
+			&tickets,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if event.VenueID != nil {
+			event.Venue = &domain.Venue{
+				ID:              *event.VenueID,
+				Name:            *venueName,
+				Address:         *venueAddress,
+				Timezone:        *venueTimezone,
+				Capacity:        *venueCapacity,
+				Latitude:        venueLatitude,
+				Longitude:       venueLongitude,
+				TaxJurisdiction: venueTaxJurisdiction,
+			}
+		}
+
+		err = json.Unmarshal(tickets, &event.Tickets)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *EventRepository) Search(text string, limit int) ([]*domain.EventMatch, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	typeMap := pgtype.NewMap()
+
+	words := searchWordRX.FindAllString(strings.ToLower(text), -1)
+	if len(words) == 0 {
+		return make([]*domain.EventMatch, 0), nil
+	}
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	query := `
+		WITH Q AS (
+			SELECT to_tsquery('english', $1) AS query
+		)
+		SELECT E.id, E.name, E.starts_at, E.ends_at, E.doors_open_at, E.timezone, E.status, E.max_tickets_per_customer, E.venue_id, E.series_id,
+			E.description, E.description_html, E.category, E.tags, E.min_age, E.organizer_name, E.organizer_email, E.organizer_website,
+			E.booking_fee_fixed, E.booking_fee_percentage, E.booking_fee_currency,
+			ts_rank_cd(E.search_vector, Q.query) AS rank,
+			ts_headline('english', E.name, Q.query, $2),
+			CASE WHEN V.id IS NOT NULL THEN ts_headline('english', V.name, Q.query, $2) END,
+			CASE WHEN to_tsvector('english', E.description) @@ Q.query THEN ts_headline('english', E.description, Q.query, $3) END,
+			CASE WHEN to_tsvector('english', array_to_string(E.tags, ' ')) @@ Q.query THEN ts_headline('english', array_to_string(E.tags, ' '), Q.query, $2) END
+		FROM events E
+		CROSS JOIN Q
+		LEFT JOIN venues V ON E.venue_id = V.id
+		WHERE E.search_vector @@ Q.query
+		ORDER BY rank DESC, E.starts_at, E.id
+		LIMIT $4
+	`
+	options := fmt.Sprintf("StartSel=%q, StopSel=%q, HighlightAll=true", domain.HeadlineStart, domain.HeadlineStop)
+	fragmentOptions := fmt.Sprintf("StartSel=%q, StopSel=%q, MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter=\" ... \"", domain.HeadlineStart, domain.HeadlineStop)
+	args := []any{strings.Join(words, " & "), options, fragmentOptions, limit}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches := make([]*domain.EventMatch, 0)
+	for rows.Next() {
+		var match domain.EventMatch
+
+		err := rows.Scan(
+			&match.ID,
+			&match.Name,
+			&match.StartsAt,
+			&match.EndsAt,
+			&match.DoorsOpenAt,
+			&match.Timezone,
+			&match.Status,
+			&match.MaxTicketsPerCustomer,
+			&match.VenueID,
+			&match.SeriesID,
+			&match.Description,
+			&match.DescriptionHTML,
+			&match.Category,
+			typeMap.SQLScanner(&match.Tags),
+			&match.MinAge,
+			&match.Organizer.Name,
+			&match.Organizer.Email,
+			&match.Organizer.Website,
+			&match.BookingFee.Fixed,
+			&match.BookingFee.Percentage,
+			&match.BookingFee.Currency,
+			&match.Rank,
+			&match.NameHeadline,
+			&match.VenueHeadline,
+			&match.DescriptionHeadline,
+			&match.TagsHeadline,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, &match)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+func (r *EventRepository) GetBySeriesID(seriesID int64) ([]*domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	typeMap := pgtype.NewMap()
+
+	query := `
+		SELECT id, name, starts_at, ends_at, doors_open_at, timezone, status, max_tickets_per_customer, venue_id, series_id,
+			description, description_html, category, tags, min_age, organizer_name, organizer_email, organizer_website,
+			booking_fee_fixed, booking_fee_percentage, booking_fee_currency
+		FROM events
+		WHERE series_id = $1
+		ORDER BY starts_at
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*domain.Event, 0)
+	for rows.Next() {
+		var event domain.Event
+
+		err := rows.Scan(
+			&event.ID,
+			&event.Name,
+			&event.StartsAt,
+			&event.EndsAt,
+			&event.DoorsOpenAt,
+			&event.Timezone,
+			&event.Status,
+			&event.MaxTicketsPerCustomer,
+			&event.VenueID,
+			&event.SeriesID,
+			&event.Description,
+			&event.DescriptionHTML,
+			&event.Category,
+			typeMap.SQLScanner(&event.Tags),
+			&event.MinAge,
+			&event.Organizer.Name,
+			&event.Organizer.Email,
+			&event.Organizer.Website,
+			&event.BookingFee.Fixed,
+			&event.BookingFee.Percentage,
+			&event.BookingFee.Currency,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *EventRepository) GetUpcomingByCustomerID(customerID int64, now time.Time) ([]*domain.Event, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	query := "SELECT id, name, date FROM events"
+	typeMap := pgtype.NewMap()
+
+	query := `
+		SELECT E.id, E.name, E.starts_at, E.ends_at, E.doors_open_at, E.timezone, E.status, E.max_tickets_per_customer, E.venue_id, E.series_id,
+			E.description, E.description_html, E.category, E.tags, E.min_age, E.organizer_name, E.organizer_email, E.organizer_website,
+			E.booking_fee_fixed, E.booking_fee_percentage, E.booking_fee_currency
+		FROM events E
+		WHERE E.status = $1 AND E.starts_at > $2 AND EXISTS (
+			SELECT 1
+			FROM issued_tickets IT
+			WHERE IT.event_id = E.id AND IT.customer_id = $3 AND IT.status = $4
+		)
+		ORDER BY E.starts_at
+	`
+	args := []any{domain.EventStatusScheduled, now, customerID, domain.IssuedTicketStatusValid}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -37,7 +402,7 @@ func (r *EventRepository) GetAll() ([]*domain.Event, error) {
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx)
+	rows, err := stmt.QueryContext(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +412,29 @@ func (r *EventRepository) GetAll() ([]*domain.Event, error) {
 	for rows.Next() {
 		var event domain.Event
 
-		err := rows.Scan(&event.ID, &event.Name, &event.Date)
+		err := rows.Scan(
+			&event.ID,
+			&event.Name,
+			&event.StartsAt,
+			&event.EndsAt,
+			&event.DoorsOpenAt,
+			&event.Timezone,
+			&event.Status,
+			&event.MaxTicketsPerCustomer,
+			&event.VenueID,
+			&event.SeriesID,
+			&event.Description,
+			&event.DescriptionHTML,
+			&event.Category,
+			typeMap.SQLScanner(&event.Tags),
+			&event.MinAge,
+			&event.Organizer.Name,
+			&event.Organizer.Email,
+			&event.Organizer.Website,
+			&event.BookingFee.Fixed,
+			&event.BookingFee.Percentage,
+			&event.BookingFee.Currency,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -67,11 +454,28 @@ func (r *EventRepository) Add(event *domain.Event) error {
 	defer r.mu.Unlock()
 
 	query := `
-		INSERT INTO events (name, date)
-		VALUES ($1, $2)
-		RETURNING id
+		INSERT INTO events (name, starts_at, ends_at, doors_open_at, timezone, max_tickets_per_customer, venue_id,
+			description, description_html, category, tags, min_age, organizer_name, organizer_email, organizer_website)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING id, status
 	`
-	args := []any{event.Name, event.Date}
+	args := []any{
+		event.Name,
+		event.StartsAt,
+		event.EndsAt,
+		event.DoorsOpenAt,
+		event.Timezone,
+		event.MaxTicketsPerCustomer,
+		event.VenueID,
+		event.Description,
+		event.DescriptionHTML,
+		event.Category,
+		event.Tags,
+		event.MinAge,
+		event.Organizer.Name,
+		event.Organizer.Email,
+		event.Organizer.Website,
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -82,15 +486,29 @@ func (r *EventRepository) Add(event *domain.Event) error {
 	}
 	defer stmt.Close()
 
-	return stmt.QueryRowContext(ctx, args...).Scan(&event.ID)
+	err = stmt.QueryRowContext(ctx, args...).Scan(&event.ID, &event.Status)
+	if err != nil {
+		switch {
+		case err.Error() == `ERROR: insert or update on table "events" violates foreign key constraint "events_fk_venue_id_venues_id" (SQLSTATE 23503)`:
+			return utils.ErrVenueNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (r *EventRepository) GetByID(eventID int64) (*domain.Event, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	typeMap := pgtype.NewMap()
+
 	query := `
-		SELECT id, name, date
+		SELECT id, name, starts_at, ends_at, doors_open_at, timezone, status, max_tickets_per_customer, venue_id, series_id,
+			description, description_html, category, tags, min_age, organizer_name, organizer_email, organizer_website,
+			booking_fee_fixed, booking_fee_percentage, booking_fee_currency
 		FROM events
 		WHERE id = $1
 	`
@@ -109,7 +527,151 @@ func (r *EventRepository) GetByID(eventID int64) (*domain.Event, error) {
 	err = stmt.QueryRowContext(ctx, eventID).Scan(
 		&event.ID,
 		&event.Name,
-		&event.Date,
+		&event.StartsAt,
+		&event.EndsAt,
+		&event.DoorsOpenAt,
+		&event.Timezone,
+		&event.Status,
+		&event.MaxTicketsPerCustomer,
+		&event.VenueID,
+		&event.SeriesID,
+		&event.Description,
+		&event.DescriptionHTML,
+		&event.Category,
+		typeMap.SQLScanner(&event.Tags),
+		&event.MinAge,
+		&event.Organizer.Name,
+		&event.Organizer.Email,
+		&event.Organizer.Website,
+		&event.BookingFee.Fixed,
+		&event.BookingFee.Percentage,
+		&event.BookingFee.Currency,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrEventNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &event, nil
+}
+
+func (r *EventRepository) UpdatePurchaseLimit(eventID int64, maxTicketsPerCustomer *int) (*domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	typeMap := pgtype.NewMap()
+
+	query := `
+		UPDATE events
+		SET max_tickets_per_customer = $1
+		WHERE id = $2
+		RETURNING id, name, starts_at, ends_at, doors_open_at, timezone, status, max_tickets_per_customer, venue_id, series_id,
+			description, description_html, category, tags, min_age, organizer_name, organizer_email, organizer_website,
+			booking_fee_fixed, booking_fee_percentage, booking_fee_currency
+	`
+	args := []any{maxTicketsPerCustomer, eventID}
+
+	var event domain.Event
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(
+		&event.ID,
+		&event.Name,
+		&event.StartsAt,
+		&event.EndsAt,
+		&event.DoorsOpenAt,
+		&event.Timezone,
+		&event.Status,
+		&event.MaxTicketsPerCustomer,
+		&event.VenueID,
+		&event.SeriesID,
+		&event.Description,
+		&event.DescriptionHTML,
+		&event.Category,
+		typeMap.SQLScanner(&event.Tags),
+		&event.MinAge,
+		&event.Organizer.Name,
+		&event.Organizer.Email,
+		&event.Organizer.Website,
+		&event.BookingFee.Fixed,
+		&event.BookingFee.Percentage,
+		&event.BookingFee.Currency,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrEventNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &event, nil
+}
+
+func (r *EventRepository) UpdateBookingFee(eventID int64, fee domain.BookingFee) (*domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	typeMap := pgtype.NewMap()
+
+	query := `
+		UPDATE events
+		SET booking_fee_fixed = $1, booking_fee_percentage = $2, booking_fee_currency = $3
+		WHERE id = $4
+		RETURNING id, name, starts_at, ends_at, doors_open_at, timezone, status, max_tickets_per_customer, venue_id, series_id,
+			description, description_html, category, tags, min_age, organizer_name, organizer_email, organizer_website,
+			booking_fee_fixed, booking_fee_percentage, booking_fee_currency
+	`
+	args := []any{fee.Fixed, fee.Percentage, fee.Currency, eventID}
+
+	var event domain.Event
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(
+		&event.ID,
+		&event.Name,
+		&event.StartsAt,
+		&event.EndsAt,
+		&event.DoorsOpenAt,
+		&event.Timezone,
+		&event.Status,
+		&event.MaxTicketsPerCustomer,
+		&event.VenueID,
+		&event.SeriesID,
+		&event.Description,
+		&event.DescriptionHTML,
+		&event.Category,
+		typeMap.SQLScanner(&event.Tags),
+		&event.MinAge,
+		&event.Organizer.Name,
+		&event.Organizer.Email,
+		&event.Organizer.Website,
+		&event.BookingFee.Fixed,
+		&event.BookingFee.Percentage,
+		&event.BookingFee.Currency,
 	)
 
 	if err != nil {
@@ -123,3 +685,300 @@ func (r *EventRepository) GetByID(eventID int64) (*domain.Event, error) {
 
 	return &event, nil
 }
+
+func (r *EventRepository) UpdateVenue(eventID int64, venueID int64) (*domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	typeMap := pgtype.NewMap()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT venue_id
+		FROM events
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var currentVenueID *int64
+
+	err = tx.QueryRowContext(ctx, query, eventID).Scan(&currentVenueID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrEventNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	// The venue is locked against capacity changes until the tickets of the
+	// event have been checked against it.
+	query = `
+		SELECT timezone
+		FROM venues
+		WHERE id = $1
+		FOR SHARE
+	`
+
+	var timezone string
+
+	err = tx.QueryRowContext(ctx, query, venueID).Scan(&timezone)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrVenueNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if currentVenueID != nil && *currentVenueID != venueID {
+		query = `
+			SELECT EXISTS (
+				SELECT 1
+				FROM ticket_seats TS
+				JOIN tickets T ON TS.ticket_id = T.id
+				WHERE T.event_id = $1
+			)
+		`
+
+		var assigned bool
+		err = tx.QueryRowContext(ctx, query, eventID).Scan(&assigned)
+		if err != nil {
+			return nil, err
+		}
+
+		if assigned {
+			return nil, utils.ErrEventSeatsAssigned
+		}
+	}
+
+	query = `
+		UPDATE events
+		SET venue_id = $1, timezone = $2
+		WHERE id = $3
+		RETURNING id, name, starts_at, ends_at, doors_open_at, timezone, status, max_tickets_per_customer, venue_id, series_id,
+			description, description_html, category, tags, min_age, organizer_name, organizer_email, organizer_website,
+			booking_fee_fixed, booking_fee_percentage, booking_fee_currency
+	`
+
+	var event domain.Event
+
+	err = tx.QueryRowContext(ctx, query, venueID, timezone, eventID).Scan(
+		&event.ID,
+		&event.Name,
+		&event.StartsAt,
+		&event.EndsAt,
+		&event.DoorsOpenAt,
+		&event.Timezone,
+		&event.Status,
+		&event.MaxTicketsPerCustomer,
+		&event.VenueID,
+		&event.SeriesID,
+		&event.Description,
+		&event.DescriptionHTML,
+		&event.Category,
+		typeMap.SQLScanner(&event.Tags),
+		&event.MinAge,
+		&event.Organizer.Name,
+		&event.Organizer.Email,
+		&event.Organizer.Website,
+		&event.BookingFee.Fixed,
+		&event.BookingFee.Percentage,
+		&event.BookingFee.Currency,
+	)
+	if err != nil {
+		switch {
+		case err.Error() == `ERROR: insert or update on table "events" violates foreign key constraint "events_fk_venue_id_venues_id" (SQLSTATE 23503)`:
+			return nil, utils.ErrVenueNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	err = checkVenueCapacity(ctx, tx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func (r *EventRepository) UpdateSchedule(event *domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	typeMap := pgtype.NewMap()
+
+	query := `
+		UPDATE events
+		SET starts_at = $1, ends_at = $2, doors_open_at = $3, timezone = $4
+		WHERE id = $5
+		RETURNING name, status, max_tickets_per_customer, venue_id, series_id,
+			description, description_html, category, tags, min_age, organizer_name, organizer_email, organizer_website,
+			booking_fee_fixed, booking_fee_percentage, booking_fee_currency
+	`
+	args := []any{event.StartsAt, event.EndsAt, event.DoorsOpenAt, event.Timezone, event.ID}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(
+		&event.Name,
+		&event.Status,
+		&event.MaxTicketsPerCustomer,
+		&event.VenueID,
+		&event.SeriesID,
+		&event.Description,
+		&event.DescriptionHTML,
+		&event.Category,
+		typeMap.SQLScanner(&event.Tags),
+		&event.MinAge,
+		&event.Organizer.Name,
+		&event.Organizer.Email,
+		&event.Organizer.Website,
+		&event.BookingFee.Fixed,
+		&event.BookingFee.Percentage,
+		&event.BookingFee.Currency,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrEventNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *EventRepository) UpdateDetails(event *domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE events
+		SET description = $1, description_html = $2, category = $3, tags = $4, min_age = $5,
+			organizer_name = $6, organizer_email = $7, organizer_website = $8
+		WHERE id = $9
+		RETURNING name, starts_at, ends_at, doors_open_at, timezone, status, max_tickets_per_customer, venue_id, series_id,
+			booking_fee_fixed, booking_fee_percentage, booking_fee_currency
+	`
+	args := []any{
+		event.Description,
+		event.DescriptionHTML,
+		event.Category,
+		event.Tags,
+		event.MinAge,
+		event.Organizer.Name,
+		event.Organizer.Email,
+		event.Organizer.Website,
+		event.ID,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(
+		&event.Name,
+		&event.StartsAt,
+		&event.EndsAt,
+		&event.DoorsOpenAt,
+		&event.Timezone,
+		&event.Status,
+		&event.MaxTicketsPerCustomer,
+		&event.VenueID,
+		&event.SeriesID,
+		&event.BookingFee.Fixed,
+		&event.BookingFee.Percentage,
+		&event.BookingFee.Currency,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrEventNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *EventRepository) CountByCategory() ([]*domain.CategoryCount, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT category, COUNT(*)
+		FROM events
+		WHERE category IS NOT NULL AND status = $1 AND starts_at > NOW()
+		GROUP BY category
+		ORDER BY category
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, domain.EventStatusScheduled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make([]*domain.CategoryCount, 0)
+	for rows.Next() {
+		var count domain.CategoryCount
+
+		err := rows.Scan(&count.Name, &count.EventCount)
+		if err != nil {
+			return nil, err
+		}
+
+		counts = append(counts, &count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,537 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type EventCancellationRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewEventCancellationRepository(db *sql.DB) IEventCancellationRepository {
+	return &EventCancellationRepository{
+		db: db,
+	}
+}
+
+func (r *EventCancellationRepository) GetByEventID(eventID int64) (*domain.EventCancellation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, event_id, status, refunded_orders, last_order_id, created_at, completed_at
+		FROM event_cancellations
+		WHERE event_id = $1
+	`
+
+	var cancellation domain.EventCancellation
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, eventID).Scan(
+		&cancellation.ID,
+		&cancellation.EventID,
+		&cancellation.Status,
+		&cancellation.RefundedOrders,
+		&cancellation.LastOrderID,
+		&cancellation.CreatedAt,
+		&cancellation.CompletedAt,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrEventCancellationNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	err = getRefundedAmounts(ctx, r.db, &cancellation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cancellation, nil
+}
+
+func (r *EventCancellationRepository) GetInProgress() ([]*domain.EventCancellation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, event_id, status, refunded_orders, last_order_id, created_at, completed_at
+		FROM event_cancellations
+		WHERE status = $1
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, domain.EventCancellationStatusInProgress)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cancellations := make([]*domain.EventCancellation, 0)
+	for rows.Next() {
+		var cancellation domain.EventCancellation
+
+		err := rows.Scan(
+			&cancellation.ID,
+			&cancellation.EventID,
+			&cancellation.Status,
+			&cancellation.RefundedOrders,
+			&cancellation.LastOrderID,
+			&cancellation.CreatedAt,
+			&cancellation.CompletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		cancellations = append(cancellations, &cancellation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, cancellation := range cancellations {
+		err = getRefundedAmounts(ctx, r.db, cancellation)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cancellations, nil
+}
+
+// The event is marked cancelled in the same transaction, so it only ever has
+// one cancellation job.
+func (r *EventCancellationRepository) Add(cancellation *domain.EventCancellation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE events
+		SET status = $1
+		WHERE id = $2 AND status = $3
+	`
+	args := []any{domain.EventStatusCancelled, cancellation.EventID, domain.EventStatusScheduled}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	updateStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer updateStmt.Close()
+
+	result, err := updateStmt.ExecContext(ctx, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return utils.ErrEventAlreadyCancelled
+	}
+
+	query = `
+		INSERT INTO event_cancellations (event_id, status, created_at)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+	args = []any{cancellation.EventID, cancellation.Status, cancellation.CreatedAt}
+
+	insertStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer insertStmt.Close()
+
+	err = insertStmt.QueryRowContext(ctx, args...).Scan(&cancellation.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RefundOrder skips orders that are no longer completed, so it is safe to run
+// again after a crash.
+func (r *EventCancellationRepository) RefundOrder(cancellation *domain.EventCancellation, order *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	balanceRefunds, buyerShare, err := resaleBuyerRefunds(ctx, tx, order)
+	if err != nil {
+		return err
+	}
+
+	buyerRefund := domain.RoundCents(order.ChargedAmount * buyerShare)
+
+	status := domain.OrderStatusRefunded
+	if order.PaymentMethod == domain.PaymentMethodProvider && buyerRefund > 0 {
+		status = domain.OrderStatusRefundPending
+	}
+
+	query := `
+		UPDATE orders
+		SET status = $1
+		WHERE id = $2 AND status = $3
+	`
+	args := []any{status, order.ID, domain.OrderStatusCompleted}
+
+	orderStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer orderStmt.Close()
+
+	result, err := orderStmt.ExecContext(ctx, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	refundedOrders := 0
+	totals := make(map[string]float64)
+
+	if rowsAffected != 0 {
+		refundedAmounts := make(map[string]float64)
+
+		if order.PaymentMethod == domain.PaymentMethodBalance && buyerRefund > 0 {
+			balanceRefunds[order.CustomerID] = &balanceRefund{amount: buyerRefund, currency: order.ChargedCurrency}
+		}
+
+		// Customers are credited in order of ID, as at resale, to avoid
+		// deadlocks.
+		customerIDs := make([]int64, 0, len(balanceRefunds))
+		for customerID := range balanceRefunds {
+			customerIDs = append(customerIDs, customerID)
+		}
+		slices.Sort(customerIDs)
+
+		query = `
+			UPDATE customers
+			SET balance = balance + $1
+			WHERE id = $2
+		`
+
+		customerStmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer customerStmt.Close()
+
+		for _, customerID := range customerIDs {
+			refund := balanceRefunds[customerID]
+
+			_, err = customerStmt.ExecContext(ctx, refund.amount, customerID)
+			if err != nil {
+				return err
+			}
+
+			refundedAmounts[refund.currency] += refund.amount
+		}
+
+		err = addPaymentRefund(ctx, tx, order, buyerRefund, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if order.PaymentMethod == domain.PaymentMethodProvider {
+			refundedAmounts[order.ChargedCurrency] += buyerRefund
+		}
+
+		giftCardRefund, giftCardCurrency, err := returnGiftCard(ctx, tx, order, buyerShare)
+		if err != nil {
+			return err
+		}
+
+		if giftCardRefund > 0 {
+			refundedAmounts[giftCardCurrency] += giftCardRefund
+		}
+
+		query = `
+			UPDATE issued_tickets
+			SET status = $1
+			WHERE order_id = $2
+		`
+		args = []any{domain.IssuedTicketStatusVoid, order.ID}
+
+		issuedTicketStmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer issuedTicketStmt.Close()
+
+		_, err = issuedTicketStmt.ExecContext(ctx, args...)
+		if err != nil {
+			return err
+		}
+
+		refundedOrders = 1
+
+		query = `
+			INSERT INTO event_cancellation_amounts (event_cancellation_id, currency, amount)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (event_cancellation_id, currency)
+			DO UPDATE SET amount = event_cancellation_amounts.amount + EXCLUDED.amount
+			RETURNING amount
+		`
+
+		amountStmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer amountStmt.Close()
+
+		for currency, amount := range refundedAmounts {
+			var total float64
+
+			err = amountStmt.QueryRowContext(ctx, cancellation.ID, currency, domain.RoundCents(amount)).Scan(&total)
+			if err != nil {
+				return err
+			}
+
+			totals[currency] = total
+		}
+	}
+
+	query = `
+		UPDATE event_cancellations
+		SET refunded_orders = refunded_orders + $1, last_order_id = $2
+		WHERE id = $3
+		RETURNING refunded_orders, last_order_id
+	`
+	args = []any{refundedOrders, order.ID, cancellation.ID}
+
+	progressStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer progressStmt.Close()
+
+	var progress domain.EventCancellation
+
+	err = progressStmt.QueryRowContext(ctx, args...).Scan(
+		&progress.RefundedOrders,
+		&progress.LastOrderID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	cancellation.RefundedOrders = progress.RefundedOrders
+	if len(totals) != 0 {
+		if cancellation.RefundedAmounts == nil {
+			cancellation.RefundedAmounts = make(map[string]float64)
+		}
+		maps.Copy(cancellation.RefundedAmounts, totals)
+	}
+	cancellation.LastOrderID = progress.LastOrderID
+
+	return nil
+}
+
+func (r *EventCancellationRepository) Complete(cancellation *domain.EventCancellation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE event_cancellations
+		SET status = $1, completed_at = $2
+		WHERE id = $3
+	`
+	completedAt := time.Now()
+	args := []any{domain.EventCancellationStatusCompleted, completedAt, cancellation.ID}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return err
+	}
+
+	cancellation.Status = domain.EventCancellationStatusCompleted
+	cancellation.CompletedAt = &completedAt
+
+	return nil
+}
+
+func getRefundedAmounts(ctx context.Context, db *sql.DB, cancellation *domain.EventCancellation) error {
+	query := `
+		SELECT currency, amount
+		FROM event_cancellation_amounts
+		WHERE event_cancellation_id = $1
+	`
+
+	rows, err := db.QueryContext(ctx, query, cancellation.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	cancellation.RefundedAmounts = make(map[string]float64)
+	for rows.Next() {
+		var currency string
+		var amount float64
+
+		err := rows.Scan(&currency, &amount)
+		if err != nil {
+			return err
+		}
+
+		cancellation.RefundedAmounts[currency] = amount
+	}
+
+	return rows.Err()
+}
+
+type balanceRefund struct {
+	amount   float64
+	currency string
+}
+
+// Tickets bought at resale are refunded to their last resale buyer, the one who
+// last paid for them. Any other ticket, even when given away since, is refunded
+// to the purchaser along with the rest of the order.
+func resaleBuyerRefunds(ctx context.Context, tx *sql.Tx, order *domain.Order) (map[int64]*balanceRefund, float64, error) {
+	refunds := make(map[int64]*balanceRefund)
+
+	if order.Subtotal <= 0 {
+		return refunds, 1, nil
+	}
+
+	query := `
+		SELECT RL.buyer_id, C.currency, OI.unit_price
+		FROM issued_tickets IT
+		JOIN order_items OI ON IT.order_item_id = OI.id
+		JOIN LATERAL (
+			SELECT buyer_id
+			FROM resale_listings
+			WHERE issued_ticket_id = IT.id AND status = $3
+			ORDER BY sold_at DESC, id DESC
+			LIMIT 1
+		) RL ON TRUE
+		JOIN customers C ON RL.buyer_id = C.id
+		WHERE IT.order_id = $1 AND RL.buyer_id <> $2
+	`
+
+	rows, err := tx.QueryContext(ctx, query, order.ID, order.CustomerID, domain.ResaleListingStatusSold)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	type resoldTicket struct {
+		customerID int64
+		currency   string
+		unitPrice  float64
+	}
+
+	resoldTickets := make([]resoldTicket, 0)
+	for rows.Next() {
+		var ticket resoldTicket
+
+		err := rows.Scan(&ticket.customerID, &ticket.currency, &ticket.unitPrice)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resoldTickets = append(resoldTickets, ticket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	rows.Close()
+
+	paid := order.ChargedAmount + order.GiftCardAmount
+	buyerShare := 1.0
+
+	for _, ticket := range resoldTickets {
+		share := ticket.unitPrice / order.Subtotal
+		buyerShare -= share
+
+		rate, err := exchangeRate(ctx, tx, order.ChargedCurrency, ticket.currency)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		refund, ok := refunds[ticket.customerID]
+		if !ok {
+			refund = &balanceRefund{currency: ticket.currency}
+			refunds[ticket.customerID] = refund
+		}
+		refund.amount += rate.Convert(paid * share)
+	}
+
+	return refunds, max(buyerShare, 0), nil
+}
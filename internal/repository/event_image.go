@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type EventImageRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewEventImageRepository(db *sql.DB) IEventImageRepository {
+	return &EventImageRepository{
+		db: db,
+	}
+}
+
+func (r *EventImageRepository) GetByEventID(eventID int64) ([]*domain.EventImage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, event_id, key, content_type, width, height, size, created_at
+		FROM event_images
+		WHERE event_id = $1
+		ORDER BY id
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	images := make([]*domain.EventImage, 0)
+	for rows.Next() {
+		var image domain.EventImage
+
+		err := rows.Scan(
+			&image.ID,
+			&image.EventID,
+			&image.Key,
+			&image.ContentType,
+			&image.Width,
+			&image.Height,
+			&image.Size,
+			&image.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		images = append(images, &image)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (r *EventImageRepository) GetByID(imageID int64) (*domain.EventImage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, event_id, key, content_type, width, height, size, created_at
+		FROM event_images
+		WHERE id = $1
+	`
+
+	var image domain.EventImage
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, imageID).Scan(
+		&image.ID,
+		&image.EventID,
+		&image.Key,
+		&image.ContentType,
+		&image.Width,
+		&image.Height,
+		&image.Size,
+		&image.CreatedAt,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrEventImageNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &image, nil
+}
+
+func (r *EventImageRepository) Add(image *domain.EventImage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		INSERT INTO event_images (event_id, key, content_type, width, height, size)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	args := []any{image.EventID, image.Key, image.ContentType, image.Width, image.Height, image.Size}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(&image.ID, &image.CreatedAt)
+	if err != nil {
+		switch {
+		case err.Error() == `ERROR: insert or update on table "event_images" violates foreign key constraint "event_images_fk_event_id_events_id" (SQLSTATE 23503)`:
+			return utils.ErrEventNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *EventImageRepository) Delete(eventID int64, imageID int64) (*domain.EventImage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		DELETE FROM event_images
+		WHERE id = $1 AND event_id = $2
+		RETURNING id, event_id, key, content_type, width, height, size, created_at
+	`
+
+	var image domain.EventImage
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, imageID, eventID).Scan(
+		&image.ID,
+		&image.EventID,
+		&image.Key,
+		&image.ContentType,
+		&image.Width,
+		&image.Height,
+		&image.Size,
+		&image.CreatedAt,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrEventImageNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &image, nil
+}
@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type EventSeriesRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewEventSeriesRepository(db *sql.DB) IEventSeriesRepository {
+	return &EventSeriesRepository{
+		db: db,
+	}
+}
+
+func (r *EventSeriesRepository) GetByID(seriesID int64) (*domain.EventSeries, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, name, timezone, frequency, frequency_interval, by_day, until, count
+		FROM event_series
+		WHERE id = $1
+	`
+
+	var series domain.EventSeries
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	typeMap := pgtype.NewMap()
+
+	err = stmt.QueryRowContext(ctx, seriesID).Scan(
+		&series.ID,
+		&series.Name,
+		&series.Timezone,
+		&series.Recurrence.Frequency,
+		&series.Recurrence.Interval,
+		typeMap.SQLScanner(&series.Recurrence.ByDay),
+		&series.Recurrence.Until,
+		&series.Recurrence.Count,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrEventSeriesNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &series, nil
+}
+
+func (r *EventSeriesRepository) Add(series *domain.EventSeries) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// A series can span hundreds of events, each with its own tickets.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	template := series.Events[0]
+
+	query := `
+		SELECT series_id
+		FROM events
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var seriesID *int64
+
+	err = tx.QueryRowContext(ctx, query, template.ID).Scan(&seriesID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrEventNotFound
+		default:
+			return err
+		}
+	}
+
+	if seriesID != nil {
+		return utils.ErrEventInSeries
+	}
+
+	query = `
+		INSERT INTO event_series (name, timezone, frequency, frequency_interval, by_day, until, count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	args := []any{
+		series.Name,
+		series.Timezone,
+		series.Recurrence.Frequency,
+		series.Recurrence.Interval,
+		series.Recurrence.ByDay,
+		series.Recurrence.Until,
+		series.Recurrence.Count,
+	}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&series.ID)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE events
+		SET series_id = $1
+		WHERE id = $2
+	`
+
+	_, err = tx.ExecContext(ctx, query, series.ID, template.ID)
+	if err != nil {
+		return err
+	}
+	template.SeriesID = &series.ID
+
+	query = `
+		INSERT INTO events (name, starts_at, ends_at, doors_open_at, timezone, max_tickets_per_customer, venue_id, series_id,
+			description, description_html, category, tags, min_age, organizer_name, organizer_email, organizer_website,
+			booking_fee_fixed, booking_fee_percentage, booking_fee_currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		RETURNING id, status
+	`
+
+	eventStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer eventStmt.Close()
+
+	query = `
+		INSERT INTO tickets (event_id, ticket_type_id, quantity, sales_start, sales_end, max_per_customer)
+		SELECT $1, T.ticket_type_id,
+			T.quantity + (
+				SELECT COUNT(*)
+				FROM issued_tickets IT
+				WHERE IT.ticket_id = T.id AND IT.status = $4
+			),
+			T.sales_start + $3 * INTERVAL '1 second',
+			T.sales_end + $3 * INTERVAL '1 second',
+			T.max_per_customer
+		FROM tickets T
+		WHERE T.event_id = $2
+	`
+
+	ticketStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer ticketStmt.Close()
+
+	query = `
+		INSERT INTO ticket_seats (ticket_id, seat_id)
+		SELECT NT.id, TS.seat_id
+		FROM ticket_seats TS
+		JOIN tickets T ON TS.ticket_id = T.id
+		JOIN tickets NT ON NT.event_id = $1 AND NT.ticket_type_id = T.ticket_type_id
+		WHERE T.event_id = $2
+	`
+
+	seatStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer seatStmt.Close()
+
+	for _, event := range series.Events[1:] {
+		event.SeriesID = &series.ID
+		args := []any{
+			event.Name,
+			event.StartsAt,
+			event.EndsAt,
+			event.DoorsOpenAt,
+			event.Timezone,
+			event.MaxTicketsPerCustomer,
+			event.VenueID,
+			event.SeriesID,
+			event.Description,
+			event.DescriptionHTML,
+			event.Category,
+			event.Tags,
+			event.MinAge,
+			event.Organizer.Name,
+			event.Organizer.Email,
+			event.Organizer.Website,
+			event.BookingFee.Fixed,
+			event.BookingFee.Percentage,
+			event.BookingFee.Currency,
+		}
+
+		err = eventStmt.QueryRowContext(ctx, args...).Scan(&event.ID, &event.Status)
+		if err != nil {
+			return err
+		}
+
+		shift := event.StartsAt.Sub(template.StartsAt).Seconds()
+
+		_, err = ticketStmt.ExecContext(ctx, event.ID, template.ID, shift, domain.IssuedTicketStatusValid)
+		if err != nil {
+			return err
+		}
+
+		_, err = seatStmt.ExecContext(ctx, event.ID, template.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *EventSeriesRepository) Update(series *domain.EventSeries, events []*domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE event_series
+		SET name = $1
+		WHERE id = $2
+	`
+
+	result, err := tx.ExecContext(ctx, query, series.Name, series.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return utils.ErrEventSeriesNotFound
+	}
+
+	query = `
+		UPDATE events
+		SET name = $1, starts_at = $2, ends_at = $3, doors_open_at = $4, max_tickets_per_customer = $5
+		WHERE id = $6 AND series_id = $7 AND status = $8 AND starts_at > NOW()
+	`
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, event := range events {
+		args := []any{
+			event.Name,
+			event.StartsAt,
+			event.EndsAt,
+			event.DoorsOpenAt,
+			event.MaxTicketsPerCustomer,
+			event.ID,
+			series.ID,
+			domain.EventStatusScheduled,
+		}
+
+		_, err = stmt.ExecContext(ctx, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
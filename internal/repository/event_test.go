@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/testutil"
+)
+
+func TestEventSearch(t *testing.T) {
+	db := testutil.OpenDB(t)
+
+	venues := NewVenueRepository(db)
+	events := NewEventRepository(db)
+
+	venue := &domain.Venue{
+		Name:     "Jazzland Arena",
+		Address:  "1 Harbour Road",
+		Timezone: "UTC",
+		Capacity: 1000,
+	}
+	err := venues.Add(venue)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	startsAt := time.Now().AddDate(0, 1, 0)
+	for _, event := range []*domain.Event{
+		{Name: "Jazz Night", Description: "An evening of standards.", Tags: []string{"live"}},
+		{Name: "Summer Sessions", Description: "Music by the lake.", Tags: []string{"jazz", "outdoor"}},
+		{Name: "Rock Festival", Description: "Three stages of guitars.", Tags: []string{}, VenueID: &venue.ID},
+		{Name: "Open Mic", Description: "Bring your saxophone for a late jazz jam with friends and strangers alike.", Tags: []string{}},
+		{Name: "Comedy Hour", Description: "Stand-up all night long.", Tags: []string{"comedy"}},
+	} {
+		event.StartsAt = startsAt
+		event.Timezone = "UTC"
+
+		err := events.Add(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	matches, err := events.Search("JAZ", 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(matches) != 4 {
+		t.Fatalf("got %d matches, want 4", len(matches))
+	}
+
+	if matches[0].Name != "Jazz Night" {
+		t.Errorf("got %q ranked first, want the event matching by name", matches[0].Name)
+	}
+
+	byName := make(map[string]*domain.EventMatch, len(matches))
+	for _, match := range matches {
+		byName[match.Name] = match
+	}
+
+	for _, name := range []string{"Jazz Night", "Summer Sessions", "Rock Festival", "Open Mic"} {
+		if byName[name] == nil {
+			t.Fatalf("got no match for %q", name)
+		}
+	}
+
+	tests := []struct {
+		event    string
+		field    string
+		headline *string
+		want     string
+	}{
+		{"Jazz Night", "name", &byName["Jazz Night"].NameHeadline, "[[Jazz]] Night"},
+		{"Summer Sessions", "tags", byName["Summer Sessions"].TagsHeadline, "[[jazz]] outdoor"},
+		{"Rock Festival", "venue", byName["Rock Festival"].VenueHeadline, "[[Jazzland]] Arena"},
+		{"Open Mic", "description", byName["Open Mic"].DescriptionHeadline, "[[jazz]]"},
+	}
+
+	for _, tt := range tests {
+		if tt.headline == nil {
+			t.Errorf("%s: got no %s headline", tt.event, tt.field)
+			continue
+		}
+
+		if !strings.Contains(*tt.headline, tt.want) {
+			t.Errorf("%s: got %s headline %q, want it to contain %q", tt.event, tt.field, *tt.headline, tt.want)
+		}
+	}
+
+	if byName["Jazz Night"].DescriptionHeadline != nil || byName["Jazz Night"].TagsHeadline != nil {
+		t.Error("Jazz Night: got headlines of fields that didn't match")
+	}
+}
+
+func TestEventSearchWithoutWords(t *testing.T) {
+	db := testutil.OpenDB(t)
+
+	matches, err := NewEventRepository(db).Search(" &|!: ", 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(matches) != 0 {
+		t.Fatalf("got %d matches, want none", len(matches))
+	}
+}
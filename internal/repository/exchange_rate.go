@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type ExchangeRateRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewExchangeRateRepository(db *sql.DB) IExchangeRateRepository {
+	return &ExchangeRateRepository{
+		db: db,
+	}
+}
+
+func (r *ExchangeRateRepository) GetAll() ([]*domain.ExchangeRate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, base_currency, quote_currency, rate, updated_at
+		FROM exchange_rates
+		ORDER BY base_currency, quote_currency
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := make([]*domain.ExchangeRate, 0)
+	for rows.Next() {
+		var rate domain.ExchangeRate
+
+		err := rows.Scan(
+			&rate.ID,
+			&rate.BaseCurrency,
+			&rate.QuoteCurrency,
+			&rate.Rate,
+			&rate.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rates = append(rates, &rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (r *ExchangeRateRepository) Set(rate *domain.ExchangeRate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		INSERT INTO exchange_rates (base_currency, quote_currency, rate)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (base_currency, quote_currency) DO UPDATE
+		SET rate = EXCLUDED.rate, updated_at = NOW()
+		RETURNING id, updated_at
+	`
+	args := []any{rate.BaseCurrency, rate.QuoteCurrency, rate.Rate}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(&rate.ID, &rate.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ExchangeRateRepository) Delete(rateID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		DELETE FROM exchange_rates
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, rateID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return utils.ErrExchangeRateNotFound
+	}
+
+	return nil
+}
+
+func exchangeRate(ctx context.Context, tx *sql.Tx, from string, to string) (*domain.ExchangeRate, error) {
+	rate := &domain.ExchangeRate{
+		BaseCurrency:  from,
+		QuoteCurrency: to,
+		Rate:          1,
+	}
+
+	if from == to {
+		return rate, nil
+	}
+
+	query := `
+		SELECT rate
+		FROM (
+			SELECT rate, 0 AS preference
+			FROM exchange_rates
+			WHERE base_currency = $1 AND quote_currency = $2
+			UNION ALL
+			SELECT 1 / rate, 1 AS preference
+			FROM exchange_rates
+			WHERE base_currency = $2 AND quote_currency = $1
+		) R
+		ORDER BY preference
+		LIMIT 1
+	`
+
+	err := tx.QueryRowContext(ctx, query, from, to).Scan(&rate.Rate)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, fmt.Errorf("%w: from %s to %s", utils.ErrCurrencyNotConvertible, from, to)
+		default:
+			return nil, err
+		}
+	}
+
+	return rate, nil
+}
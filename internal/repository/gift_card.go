@@ -0,0 +1,352 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type GiftCardRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewGiftCardRepository(db *sql.DB) IGiftCardRepository {
+	return &GiftCardRepository{
+		db: db,
+	}
+}
+
+func (r *GiftCardRepository) GetByPurchaserID(customerID int64) ([]*domain.GiftCard, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, code, value, balance, currency, purchaser_id, expires_at, created_at
+		FROM gift_cards
+		WHERE purchaser_id = $1
+		ORDER BY id DESC
+	`
+
+	return r.query(query, customerID)
+}
+
+func (r *GiftCardRepository) GetByCode(code string) (*domain.GiftCard, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, code, value, balance, currency, purchaser_id, expires_at, created_at
+		FROM gift_cards
+		WHERE code = $1
+	`
+
+	giftCards, err := r.query(query, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(giftCards) == 0 {
+		return nil, utils.ErrGiftCardNotFound
+	}
+
+	return giftCards[0], nil
+}
+
+func (r *GiftCardRepository) Add(giftCard *domain.GiftCard) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, transaction := range giftCard.Transactions {
+		if transaction.Type != domain.GiftCardTransactionPurchase {
+			continue
+		}
+
+		query := `
+			UPDATE customers
+			SET balance = balance - $1
+			WHERE id = $2
+		`
+
+		_, err = tx.ExecContext(ctx, query, transaction.Amount, transaction.CustomerID)
+		if err != nil {
+			switch {
+			case err.Error() == `ERROR: new row for relation "customers" violates check constraint "customers_balance_check" (SQLSTATE 23514)`:
+				return utils.ErrInsufficientBalance
+			default:
+				return err
+			}
+		}
+	}
+
+	query := `
+		INSERT INTO gift_cards (code, value, balance, currency, purchaser_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	args := []any{
+		giftCard.Code,
+		giftCard.Value,
+		giftCard.Balance,
+		giftCard.Currency,
+		giftCard.PurchaserID,
+		giftCard.ExpiresAt,
+		giftCard.CreatedAt,
+	}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&giftCard.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, transaction := range giftCard.Transactions {
+		transaction.GiftCardID = giftCard.ID
+
+		err = addGiftCardTransaction(ctx, tx, transaction)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *GiftCardRepository) Redeem(giftCard *domain.GiftCard, customerID int64, amount *float64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The customer is locked before the card, in the same order as at checkout,
+	// to avoid deadlocks.
+	query := `
+		SELECT currency
+		FROM customers
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var walletCurrency string
+
+	err = tx.QueryRowContext(ctx, query, customerID).Scan(&walletCurrency)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrCustomerNotFound
+		default:
+			return err
+		}
+	}
+
+	query = `
+		SELECT balance, currency, expires_at
+		FROM gift_cards
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRowContext(ctx, query, giftCard.ID).Scan(&giftCard.Balance, &giftCard.Currency, &giftCard.ExpiresAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrGiftCardNotFound
+		default:
+			return err
+		}
+	}
+
+	if giftCard.Expired(at) || giftCard.Balance == 0 {
+		return utils.ErrGiftCardUnavailable
+	}
+
+	spent := giftCard.Balance
+	if amount != nil {
+		spent = *amount
+	}
+
+	if spent > giftCard.Balance {
+		return utils.ErrGiftCardBalanceExceeded
+	}
+
+	rate, err := exchangeRate(ctx, tx, giftCard.Currency, walletCurrency)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE gift_cards
+		SET balance = balance - $1
+		WHERE id = $2
+		RETURNING balance
+	`
+
+	err = tx.QueryRowContext(ctx, query, spent, giftCard.ID).Scan(&giftCard.Balance)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE customers
+		SET balance = balance + $1
+		WHERE id = $2
+	`
+
+	_, err = tx.ExecContext(ctx, query, rate.Convert(spent), customerID)
+	if err != nil {
+		return err
+	}
+
+	transaction := &domain.GiftCardTransaction{
+		GiftCardID: giftCard.ID,
+		CustomerID: customerID,
+		Type:       domain.GiftCardTransactionRedemption,
+		Amount:     -spent,
+		CreatedAt:  at,
+	}
+
+	err = addGiftCardTransaction(ctx, tx, transaction)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	giftCard.Transactions = append(giftCard.Transactions, transaction)
+
+	return nil
+}
+
+func (r *GiftCardRepository) query(query string, args ...any) ([]*domain.GiftCard, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	giftCards := make([]*domain.GiftCard, 0)
+	giftCardsByID := make(map[int64]*domain.GiftCard)
+	giftCardIDs := make([]int64, 0)
+	for rows.Next() {
+		var giftCard domain.GiftCard
+
+		err := rows.Scan(
+			&giftCard.ID,
+			&giftCard.Code,
+			&giftCard.Value,
+			&giftCard.Balance,
+			&giftCard.Currency,
+			&giftCard.PurchaserID,
+			&giftCard.ExpiresAt,
+			&giftCard.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		giftCard.Transactions = make([]*domain.GiftCardTransaction, 0)
+		giftCards = append(giftCards, &giftCard)
+		giftCardsByID[giftCard.ID] = &giftCard
+		giftCardIDs = append(giftCardIDs, giftCard.ID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(giftCards) == 0 {
+		return giftCards, nil
+	}
+
+	query = `
+		SELECT id, gift_card_id, customer_id, order_id, type, amount, created_at
+		FROM gift_card_transactions
+		WHERE gift_card_id = ANY($1)
+		ORDER BY id
+	`
+
+	transactionRows, err := r.db.QueryContext(ctx, query, giftCardIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer transactionRows.Close()
+
+	for transactionRows.Next() {
+		var transaction domain.GiftCardTransaction
+
+		err := transactionRows.Scan(
+			&transaction.ID,
+			&transaction.GiftCardID,
+			&transaction.CustomerID,
+			&transaction.OrderID,
+			&transaction.Type,
+			&transaction.Amount,
+			&transaction.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		giftCard := giftCardsByID[transaction.GiftCardID]
+		giftCard.Transactions = append(giftCard.Transactions, &transaction)
+	}
+
+	if err := transactionRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return giftCards, nil
+}
+
+func addGiftCardTransaction(ctx context.Context, tx *sql.Tx, transaction *domain.GiftCardTransaction) error {
+	query := `
+		INSERT INTO gift_card_transactions (gift_card_id, customer_id, order_id, type, amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	args := []any{
+		transaction.GiftCardID,
+		transaction.CustomerID,
+		transaction.OrderID,
+		transaction.Type,
+		transaction.Amount,
+		transaction.CreatedAt,
+	}
+
+	return tx.QueryRowContext(ctx, query, args...).Scan(&transaction.ID)
+}
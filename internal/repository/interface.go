@@ -1,6 +1,10 @@
 package repository
 
-import "github.com/nadiannis/evento-api-fr-auth/internal/domain"
+import (
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+)
 
 type CustomerReader interface {
 	GetAll() ([]*domain.Customer, error)
@@ -20,12 +24,21 @@ type ICustomerRepository interface {
 }
 
 type EventReader interface {
-	GetAll() ([]*domain.Event, error)
+	GetAll(filters *domain.EventFilters) ([]*domain.EventDetail, error)
 	GetByID(eventID int64) (*domain.Event, error)
+	GetBySeriesID(seriesID int64) ([]*domain.Event, error)
+	Search(text string, limit int) ([]*domain.EventMatch, error)
+	GetUpcomingByCustomerID(customerID int64, now time.Time) ([]*domain.Event, error)
+	CountByCategory() ([]*domain.CategoryCount, error)
 }
 
 type EventWriter interface {
 	Add(event *domain.Event) error
+	UpdatePurchaseLimit(eventID int64, maxTicketsPerCustomer *int) (*domain.Event, error)
+	UpdateBookingFee(eventID int64, fee domain.BookingFee) (*domain.Event, error)
+	UpdateVenue(eventID int64, venueID int64) (*domain.Event, error)
+	UpdateSchedule(event *domain.Event) error
+	UpdateDetails(event *domain.Event) error
 }
 
 type IEventRepository interface {
@@ -33,6 +46,51 @@ type IEventRepository interface {
 	EventWriter
 }
 
+type EventSeriesReader interface {
+	GetByID(seriesID int64) (*domain.EventSeries, error)
+}
+
+type EventSeriesWriter interface {
+	Add(series *domain.EventSeries) error
+	Update(series *domain.EventSeries, events []*domain.Event) error
+}
+
+type IEventSeriesRepository interface {
+	EventSeriesReader
+	EventSeriesWriter
+}
+
+type EventImageReader interface {
+	GetByEventID(eventID int64) ([]*domain.EventImage, error)
+	GetByID(imageID int64) (*domain.EventImage, error)
+}
+
+type EventImageWriter interface {
+	Add(image *domain.EventImage) error
+	Delete(eventID int64, imageID int64) (*domain.EventImage, error)
+}
+
+type IEventImageRepository interface {
+	EventImageReader
+	EventImageWriter
+}
+
+type EventCancellationReader interface {
+	GetByEventID(eventID int64) (*domain.EventCancellation, error)
+	GetInProgress() ([]*domain.EventCancellation, error)
+}
+
+type EventCancellationWriter interface {
+	Add(cancellation *domain.EventCancellation) error
+	RefundOrder(cancellation *domain.EventCancellation, order *domain.Order) error
+	Complete(cancellation *domain.EventCancellation) error
+}
+
+type IEventCancellationRepository interface {
+	EventCancellationReader
+	EventCancellationWriter
+}
+
 type TicketTypeReader interface {
 	GetAll() ([]*domain.TicketType, error)
 	GetByName(ticketTypeName domain.TicketTypeName) (*domain.TicketType, error)
@@ -57,6 +115,9 @@ type TicketWriter interface {
 	Add(ticket *domain.Ticket) error
 	AddQuantity(ticketID int64, quantity int) (*domain.Ticket, error)
 	DeductQuantity(ticketID int64, quantity int) (*domain.Ticket, error)
+	UpdateSalesWindow(ticketID int64, salesStart, salesEnd *time.Time) (*domain.Ticket, error)
+	UpdatePurchaseLimit(ticketID int64, maxPerCustomer *int) (*domain.Ticket, error)
+	AssignSeats(ticketID int64, seatIDs []int64) (*domain.Ticket, error)
 }
 
 type ITicketRepository interface {
@@ -64,17 +125,231 @@ type ITicketRepository interface {
 	TicketWriter
 }
 
+type PresaleReader interface {
+	GetByTicketID(ticketID int64) ([]*domain.Presale, error)
+	GetActiveByTicketID(ticketID int64, at time.Time) ([]*domain.Presale, error)
+}
+
+type PresaleWriter interface {
+	Add(presale *domain.Presale) error
+}
+
+type IPresaleRepository interface {
+	PresaleReader
+	PresaleWriter
+}
+
+type TopUpReader interface {
+	GetByCustomerID(customerID int64) ([]*domain.TopUp, error)
+	GetByID(topUpID int64) (*domain.TopUp, error)
+	GetByIntentID(provider string, intentID string) (*domain.TopUp, error)
+}
+
+type TopUpWriter interface {
+	Add(topUp *domain.TopUp) error
+	Confirm(topUp *domain.TopUp) error
+	Fail(topUp *domain.TopUp) error
+	Refund(topUp *domain.TopUp) error
+}
+
+type ITopUpRepository interface {
+	TopUpReader
+	TopUpWriter
+}
+
+type PaymentRefundReader interface {
+	GetPending(provider string, afterID int64, limit int) ([]*domain.PaymentRefund, error)
+	GetPendingByOrderID(orderID int64) ([]*domain.PaymentRefund, error)
+}
+
+type PaymentRefundWriter interface {
+	Complete(refund *domain.PaymentRefund) error
+	Fail(refund *domain.PaymentRefund, cause string) error
+}
+
+type IPaymentRefundRepository interface {
+	PaymentRefundReader
+	PaymentRefundWriter
+}
+
+type GiftCardReader interface {
+	GetByPurchaserID(customerID int64) ([]*domain.GiftCard, error)
+	GetByCode(code string) (*domain.GiftCard, error)
+}
+
+type GiftCardWriter interface {
+	Add(giftCard *domain.GiftCard) error
+	Redeem(giftCard *domain.GiftCard, customerID int64, amount *float64, at time.Time) error
+}
+
+type IGiftCardRepository interface {
+	GiftCardReader
+	GiftCardWriter
+}
+
+type ExchangeRateReader interface {
+	GetAll() ([]*domain.ExchangeRate, error)
+}
+
+type ExchangeRateWriter interface {
+	Set(rate *domain.ExchangeRate) error
+	Delete(rateID int64) error
+}
+
+type IExchangeRateRepository interface {
+	ExchangeRateReader
+	ExchangeRateWriter
+}
+
+type TaxRateReader interface {
+	GetAll() ([]*domain.TaxRate, error)
+}
+
+type TaxRateWriter interface {
+	Add(taxRate *domain.TaxRate) error
+	Update(taxRate *domain.TaxRate) error
+	Delete(taxRateID int64) error
+}
+
+type ITaxRateRepository interface {
+	TaxRateReader
+	TaxRateWriter
+}
+
+type PriceTierReader interface {
+	GetByTicketID(ticketID int64) ([]*domain.PriceTier, error)
+}
+
+type PriceTierWriter interface {
+	Add(tier *domain.PriceTier) error
+	Delete(ticketID int64, tierID int64) (*domain.PriceTier, error)
+}
+
+type IPriceTierRepository interface {
+	PriceTierReader
+	PriceTierWriter
+}
+
 type OrderReader interface {
 	GetAll() ([]*domain.Order, error)
+	GetByID(orderID int64) (*domain.Order, error)
 	GetByCustomerID(customerID int64) ([]*domain.Order, error)
+	GetCompletedByEventID(eventID int64, afterOrderID int64, limit int) ([]*domain.Order, error)
+	GetByPaymentIntentID(provider string, intentID string) (*domain.Order, error)
+	GetExpired(now time.Time, limit int) ([]*domain.Order, error)
 }
 
 type OrderWriter interface {
-	Add(order *domain.Order) error
-	DeleteAll() error
+	Place(order *domain.Order, limit *domain.PurchaseLimit) error
+	Cancel(order *domain.Order) error
+	Complete(order *domain.Order) error
+	Release(order *domain.Order, status domain.OrderStatus) error
+	SetPaymentIntent(order *domain.Order) error
+	RequestRefund(order *domain.Order) error
+	DeleteAll() ([]*domain.Order, error)
 }
 
 type IOrderRepository interface {
 	OrderReader
 	OrderWriter
 }
+
+type IssuedTicketReader interface {
+	GetByOrderID(orderID int64) ([]*domain.IssuedTicket, error)
+	GetByIDs(issuedTicketIDs []int64) ([]*domain.IssuedTicket, error)
+	GetByCustomerID(customerID int64) ([]*domain.IssuedTicket, error)
+	GetByCode(code string) (*domain.IssuedTicket, error)
+	GetCheckInStats(eventID int64) (*domain.CheckInStats, error)
+}
+
+type IssuedTicketWriter interface {
+	CheckIn(eventID int64, code string, scannerID int64, checkedInAt time.Time) (*domain.IssuedTicket, error)
+}
+
+type IIssuedTicketRepository interface {
+	IssuedTicketReader
+	IssuedTicketWriter
+}
+
+type TicketTransferReader interface {
+	GetByID(transferID int64) (*domain.TicketTransfer, error)
+	GetByCustomerID(customerID int64) ([]*domain.TicketTransfer, error)
+}
+
+type TicketTransferWriter interface {
+	Add(transfer *domain.TicketTransfer, startsAfter time.Time) error
+	Accept(transfer *domain.TicketTransfer, codes map[int64]string, startsAfter time.Time) error
+	Close(transfer *domain.TicketTransfer, status domain.TicketTransferStatus) error
+}
+
+type ITicketTransferRepository interface {
+	TicketTransferReader
+	TicketTransferWriter
+}
+
+type ResaleListingReader interface {
+	GetListed() ([]*domain.ResaleListing, error)
+	GetByID(listingID int64) (*domain.ResaleListing, error)
+}
+
+type ResaleListingWriter interface {
+	Add(listing *domain.ResaleListing, startsAfter time.Time) error
+	Purchase(listing *domain.ResaleListing, buyerID int64, code string, startsAfter time.Time) error
+	Cancel(listing *domain.ResaleListing) error
+}
+
+type IResaleListingRepository interface {
+	ResaleListingReader
+	ResaleListingWriter
+}
+
+type WaitlistReader interface {
+	GetByCustomerID(customerID int64) ([]*domain.WaitlistEntry, error)
+	GetPendingTicketIDs(at time.Time) ([]int64, error)
+}
+
+type WaitlistWriter interface {
+	Add(entry *domain.WaitlistEntry) error
+	Cancel(ticketID int64, customerID int64) (*domain.WaitlistEntry, error)
+	Offer(ticketID int64, at time.Time, claimWindow time.Duration) ([]*domain.WaitlistEntry, error)
+}
+
+type IWaitlistRepository interface {
+	WaitlistReader
+	WaitlistWriter
+}
+
+type VenueReader interface {
+	GetAll() ([]*domain.Venue, error)
+	GetByID(venueID int64) (*domain.Venue, error)
+	GetSections(venueID int64) ([]*domain.VenueSection, error)
+	GetSeatsByIDs(seatIDs []int64) ([]*domain.Seat, error)
+	GetSeatsByEventID(eventID int64) ([]*domain.EventSeat, error)
+}
+
+type VenueWriter interface {
+	Add(venue *domain.Venue) error
+	Update(venue *domain.Venue) error
+	Delete(venueID int64) error
+}
+
+type IVenueRepository interface {
+	VenueReader
+	VenueWriter
+}
+
+type PromoCodeReader interface {
+	GetAll() ([]*domain.PromoCode, error)
+	GetByID(promoCodeID int64) (*domain.PromoCode, error)
+	GetByCode(code string) (*domain.PromoCode, error)
+}
+
+type PromoCodeWriter interface {
+	Add(promoCode *domain.PromoCode) error
+	Update(promoCode *domain.PromoCode) error
+}
+
+type IPromoCodeRepository interface {
+	PromoCodeReader
+	PromoCodeWriter
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type IssuedTicketRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewIssuedTicketRepository(db *sql.DB) IIssuedTicketRepository {
+	return &IssuedTicketRepository{
+		db: db,
+	}
+}
+
+func (r *IssuedTicketRepository) GetByOrderID(orderID int64) ([]*domain.IssuedTicket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, order_id, order_item_id, ticket_id, event_id, customer_id, code, status, created_at, checked_in_at, checked_in_by, seat_id
+		FROM issued_tickets
+		WHERE order_id = $1
+		ORDER BY id
+	`
+
+	return r.query(query, orderID)
+}
+
+func (r *IssuedTicketRepository) GetByIDs(issuedTicketIDs []int64) ([]*domain.IssuedTicket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, order_id, order_item_id, ticket_id, event_id, customer_id, code, status, created_at, checked_in_at, checked_in_by, seat_id
+		FROM issued_tickets
+		WHERE id = ANY($1)
+		ORDER BY id
+	`
+
+	return r.query(query, issuedTicketIDs)
+}
+
+func (r *IssuedTicketRepository) GetByCustomerID(customerID int64) ([]*domain.IssuedTicket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, order_id, order_item_id, ticket_id, event_id, customer_id, code, status, created_at, checked_in_at, checked_in_by, seat_id
+		FROM issued_tickets
+		WHERE customer_id = $1
+		ORDER BY id
+	`
+
+	return r.query(query, customerID)
+}
+
+func (r *IssuedTicketRepository) GetByCode(code string) (*domain.IssuedTicket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, order_id, order_item_id, ticket_id, event_id, customer_id, code, status, created_at, checked_in_at, checked_in_by, seat_id
+		FROM issued_tickets
+		WHERE code = $1
+	`
+
+	var issuedTicket domain.IssuedTicket
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, code).Scan(
+		&issuedTicket.ID,
+		&issuedTicket.OrderID,
+		&issuedTicket.OrderItemID,
+		&issuedTicket.TicketID,
+		&issuedTicket.EventID,
+		&issuedTicket.CustomerID,
+		&issuedTicket.Code,
+		&issuedTicket.Status,
+		&issuedTicket.CreatedAt,
+		&issuedTicket.CheckedInAt,
+		&issuedTicket.CheckedInBy,
+		&issuedTicket.SeatID,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrIssuedTicketNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &issuedTicket, nil
+}
+
+// The update only matches a ticket that hasn't been checked in, so concurrent
+// scans can't both succeed.
+func (r *IssuedTicketRepository) CheckIn(eventID int64, code string, scannerID int64, checkedInAt time.Time) (*domain.IssuedTicket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE issued_tickets
+		SET checked_in_at = $1, checked_in_by = $2
+		WHERE code = $3 AND event_id = $4 AND status = $5 AND checked_in_at IS NULL
+		RETURNING id, order_id, order_item_id, ticket_id, event_id, customer_id, code, status, created_at, checked_in_at, checked_in_by, seat_id
+	`
+	args := []any{checkedInAt, scannerID, code, eventID, domain.IssuedTicketStatusValid}
+
+	var issuedTicket domain.IssuedTicket
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&issuedTicket.ID,
+		&issuedTicket.OrderID,
+		&issuedTicket.OrderItemID,
+		&issuedTicket.TicketID,
+		&issuedTicket.EventID,
+		&issuedTicket.CustomerID,
+		&issuedTicket.Code,
+		&issuedTicket.Status,
+		&issuedTicket.CreatedAt,
+		&issuedTicket.CheckedInAt,
+		&issuedTicket.CheckedInBy,
+		&issuedTicket.SeatID,
+	)
+	if err == nil {
+		return &issuedTicket, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	query = `
+		SELECT id, order_id, order_item_id, ticket_id, event_id, customer_id, code, status, created_at, checked_in_at, checked_in_by, seat_id
+		FROM issued_tickets
+		WHERE code = $1
+	`
+
+	err = r.db.QueryRowContext(ctx, query, code).Scan(
+		&issuedTicket.ID,
+		&issuedTicket.OrderID,
+		&issuedTicket.OrderItemID,
+		&issuedTicket.TicketID,
+		&issuedTicket.EventID,
+		&issuedTicket.CustomerID,
+		&issuedTicket.Code,
+		&issuedTicket.Status,
+		&issuedTicket.CreatedAt,
+		&issuedTicket.CheckedInAt,
+		&issuedTicket.CheckedInBy,
+		&issuedTicket.SeatID,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrIssuedTicketNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if issuedTicket.EventID != eventID {
+		return nil, utils.ErrTicketEventMismatch
+	}
+
+	if issuedTicket.Status != domain.IssuedTicketStatusValid {
+		return &issuedTicket, utils.ErrIssuedTicketVoid
+	}
+
+	return &issuedTicket, utils.ErrTicketAlreadyCheckedIn
+}
+
+func (r *IssuedTicketRepository) GetCheckInStats(eventID int64) (*domain.CheckInStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT T.id, TT.name, COUNT(IT.id), COUNT(IT.checked_in_at)
+		FROM tickets T
+		JOIN ticket_types TT ON T.ticket_type_id = TT.id
+		LEFT JOIN issued_tickets IT ON IT.ticket_id = T.id AND IT.status = $2
+		WHERE T.event_id = $1
+		GROUP BY T.id, TT.name
+		ORDER BY T.id
+	`
+	args := []any{eventID, domain.IssuedTicketStatusValid}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := &domain.CheckInStats{
+		EventID: eventID,
+		Tickets: make([]*domain.TicketCheckInStats, 0),
+	}
+	for rows.Next() {
+		var ticketStats domain.TicketCheckInStats
+
+		err := rows.Scan(
+			&ticketStats.TicketID,
+			&ticketStats.Type,
+			&ticketStats.Issued,
+			&ticketStats.CheckedIn,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.Issued += ticketStats.Issued
+		stats.CheckedIn += ticketStats.CheckedIn
+		stats.Tickets = append(stats.Tickets, &ticketStats)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	stats.Remaining = stats.Issued - stats.CheckedIn
+
+	return stats, nil
+}
+
+func (r *IssuedTicketRepository) query(query string, args ...any) ([]*domain.IssuedTicket, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	issuedTickets := make([]*domain.IssuedTicket, 0)
+	for rows.Next() {
+		var issuedTicket domain.IssuedTicket
+
+		err := rows.Scan(
+			&issuedTicket.ID,
+			&issuedTicket.OrderID,
+			&issuedTicket.OrderItemID,
+			&issuedTicket.TicketID,
+			&issuedTicket.EventID,
+			&issuedTicket.CustomerID,
+			&issuedTicket.Code,
+			&issuedTicket.Status,
+			&issuedTicket.CreatedAt,
+			&issuedTicket.CheckedInAt,
+			&issuedTicket.CheckedInBy,
+			&issuedTicket.SeatID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		issuedTickets = append(issuedTickets, &issuedTicket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return issuedTickets, nil
+}
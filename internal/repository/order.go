@@ -3,10 +3,14 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
 )
 
 type OrderRepository struct {
@@ -24,142 +28,1576 @@ func (r *OrderRepository) GetAll() ([]*domain.Order, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	query := "SELECT id, customer_id, ticket_id, quantity, total_price, created_at FROM orders"
+	query := `
+		SELECT id, customer_id, event_id, subtotal, discount, promo_code_id, booking_fee, tax, tax_rate, tax_jurisdiction, total_price,
+			currency, charged_currency, exchange_rate, gift_card_id, gift_card_amount, charged_amount, payment_method, payment_provider, payment_intent_id,
+			status, created_at, expires_at
+		FROM orders
+	`
+
+	return r.query(query)
+}
+
+// The customer row is locked for the duration, so concurrent orders of the same
+// customer are checked against each other.
+func (r *OrderRepository) Place(order *domain.Order, limit *domain.PurchaseLimit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT E.status, E.booking_fee_fixed, E.booking_fee_percentage, E.booking_fee_currency, TR.jurisdiction, TR.rate
+		FROM events E
+		LEFT JOIN venues V ON E.venue_id = V.id
+		LEFT JOIN tax_rates TR ON V.tax_jurisdiction = TR.jurisdiction
+		WHERE E.id = $1
+		FOR SHARE OF E
+	`
+
+	var (
+		eventStatus     domain.EventStatus
+		bookingFee      domain.BookingFee
+		taxJurisdiction *string
+		taxRate         *float64
+	)
+
+	err = tx.QueryRowContext(ctx, query, order.EventID).Scan(
+		&eventStatus,
+		&bookingFee.Fixed,
+		&bookingFee.Percentage,
+		&bookingFee.Currency,
+		&taxJurisdiction,
+		&taxRate,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrEventNotFound
+		default:
+			return err
+		}
+	}
+
+	if eventStatus == domain.EventStatusCancelled {
+		return utils.ErrEventCancelled
+	}
+
+	query = `
+		SELECT currency
+		FROM customers
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var walletCurrency string
+
+	err = tx.QueryRowContext(ctx, query, order.CustomerID).Scan(&walletCurrency)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrCustomerNotFound
+		default:
+			return err
+		}
+	}
+
+	// Tickets are always updated in the same order to avoid deadlocks between
+	// concurrent orders for the same tickets.
+	items := make([]*domain.OrderItem, len(order.Items))
+	copy(items, order.Items)
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].TicketID < items[j].TicketID
+	})
+
+	for _, item := range items {
+		maxPerTicket, ok := limit.MaxPerTicket[item.TicketID]
+		if !ok {
+			continue
+		}
+
+		query = `
+			SELECT COALESCE(SUM(OI.quantity), 0)
+			FROM order_items OI
+			JOIN orders O ON OI.order_id = O.id
+			WHERE O.customer_id = $1 AND OI.ticket_id = $2 AND O.status IN ($3, $4)
+		`
+		args := []any{order.CustomerID, item.TicketID, domain.OrderStatusCompleted, domain.OrderStatusPending}
+
+		var purchased int
+
+		err = tx.QueryRowContext(ctx, query, args...).Scan(&purchased)
+		if err != nil {
+			return err
+		}
+
+		if purchased+item.Quantity > maxPerTicket {
+			return fmt.Errorf("%w: at most %d per customer for ticket %d, %d already purchased", utils.ErrPurchaseLimitExceeded, maxPerTicket, item.TicketID, purchased)
+		}
+	}
+
+	if limit.MaxPerEvent != nil {
+		query = `
+			SELECT COALESCE(SUM(OI.quantity), 0)
+			FROM order_items OI
+			JOIN orders O ON OI.order_id = O.id
+			WHERE O.customer_id = $1 AND O.event_id = $2 AND O.status IN ($3, $4)
+		`
+		args := []any{order.CustomerID, order.EventID, domain.OrderStatusCompleted, domain.OrderStatusPending}
+
+		var purchased int
+
+		err = tx.QueryRowContext(ctx, query, args...).Scan(&purchased)
+		if err != nil {
+			return err
+		}
+
+		if purchased+order.Quantity() > *limit.MaxPerEvent {
+			return fmt.Errorf("%w: at most %d per customer for this event, %d already purchased", utils.ErrPurchaseLimitExceeded, *limit.MaxPerEvent, purchased)
+		}
+	}
+
+	query = `
+		UPDATE tickets
+		SET quantity = quantity - $1
+		WHERE id = $2
+		RETURNING quantity
+	`
+
+	ticketStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer ticketStmt.Close()
+
+	for _, item := range items {
+		var remaining int
+
+		err = ticketStmt.QueryRowContext(ctx, item.Quantity, item.TicketID).Scan(&remaining)
+		if err != nil {
+			switch {
+			case err.Error() == `ERROR: new row for relation "tickets" violates check constraint "tickets_quantity_check" (SQLSTATE 23514)`:
+				return fmt.Errorf("%w: ticket %d", utils.ErrInsufficientTicketQuantity, item.TicketID)
+			default:
+				return err
+			}
+		}
+
+		// Stock offered to customers on the waitlist is held for them until
+		// their offer runs out.
+		reserved, err := reservedQuantity(ctx, tx, item.TicketID, order.CustomerID, order.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		if remaining < reserved {
+			return fmt.Errorf("%w: ticket %d", utils.ErrInsufficientTicketQuantity, item.TicketID)
+		}
+
+		// The ticket row is locked by now, so concurrent orders see each
+		// other's seats and tier sales.
+		err = assignSeats(ctx, tx, order.EventID, item)
+		if err != nil {
+			return err
+		}
+
+		err = priceItem(ctx, tx, item, order.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	order.Subtotal = 0
+	for _, item := range order.Items {
+		order.Subtotal += item.TotalPrice
+	}
+	order.Subtotal = domain.RoundCents(order.Subtotal)
+	order.Discount = 0
+
+	ticketIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		ticketIDs = append(ticketIDs, item.TicketID)
+	}
+
+	query = `
+		UPDATE waitlist_entries
+		SET status = $1
+		WHERE customer_id = $2 AND ticket_id = ANY($3) AND status = $4 AND offer_expires_at > $5
+	`
+	args := []any{
+		domain.WaitlistEntryStatusClaimed,
+		order.CustomerID,
+		ticketIDs,
+		domain.WaitlistEntryStatusOffered,
+		order.CreatedAt,
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if order.PromoCodeID != nil {
+		err = redeemPromoCode(ctx, tx, order)
+		if err != nil {
+			return err
+		}
+	}
+
+	if bookingFee.Currency != nil {
+		rate, err := exchangeRate(ctx, tx, *bookingFee.Currency, order.Currency)
+		if err != nil {
+			return err
+		}
+		bookingFee.Fixed = rate.Convert(bookingFee.Fixed)
+	}
+
+	var tax *domain.TaxRate
+	if taxJurisdiction != nil {
+		tax = &domain.TaxRate{Jurisdiction: *taxJurisdiction, Rate: *taxRate}
+	}
+	order.SetTotal(bookingFee, tax)
+
+	chargedCurrency := order.Currency
+	if order.PaymentMethod == domain.PaymentMethodBalance {
+		chargedCurrency = walletCurrency
+	}
+
+	rate, err := exchangeRate(ctx, tx, order.Currency, chargedCurrency)
+	if err != nil {
+		return err
+	}
+	order.ChargedCurrency = rate.QuoteCurrency
+	order.ExchangeRate = rate.Rate
+	order.ChargedAmount = rate.Convert(order.TotalPrice)
+
+	var giftCardSpent float64
+	if order.GiftCardID != nil {
+		giftCardSpent, err = spendGiftCard(ctx, tx, order)
+		if err != nil {
+			return err
+		}
+	}
+
+	if order.PaymentMethod == domain.PaymentMethodBalance {
+		query = `
+			UPDATE customers
+			SET balance = balance - $1
+			WHERE id = $2
+		`
+
+		_, err = tx.ExecContext(ctx, query, order.ChargedAmount, order.CustomerID)
+		if err != nil {
+			switch {
+			case err.Error() == `ERROR: new row for relation "customers" violates check constraint "customers_balance_check" (SQLSTATE 23514)`:
+				return utils.ErrInsufficientBalance
+			default:
+				return err
+			}
+		}
+	}
+
+	query = `
+		INSERT INTO orders (customer_id, event_id, subtotal, discount, promo_code_id, booking_fee, tax, tax_rate, tax_jurisdiction,
+			total_price, currency, charged_currency, exchange_rate, gift_card_id, gift_card_amount, charged_amount, payment_method, status,
+			created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		RETURNING id
+	`
+	args = []any{
+		order.CustomerID,
+		order.EventID,
+		order.Subtotal,
+		order.Discount,
+		order.PromoCodeID,
+		order.BookingFee,
+		order.Tax,
+		order.TaxRate,
+		order.TaxJurisdiction,
+		order.TotalPrice,
+		order.Currency,
+		order.ChargedCurrency,
+		order.ExchangeRate,
+		order.GiftCardID,
+		order.GiftCardAmount,
+		order.ChargedAmount,
+		order.PaymentMethod,
+		order.Status,
+		order.CreatedAt,
+		order.ExpiresAt,
+	}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&order.ID)
+	if err != nil {
+		return err
+	}
+
+	if order.PromoCodeID != nil {
+		query = `
+			INSERT INTO promo_code_redemptions (promo_code_id, customer_id, order_id, discount, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+		args = []any{order.PromoCodeID, order.CustomerID, order.ID, order.Discount, order.CreatedAt}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if giftCardSpent > 0 {
+		transaction := &domain.GiftCardTransaction{
+			GiftCardID: *order.GiftCardID,
+			CustomerID: order.CustomerID,
+			OrderID:    &order.ID,
+			Type:       domain.GiftCardTransactionCheckout,
+			Amount:     -giftCardSpent,
+			CreatedAt:  order.CreatedAt,
+		}
+
+		err = addGiftCardTransaction(ctx, tx, transaction)
+		if err != nil {
+			return err
+		}
+	}
+
+	query = `
+		INSERT INTO order_items (order_id, ticket_id, quantity, unit_price, total_price)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	itemStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer itemStmt.Close()
+
+	for _, item := range order.Items {
+		item.OrderID = order.ID
+		args := []any{item.OrderID, item.TicketID, item.Quantity, item.UnitPrice, item.TotalPrice}
+
+		err = itemStmt.QueryRowContext(ctx, args...).Scan(&item.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	query = `
+		INSERT INTO order_item_prices (order_item_id, tier_id, name, quantity, unit_price)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	priceStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer priceStmt.Close()
+
+	for _, item := range order.Items {
+		for _, price := range item.Prices {
+			_, err = priceStmt.ExecContext(ctx, item.ID, price.TierID, price.Name, price.Quantity, price.UnitPrice)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	query = `
+		INSERT INTO issued_tickets (order_id, order_item_id, ticket_id, event_id, customer_id, code, status, created_at, seat_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	issuedTicketStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer issuedTicketStmt.Close()
+
+	for _, item := range order.Items {
+		for _, issuedTicket := range item.IssuedTickets {
+			issuedTicket.OrderID = order.ID
+			issuedTicket.OrderItemID = item.ID
+			args := []any{
+				issuedTicket.OrderID,
+				issuedTicket.OrderItemID,
+				issuedTicket.TicketID,
+				issuedTicket.EventID,
+				issuedTicket.CustomerID,
+				issuedTicket.Code,
+				issuedTicket.Status,
+				issuedTicket.CreatedAt,
+				issuedTicket.SeatID,
+			}
+
+			err = issuedTicketStmt.QueryRowContext(ctx, args...).Scan(&issuedTicket.ID)
+			if err != nil {
+				switch {
+				case err.Error() == `ERROR: duplicate key value violates unique constraint "issued_tickets_event_id_seat_id_valid_idx" (SQLSTATE 23505)`:
+					return utils.ErrSeatUnavailable
+				default:
+					return err
+				}
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *OrderRepository) GetByID(orderID int64) (*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, customer_id, event_id, subtotal, discount, promo_code_id, booking_fee, tax, tax_rate, tax_jurisdiction, total_price,
+			currency, charged_currency, exchange_rate, gift_card_id, gift_card_amount, charged_amount, payment_method, payment_provider, payment_intent_id,
+			status, created_at, expires_at
+		FROM orders
+		WHERE id = $1
+	`
+
+	orders, err := r.query(query, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orders) == 0 {
+		return nil, utils.ErrOrderNotFound
+	}
+
+	return orders[0], nil
+}
+
+func (r *OrderRepository) Cancel(order *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE orders
+		SET status = $1
+		WHERE id = $2 AND status = $3
+	`
+	args := []any{domain.OrderStatusCancelled, order.ID, domain.OrderStatusCompleted}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return utils.ErrOrderNotCancellable
+	}
+
+	err = restockItems(ctx, tx, order)
+	if err != nil {
+		return err
+	}
+
+	if order.PaymentMethod == domain.PaymentMethodBalance {
+		query = `
+			UPDATE customers
+			SET balance = balance + $1
+			WHERE id = $2
+		`
+
+		_, err = tx.ExecContext(ctx, query, order.ChargedAmount, order.CustomerID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = addPaymentRefund(ctx, tx, order, order.ChargedAmount, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Voiding locks the issued tickets, so a ticket checked in or transferred
+	// at the same time is either seen here or rejected as void.
+	query = `
+		UPDATE issued_tickets
+		SET status = $1
+		WHERE order_id = $2
+		RETURNING checked_in_at, customer_id
+	`
+
+	rows, err := tx.QueryContext(ctx, query, domain.IssuedTicketStatusVoid, order.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	cancellable := true
+	for rows.Next() {
+		var (
+			checkedInAt *time.Time
+			customerID  int64
+		)
+
+		err := rows.Scan(&checkedInAt, &customerID)
+		if err != nil {
+			return err
+		}
+
+		if checkedInAt != nil || customerID != order.CustomerID {
+			cancellable = false
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if !cancellable {
+		return utils.ErrOrderNotCancellable
+	}
+
+	err = returnPromoCode(ctx, tx, order)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = returnGiftCard(ctx, tx, order, 1)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	order.Status = domain.OrderStatusCancelled
+
+	return nil
+}
+
+// The event is locked, so an order is either completed before the event is
+// cancelled and refunded with the others, or not at all.
+func (r *OrderRepository) Complete(order *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT status
+		FROM events
+		WHERE id = $1
+		FOR SHARE
+	`
+
+	var eventStatus domain.EventStatus
+
+	err = tx.QueryRowContext(ctx, query, order.EventID).Scan(&eventStatus)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrEventNotFound
+		default:
+			return err
+		}
+	}
+
+	if eventStatus == domain.EventStatusCancelled {
+		return utils.ErrEventCancelled
+	}
+
+	query = `
+		UPDATE orders
+		SET status = $1
+		WHERE id = $2 AND status = $3
+	`
+	args := []any{domain.OrderStatusCompleted, order.ID, domain.OrderStatusPending}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return utils.ErrOrderNotPending
+	}
+
+	query = `
+		UPDATE issued_tickets
+		SET status = $1
+		WHERE order_id = $2 AND status = $3
+	`
+	args = []any{domain.IssuedTicketStatusValid, order.ID, domain.IssuedTicketStatusPending}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	order.Status = domain.OrderStatusCompleted
+	for _, item := range order.Items {
+		for _, issuedTicket := range item.IssuedTickets {
+			if issuedTicket.Status == domain.IssuedTicketStatusPending {
+				issuedTicket.Status = domain.IssuedTicketStatusValid
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *OrderRepository) Release(order *domain.Order, status domain.OrderStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE orders
+		SET status = $1
+		WHERE id = $2 AND status = $3
+	`
+	args := []any{status, order.ID, domain.OrderStatusPending}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return utils.ErrOrderNotPending
+	}
+
+	err = restockItems(ctx, tx, order)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE issued_tickets
+		SET status = $1
+		WHERE order_id = $2
+	`
+
+	_, err = tx.ExecContext(ctx, query, domain.IssuedTicketStatusVoid, order.ID)
+	if err != nil {
+		return err
+	}
+
+	err = returnPromoCode(ctx, tx, order)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = returnGiftCard(ctx, tx, order, 1)
+	if err != nil {
+		return err
+	}
+
+	if status == domain.OrderStatusRefundPending {
+		err = addPaymentRefund(ctx, tx, order, order.ChargedAmount, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	order.Status = status
+
+	return nil
+}
+
+func (r *OrderRepository) SetPaymentIntent(order *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE orders
+		SET payment_provider = $1, payment_intent_id = $2
+		WHERE id = $3
+	`
+	args := []any{order.PaymentProvider, order.PaymentIntentID, order.ID}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *OrderRepository) GetByPaymentIntentID(provider string, intentID string) (*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, customer_id, event_id, subtotal, discount, promo_code_id, booking_fee, tax, tax_rate, tax_jurisdiction, total_price,
+			currency, charged_currency, exchange_rate, gift_card_id, gift_card_amount, charged_amount, payment_method, payment_provider, payment_intent_id,
+			status, created_at, expires_at
+		FROM orders
+		WHERE payment_provider = $1 AND payment_intent_id = $2
+	`
+
+	orders, err := r.query(query, provider, intentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orders) == 0 {
+		return nil, utils.ErrOrderNotFound
+	}
+
+	return orders[0], nil
+}
+
+func (r *OrderRepository) GetExpired(now time.Time, limit int) ([]*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, customer_id, event_id, subtotal, discount, promo_code_id, booking_fee, tax, tax_rate, tax_jurisdiction, total_price,
+			currency, charged_currency, exchange_rate, gift_card_id, gift_card_amount, charged_amount, payment_method, payment_provider, payment_intent_id,
+			status, created_at, expires_at
+		FROM orders
+		WHERE status = $1 AND expires_at <= $2
+		ORDER BY expires_at
+		LIMIT $3
+	`
+
+	return r.query(query, domain.OrderStatusPending, now, limit)
+}
+
+func (r *OrderRepository) RequestRefund(order *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE orders
+		SET status = $1
+		WHERE id = $2 AND status IN ($3, $4)
+	`
+	args := []any{domain.OrderStatusRefundPending, order.ID, domain.OrderStatusFailed, domain.OrderStatusExpired}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return nil
+	}
+
+	err = addPaymentRefund(ctx, tx, order, order.ChargedAmount, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	order.Status = domain.OrderStatusRefundPending
+
+	return nil
+}
+
+func (r *OrderRepository) GetByCustomerID(customerID int64) ([]*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, customer_id, event_id, subtotal, discount, promo_code_id, booking_fee, tax, tax_rate, tax_jurisdiction, total_price,
+			currency, charged_currency, exchange_rate, gift_card_id, gift_card_amount, charged_amount, payment_method, payment_provider, payment_intent_id,
+			status, created_at, expires_at
+		FROM orders
+		WHERE customer_id = $1
+	`
+
+	return r.query(query, customerID)
+}
+
+func (r *OrderRepository) GetCompletedByEventID(eventID int64, afterOrderID int64, limit int) ([]*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, customer_id, event_id, subtotal, discount, promo_code_id, booking_fee, tax, tax_rate, tax_jurisdiction, total_price,
+			currency, charged_currency, exchange_rate, gift_card_id, gift_card_amount, charged_amount, payment_method, payment_provider, payment_intent_id,
+			status, created_at, expires_at
+		FROM orders
+		WHERE event_id = $1 AND status = $2 AND id > $3
+		ORDER BY id
+		LIMIT $4
+	`
+
+	return r.query(query, eventID, domain.OrderStatusCompleted, afterOrderID, limit)
+}
+
+func (r *OrderRepository) query(query string, args ...any) ([]*domain.Order, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := make([]*domain.Order, 0)
+	ordersByID := make(map[int64]*domain.Order)
+	orderIDs := make([]int64, 0)
+	for rows.Next() {
+		var order domain.Order
+
+		err := rows.Scan(
+			&order.ID,
+			&order.CustomerID,
+			&order.EventID,
+			&order.Subtotal,
+			&order.Discount,
+			&order.PromoCodeID,
+			&order.BookingFee,
+			&order.Tax,
+			&order.TaxRate,
+			&order.TaxJurisdiction,
+			&order.TotalPrice,
+			&order.Currency,
+			&order.ChargedCurrency,
+			&order.ExchangeRate,
+			&order.GiftCardID,
+			&order.GiftCardAmount,
+			&order.ChargedAmount,
+			&order.PaymentMethod,
+			&order.PaymentProvider,
+			&order.PaymentIntentID,
+			&order.Status,
+			&order.CreatedAt,
+			&order.ExpiresAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		order.Items = make([]*domain.OrderItem, 0)
+		orders = append(orders, &order)
+		ordersByID[order.ID] = &order
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(orders) == 0 {
+		return orders, nil
+	}
+
+	query = `
+		SELECT id, order_id, ticket_id, quantity, unit_price, total_price
+		FROM order_items
+		WHERE order_id = ANY($1)
+		ORDER BY id
+	`
+
+	itemRows, err := r.db.QueryContext(ctx, query, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer itemRows.Close()
+
+	itemsByID := make(map[int64]*domain.OrderItem)
+	for itemRows.Next() {
+		var item domain.OrderItem
+
+		err := itemRows.Scan(
+			&item.ID,
+			&item.OrderID,
+			&item.TicketID,
+			&item.Quantity,
+			&item.UnitPrice,
+			&item.TotalPrice,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		item.Prices = make([]*domain.OrderItemPrice, 0)
+		itemsByID[item.ID] = &item
+
+		order := ordersByID[item.OrderID]
+		order.Items = append(order.Items, &item)
+	}
+
+	if err := itemRows.Err(); err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT OIP.order_item_id, OIP.tier_id, OIP.name, OIP.quantity, OIP.unit_price
+		FROM order_item_prices OIP
+		JOIN order_items OI ON OIP.order_item_id = OI.id
+		WHERE OI.order_id = ANY($1)
+		ORDER BY OIP.id
+	`
+
+	priceRows, err := r.db.QueryContext(ctx, query, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer priceRows.Close()
+
+	for priceRows.Next() {
+		var (
+			itemID int64
+			price  domain.OrderItemPrice
+		)
+
+		err := priceRows.Scan(&itemID, &price.TierID, &price.Name, &price.Quantity, &price.UnitPrice)
+		if err != nil {
+			return nil, err
+		}
+
+		item := itemsByID[itemID]
+		item.Prices = append(item.Prices, &price)
+	}
+
+	if err := priceRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// DeleteAll returns the deleted orders whose stock went back on sale.
+func (r *OrderRepository) DeleteAll() ([]*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, customer_id, event_id, subtotal, discount, promo_code_id, booking_fee, tax, tax_rate, tax_jurisdiction, total_price,
+			currency, charged_currency, exchange_rate, gift_card_id, gift_card_amount, charged_amount, payment_method, payment_provider, payment_intent_id,
+			status, created_at, expires_at
+		FROM orders
+		ORDER BY id
+	`
+
+	orders, err := r.query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	orderIDs := make([]int64, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	stmt, err := r.db.PrepareContext(ctx, query)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer stmt.Close()
+	defer tx.Rollback()
+
+	// The statuses are read again with the orders locked, as they may have
+	// moved on since.
+	query = `
+		SELECT id, status
+		FROM orders
+		WHERE id = ANY($1)
+		ORDER BY id
+		FOR UPDATE
+	`
 
-	rows, err := stmt.QueryContext(ctx)
+	rows, err := tx.QueryContext(ctx, query, orderIDs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	orders := make([]*domain.Order, 0)
+	statuses := make(map[int64]domain.OrderStatus, len(orders))
 	for rows.Next() {
-		var order domain.Order
-
-		err = rows.Scan(
-			&order.ID,
-			&order.CustomerID,
-			&order.TicketID,
-			&order.Quantity,
-			&order.TotalPrice,
-			&order.CreatedAt,
+		var (
+			orderID int64
+			status  domain.OrderStatus
 		)
+
+		err := rows.Scan(&orderID, &status)
 		if err != nil {
 			return nil, err
 		}
 
-		orders = append(orders, &order)
+		statuses[orderID] = status
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	rows.Close()
 
-	return orders, nil
-}
+	restocked := make([]*domain.Order, 0)
+	balanceRefunds := make(map[int64]float64)
+	for _, order := range orders {
+		status, ok := statuses[order.ID]
+		if !ok {
+			continue
+		}
+		order.Status = status
 
-func (r *OrderRepository) Add(order *domain.Order) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+		held := order.Status == domain.OrderStatusPending || order.Status == domain.OrderStatusCompleted
+
+		if order.PaymentMethod == domain.PaymentMethodProvider && (held || order.Status == domain.OrderStatusRefundPending) {
+			return nil, utils.ErrOrdersNotDeletable
+		}
+
+		if !held {
+			continue
+		}
+
+		err = restockItems(ctx, tx, order)
+		if err != nil {
+			return nil, err
+		}
+		restocked = append(restocked, order)
+
+		err = returnPromoCode(ctx, tx, order)
+		if err != nil {
+			return nil, err
+		}
 
+		_, _, err = returnGiftCard(ctx, tx, order, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if order.Status == domain.OrderStatusCompleted && order.PaymentMethod == domain.PaymentMethodBalance {
+			balanceRefunds[order.CustomerID] += order.ChargedAmount
+		}
+	}
+
+	customerIDs := make([]int64, 0, len(balanceRefunds))
+	for customerID := range balanceRefunds {
+		customerIDs = append(customerIDs, customerID)
+	}
+	sort.Slice(customerIDs, func(i, j int) bool {
+		return customerIDs[i] < customerIDs[j]
+	})
+
+	for _, customerID := range customerIDs {
+		query = `
+			UPDATE customers
+			SET balance = balance + $1
+			WHERE id = $2
+		`
+
+		_, err = tx.ExecContext(ctx, query, balanceRefunds[customerID], customerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	query = `
+		DELETE FROM orders
+		WHERE id = ANY($1)
+	`
+
+	_, err = tx.ExecContext(ctx, query, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return restocked, nil
+}
+
+// The ticket is locked by the caller.
+func priceItem(ctx context.Context, tx *sql.Tx, item *domain.OrderItem, at time.Time) error {
 	query := `
-	INSERT INTO orders (customer_id, ticket_id, quantity, total_price, created_at)
-	VALUES ($1, $2, $3, $4, $5)
-	RETURNING id
-`
-	args := []any{order.CustomerID, order.TicketID, order.Quantity, order.TotalPrice, order.CreatedAt}
+		SELECT TT.price
+		FROM tickets T
+		JOIN ticket_types TT ON T.ticket_type_id = TT.id
+		WHERE T.id = $1
+	`
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
+	var regularPrice float64
 
-	stmt, err := r.db.PrepareContext(ctx, query)
+	err := tx.QueryRowContext(ctx, query, item.TicketID).Scan(&regularPrice)
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
 
-	return stmt.QueryRowContext(ctx, args...).Scan(&order.ID)
+	query = `
+		SELECT id, ticket_id, name, price, ends_at, max_sold, sold, position
+		FROM price_tiers
+		WHERE ticket_id = $1
+		ORDER BY position
+	`
+
+	rows, err := tx.QueryContext(ctx, query, item.TicketID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	tiers := make([]*domain.PriceTier, 0)
+	for rows.Next() {
+		var tier domain.PriceTier
+
+		err := rows.Scan(
+			&tier.ID,
+			&tier.TicketID,
+			&tier.Name,
+			&tier.Price,
+			&tier.EndsAt,
+			&tier.MaxSold,
+			&tier.Sold,
+			&tier.Position,
+		)
+		if err != nil {
+			return err
+		}
+
+		tiers = append(tiers, &tier)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	item.SetPrices(domain.AllocatePrices(tiers, regularPrice, item.Quantity, at))
+
+	query = `
+		UPDATE price_tiers
+		SET sold = sold + $1
+		WHERE id = $2
+	`
+
+	for _, price := range item.Prices {
+		if price.TierID == nil {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, query, price.Quantity, price.TierID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (r *OrderRepository) GetByCustomerID(customerID int64) ([]*domain.Order, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// The update locks the code, so concurrent orders using it can't exceed its
+// caps.
+func redeemPromoCode(ctx context.Context, tx *sql.Tx, order *domain.Order) error {
+	query := `
+		UPDATE promo_codes
+		SET times_redeemed = times_redeemed + 1
+		WHERE id = $1
+			AND (max_redemptions IS NULL OR times_redeemed < max_redemptions)
+			AND (valid_from IS NULL OR valid_from <= $2)
+			AND (valid_until IS NULL OR valid_until > $2)
+		RETURNING discount_type, discount_value, currency, max_per_customer
+	`
+
+	var promoCode domain.PromoCode
+
+	err := tx.QueryRowContext(ctx, query, order.PromoCodeID, order.CreatedAt).Scan(
+		&promoCode.DiscountType,
+		&promoCode.DiscountValue,
+		&promoCode.Currency,
+		&promoCode.MaxPerCustomer,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrPromoCodeUnavailable
+		default:
+			return err
+		}
+	}
+
+	if promoCode.Currency != nil {
+		rate, err := exchangeRate(ctx, tx, *promoCode.Currency, order.Currency)
+		if err != nil {
+			return err
+		}
+		promoCode.DiscountValue = rate.Convert(promoCode.DiscountValue)
+	}
+
+	order.Discount = promoCode.Discount(order.Subtotal)
+
+	maxPerCustomer := promoCode.MaxPerCustomer
+	if maxPerCustomer == nil {
+		return nil
+	}
+
+	query = `
+		SELECT COUNT(*)
+		FROM promo_code_redemptions
+		WHERE promo_code_id = $1 AND customer_id = $2
+	`
 
+	var redeemed int
+
+	err = tx.QueryRowContext(ctx, query, order.PromoCodeID, order.CustomerID).Scan(&redeemed)
+	if err != nil {
+		return err
+	}
+
+	if redeemed >= *maxPerCustomer {
+		return fmt.Errorf("%w: at most %d per customer", utils.ErrPromoCodeLimitReached, *maxPerCustomer)
+	}
+
+	return nil
+}
+
+// The card row is locked, so concurrent checkouts and redemptions never spend
+// more than its balance.
+func spendGiftCard(ctx context.Context, tx *sql.Tx, order *domain.Order) (float64, error) {
 	query := `
-		SELECT id, customer_id, ticket_id, quantity, total_price, created_at
-		FROM orders
-		WHERE customer_id = $1
+		SELECT balance, currency, expires_at
+		FROM gift_cards
+		WHERE id = $1
+		FOR UPDATE
 	`
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
+	var giftCard domain.GiftCard
 
-	stmt, err := r.db.PrepareContext(ctx, query)
+	err := tx.QueryRowContext(ctx, query, order.GiftCardID).Scan(&giftCard.Balance, &giftCard.Currency, &giftCard.ExpiresAt)
 	if err != nil {
-		return nil, err
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return 0, utils.ErrGiftCardNotFound
+		default:
+			return 0, err
+		}
+	}
+
+	if giftCard.Expired(order.CreatedAt) || giftCard.Balance == 0 {
+		return 0, utils.ErrGiftCardUnavailable
 	}
-	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx, customerID)
+	rate, err := exchangeRate(ctx, tx, giftCard.Currency, order.ChargedCurrency)
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+
+	covered, spent := giftCard.Cover(order.ChargedAmount, rate)
+
+	query = `
+		UPDATE gift_cards
+		SET balance = balance - $1
+		WHERE id = $2
+	`
+
+	_, err = tx.ExecContext(ctx, query, spent, order.GiftCardID)
+	if err != nil {
+		return 0, err
+	}
+
+	order.GiftCardAmount = covered
+	order.ChargedAmount = domain.RoundCents(order.ChargedAmount - covered)
+
+	return spent, nil
+}
+
+func assignSeats(ctx context.Context, tx *sql.Tx, eventID int64, item *domain.OrderItem) error {
+	query := `
+		SELECT S.id, S.section_id, SS.name, S.row_label, S.number,
+			EXISTS (
+				SELECT 1
+				FROM issued_tickets IT
+				WHERE IT.event_id = $2 AND IT.seat_id = S.id AND IT.status IN ($3, $4)
+			)
+		FROM ticket_seats TS
+		JOIN venue_seats S ON TS.seat_id = S.id
+		JOIN venue_sections SS ON S.section_id = SS.id
+		WHERE TS.ticket_id = $1
+		ORDER BY SS.position, S.row_position, S.number
+	`
+	args := []any{item.TicketID, eventID, domain.IssuedTicketStatusValid, domain.IssuedTicketStatusPending}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
 	}
 	defer rows.Close()
 
-	orders := make([]*domain.Order, 0)
+	seated := false
+	taken := make(map[int64]bool)
+	free := make([]*domain.Seat, 0)
 	for rows.Next() {
-		var order domain.Order
-
-		err := rows.Scan(
-			&order.ID,
-			&order.CustomerID,
-			&order.TicketID,
-			&order.Quantity,
-			&order.TotalPrice,
-			&order.CreatedAt,
+		var (
+			seat    domain.Seat
+			isTaken bool
 		)
+
+		err := rows.Scan(&seat.ID, &seat.SectionID, &seat.Section, &seat.Row, &seat.Number, &isTaken)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		orders = append(orders, &order)
+		seated = true
+		taken[seat.ID] = isTaken
+		if !isTaken {
+			free = append(free, &seat)
+		}
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return err
 	}
 
-	return orders, nil
+	if !seated {
+		if len(item.SeatIDs) > 0 {
+			return fmt.Errorf("%w: ticket %d", utils.ErrTicketNotSeated, item.TicketID)
+		}
+		return nil
+	}
+
+	if len(item.SeatIDs) > 0 {
+		for _, seatID := range item.SeatIDs {
+			isTaken, ok := taken[seatID]
+			if !ok {
+				return fmt.Errorf("%w: seat %d is not sold under ticket %d", utils.ErrSeatNotFound, seatID, item.TicketID)
+			}
+			if isTaken {
+				return fmt.Errorf("%w: seat %d", utils.ErrSeatUnavailable, seatID)
+			}
+		}
+	} else {
+		seats := bestAvailableSeats(free, item.Quantity)
+		if len(seats) < item.Quantity {
+			return fmt.Errorf("%w: ticket %d", utils.ErrInsufficientTicketQuantity, item.TicketID)
+		}
+
+		item.SeatIDs = make([]int64, 0, len(seats))
+		for _, seat := range seats {
+			item.SeatIDs = append(item.SeatIDs, seat.ID)
+		}
+	}
+
+	for i, issuedTicket := range item.IssuedTickets {
+		issuedTicket.SeatID = &item.SeatIDs[i]
+	}
+
+	return nil
 }
 
-func (r *OrderRepository) DeleteAll() error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+func bestAvailableSeats(free []*domain.Seat, quantity int) []*domain.Seat {
+	for start := range free {
+		end := start + quantity
+		if end > len(free) {
+			break
+		}
 
-	query := "DELETE FROM orders"
+		together := true
+		for i := start + 1; i < end; i++ {
+			prev, seat := free[i-1], free[i]
+			if seat.SectionID != prev.SectionID || seat.Row != prev.Row || seat.Number != prev.Number+1 {
+				together = false
+				break
+			}
+		}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
+		if together {
+			return free[start:end]
+		}
+	}
 
-	stmt, err := r.db.PrepareContext(ctx, query)
+	if len(free) < quantity {
+		return free
+	}
+
+	return free[:quantity]
+}
+
+// Tickets are always updated in the same order to avoid deadlocks.
+func restockItems(ctx context.Context, tx *sql.Tx, order *domain.Order) error {
+	query := `
+		UPDATE tickets
+		SET quantity = quantity + $1
+		WHERE id = $2
+	`
+
+	ticketStmt, err := tx.PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
+	defer ticketStmt.Close()
+
+	items := make([]*domain.OrderItem, len(order.Items))
+	copy(items, order.Items)
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].TicketID < items[j].TicketID
+	})
+
+	for _, item := range items {
+		_, err = ticketStmt.ExecContext(ctx, item.Quantity, item.TicketID)
+		if err != nil {
+			return err
+		}
+	}
+
+	query = `
+		UPDATE price_tiers PT
+		SET sold = PT.sold - P.quantity
+		FROM order_item_prices P
+		JOIN order_items OI ON P.order_item_id = OI.id
+		WHERE P.tier_id = PT.id AND OI.order_id = $1
+	`
+
+	_, err = tx.ExecContext(ctx, query, order.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func returnPromoCode(ctx context.Context, tx *sql.Tx, order *domain.Order) error {
+	query := `
+		WITH redemption AS (
+			DELETE FROM promo_code_redemptions
+			WHERE order_id = $1
+			RETURNING promo_code_id
+		)
+		UPDATE promo_codes
+		SET times_redeemed = times_redeemed - 1
+		WHERE id IN (SELECT promo_code_id FROM redemption)
+	`
 
-	_, err = stmt.ExecContext(ctx)
+	_, err := tx.ExecContext(ctx, query, order.ID)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// Not idempotent on its own: orders are only refunded once, as refunding moves
+// them out of their status. Returns the amount put back on the card, in its
+// currency.
+func returnGiftCard(ctx context.Context, tx *sql.Tx, order *domain.Order, share float64) (float64, string, error) {
+	if order.GiftCardID == nil {
+		return 0, "", nil
+	}
+
+	query := `
+		WITH refund AS (
+			INSERT INTO gift_card_transactions (gift_card_id, customer_id, order_id, type, amount, created_at)
+			SELECT gift_card_id, customer_id, order_id, $2::VARCHAR, ROUND(-SUM(amount) * $3, 2), NOW()
+			FROM gift_card_transactions
+			WHERE order_id = $1
+			GROUP BY gift_card_id, customer_id, order_id
+			HAVING ROUND(SUM(amount) * $3, 2) < 0
+			RETURNING gift_card_id, amount
+		)
+		UPDATE gift_cards G
+		SET balance = G.balance + R.amount
+		FROM refund R
+		WHERE G.id = R.gift_card_id
+		RETURNING R.amount, G.currency
+	`
+
+	var amount float64
+	var currency string
+
+	err := tx.QueryRowContext(ctx, query, order.ID, domain.GiftCardTransactionRefund, share).Scan(&amount, &currency)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return 0, "", nil
+		default:
+			return 0, "", err
+		}
+	}
+
+	return amount, currency, nil
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+)
+
+type PaymentRefundRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewPaymentRefundRepository(db *sql.DB) IPaymentRefundRepository {
+	return &PaymentRefundRepository{
+		db: db,
+	}
+}
+
+func (r *PaymentRefundRepository) GetPending(provider string, afterID int64, limit int) ([]*domain.PaymentRefund, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, order_id, top_up_id, provider, intent_id, amount, currency, status, attempts, last_error, created_at, refunded_at
+		FROM payment_refunds
+		WHERE provider = $1 AND status = $2 AND id > $3
+		ORDER BY id
+		LIMIT $4
+	`
+
+	return r.query(query, provider, domain.PaymentRefundStatusPending, afterID, limit)
+}
+
+func (r *PaymentRefundRepository) GetPendingByOrderID(orderID int64) ([]*domain.PaymentRefund, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, order_id, top_up_id, provider, intent_id, amount, currency, status, attempts, last_error, created_at, refunded_at
+		FROM payment_refunds
+		WHERE order_id = $1 AND status = $2
+	`
+
+	return r.query(query, orderID, domain.PaymentRefundStatusPending)
+}
+
+func (r *PaymentRefundRepository) Complete(refund *domain.PaymentRefund) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE payment_refunds
+		SET status = $1, attempts = attempts + 1, last_error = NULL, refunded_at = $2
+		WHERE id = $3 AND status = $4
+	`
+	refundedAt := time.Now()
+	args := []any{domain.PaymentRefundStatusSucceeded, refundedAt, refund.ID, domain.PaymentRefundStatusPending}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE orders
+		SET status = $1
+		WHERE id = $2 AND status = $3
+	`
+
+	_, err = tx.ExecContext(ctx, query, domain.OrderStatusRefunded, refund.OrderID, domain.OrderStatusRefundPending)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	refund.Status = domain.PaymentRefundStatusSucceeded
+	refund.Attempts++
+	refund.LastError = nil
+	refund.RefundedAt = &refundedAt
+
+	return nil
+}
+
+func (r *PaymentRefundRepository) Fail(refund *domain.PaymentRefund, cause string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE payment_refunds
+		SET attempts = attempts + 1, last_error = $1
+		WHERE id = $2 AND status = $3
+	`
+	args := []any{cause, refund.ID, domain.PaymentRefundStatusPending}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	refund.Attempts++
+	refund.LastError = &cause
+
+	return nil
+}
+
+func (r *PaymentRefundRepository) query(query string, args ...any) ([]*domain.PaymentRefund, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refunds := make([]*domain.PaymentRefund, 0)
+	for rows.Next() {
+		var refund domain.PaymentRefund
+
+		err := rows.Scan(
+			&refund.ID,
+			&refund.OrderID,
+			&refund.TopUpID,
+			&refund.Provider,
+			&refund.IntentID,
+			&refund.Amount,
+			&refund.Currency,
+			&refund.Status,
+			&refund.Attempts,
+			&refund.LastError,
+			&refund.CreatedAt,
+			&refund.RefundedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		refunds = append(refunds, &refund)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return refunds, nil
+}
+
+// addPaymentRefund records the amount the provider owes back on the order, for
+// orders paid through it. An order is only refunded through the provider once.
+func addPaymentRefund(ctx context.Context, tx *sql.Tx, order *domain.Order, amount float64, at time.Time) error {
+	if order.PaymentMethod != domain.PaymentMethodProvider || order.PaymentProvider == nil || order.PaymentIntentID == nil || amount <= 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO payment_refunds (order_id, provider, intent_id, amount, currency, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (order_id) DO NOTHING
+	`
+	args := []any{
+		order.ID,
+		*order.PaymentProvider,
+		*order.PaymentIntentID,
+		amount,
+		order.ChargedCurrency,
+		domain.PaymentRefundStatusPending,
+		at,
+	}
+
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type PresaleRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewPresaleRepository(db *sql.DB) IPresaleRepository {
+	return &PresaleRepository{
+		db: db,
+	}
+}
+
+func (r *PresaleRepository) GetByTicketID(ticketID int64) ([]*domain.Presale, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT P.id, P.ticket_id, P.name, P.starts_at, P.ends_at, P.access_code,
+			COALESCE(ARRAY_AGG(PC.customer_id) FILTER (WHERE PC.customer_id IS NOT NULL), '{}')
+		FROM presales P
+		LEFT JOIN presale_customers PC ON P.id = PC.presale_id
+		WHERE P.ticket_id = $1
+		GROUP BY P.id
+		ORDER BY P.starts_at
+	`
+
+	return r.query(query, ticketID)
+}
+
+func (r *PresaleRepository) GetActiveByTicketID(ticketID int64, at time.Time) ([]*domain.Presale, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT P.id, P.ticket_id, P.name, P.starts_at, P.ends_at, P.access_code,
+			COALESCE(ARRAY_AGG(PC.customer_id) FILTER (WHERE PC.customer_id IS NOT NULL), '{}')
+		FROM presales P
+		LEFT JOIN presale_customers PC ON P.id = PC.presale_id
+		WHERE P.ticket_id = $1 AND P.starts_at <= $2 AND P.ends_at > $2
+		GROUP BY P.id
+		ORDER BY P.starts_at
+	`
+
+	return r.query(query, ticketID, at)
+}
+
+func (r *PresaleRepository) query(query string, args ...any) ([]*domain.Presale, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	typeMap := pgtype.NewMap()
+
+	presales := make([]*domain.Presale, 0)
+	for rows.Next() {
+		var presale domain.Presale
+
+		err := rows.Scan(
+			&presale.ID,
+			&presale.TicketID,
+			&presale.Name,
+			&presale.StartsAt,
+			&presale.EndsAt,
+			&presale.AccessCode,
+			typeMap.SQLScanner(&presale.CustomerIDs),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		presales = append(presales, &presale)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return presales, nil
+}
+
+func (r *PresaleRepository) Add(presale *domain.Presale) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		INSERT INTO presales (ticket_id, name, starts_at, ends_at, access_code)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	args := []any{presale.TicketID, presale.Name, presale.StartsAt, presale.EndsAt, presale.AccessCode}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	insertStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer insertStmt.Close()
+
+	err = insertStmt.QueryRowContext(ctx, args...).Scan(&presale.ID)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO presale_customers (presale_id, customer_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	customerStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer customerStmt.Close()
+
+	for _, customerID := range presale.CustomerIDs {
+		_, err = customerStmt.ExecContext(ctx, presale.ID, customerID)
+		if err != nil {
+			switch {
+			case err.Error() == `ERROR: insert or update on table "presale_customers" violates foreign key constraint "presale_customers_fk_customer_id_customers_id" (SQLSTATE 23503)`:
+				return utils.ErrCustomerNotFound
+			default:
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type PriceTierRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewPriceTierRepository(db *sql.DB) IPriceTierRepository {
+	return &PriceTierRepository{
+		db: db,
+	}
+}
+
+func (r *PriceTierRepository) GetByTicketID(ticketID int64) ([]*domain.PriceTier, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, ticket_id, name, price, ends_at, max_sold, sold, position
+		FROM price_tiers
+		WHERE ticket_id = $1
+		ORDER BY position
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tiers := make([]*domain.PriceTier, 0)
+	for rows.Next() {
+		var tier domain.PriceTier
+
+		err := rows.Scan(
+			&tier.ID,
+			&tier.TicketID,
+			&tier.Name,
+			&tier.Price,
+			&tier.EndsAt,
+			&tier.MaxSold,
+			&tier.Sold,
+			&tier.Position,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		tiers = append(tiers, &tier)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tiers, nil
+}
+
+// The ticket row is locked, so tiers added at the same time get distinct
+// positions.
+func (r *PriceTierRepository) Add(tier *domain.PriceTier) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id
+		FROM tickets
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRowContext(ctx, query, tier.TicketID).Scan(&tier.TicketID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrTicketNotFound
+		default:
+			return err
+		}
+	}
+
+	query = `
+		INSERT INTO price_tiers (ticket_id, name, price, ends_at, max_sold, position)
+		SELECT $1, $2, $3, $4, $5, COALESCE(MAX(position), 0) + 1
+		FROM price_tiers
+		WHERE ticket_id = $1
+		RETURNING id, sold, position
+	`
+	args := []any{tier.TicketID, tier.Name, tier.Price, tier.EndsAt, tier.MaxSold}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&tier.ID, &tier.Sold, &tier.Position)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PriceTierRepository) Delete(ticketID int64, tierID int64) (*domain.PriceTier, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		DELETE FROM price_tiers
+		WHERE id = $1 AND ticket_id = $2
+		RETURNING id, ticket_id, name, price, ends_at, max_sold, sold, position
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var tier domain.PriceTier
+
+	err := r.db.QueryRowContext(ctx, query, tierID, ticketID).Scan(
+		&tier.ID,
+		&tier.TicketID,
+		&tier.Name,
+		&tier.Price,
+		&tier.EndsAt,
+		&tier.MaxSold,
+		&tier.Sold,
+		&tier.Position,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrPriceTierNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &tier, nil
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type PromoCodeRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewPromoCodeRepository(db *sql.DB) IPromoCodeRepository {
+	return &PromoCodeRepository{
+		db: db,
+	}
+}
+
+func (r *PromoCodeRepository) GetAll() ([]*domain.PromoCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, code, discount_type, discount_value, currency, event_id, max_redemptions, max_per_customer,
+			valid_from, valid_until, times_redeemed, created_at
+		FROM promo_codes
+		ORDER BY id
+	`
+
+	return r.query(query)
+}
+
+func (r *PromoCodeRepository) GetByID(promoCodeID int64) (*domain.PromoCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, code, discount_type, discount_value, currency, event_id, max_redemptions, max_per_customer,
+			valid_from, valid_until, times_redeemed, created_at
+		FROM promo_codes
+		WHERE id = $1
+	`
+
+	promoCodes, err := r.query(query, promoCodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(promoCodes) == 0 {
+		return nil, utils.ErrPromoCodeNotFound
+	}
+
+	return promoCodes[0], nil
+}
+
+func (r *PromoCodeRepository) GetByCode(code string) (*domain.PromoCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, code, discount_type, discount_value, currency, event_id, max_redemptions, max_per_customer,
+			valid_from, valid_until, times_redeemed, created_at
+		FROM promo_codes
+		WHERE code = $1
+	`
+
+	promoCodes, err := r.query(query, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(promoCodes) == 0 {
+		return nil, utils.ErrPromoCodeNotFound
+	}
+
+	return promoCodes[0], nil
+}
+
+func (r *PromoCodeRepository) Add(promoCode *domain.PromoCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		INSERT INTO promo_codes (code, discount_type, discount_value, currency, event_id, max_redemptions, max_per_customer,
+			valid_from, valid_until)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, times_redeemed, created_at
+	`
+	args := []any{
+		promoCode.Code,
+		promoCode.DiscountType,
+		promoCode.DiscountValue,
+		promoCode.Currency,
+		promoCode.EventID,
+		promoCode.MaxRedemptions,
+		promoCode.MaxPerCustomer,
+		promoCode.ValidFrom,
+		promoCode.ValidUntil,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(&promoCode.ID, &promoCode.TimesRedeemed, &promoCode.CreatedAt)
+	if err != nil {
+		switch {
+		case err.Error() == `ERROR: duplicate key value violates unique constraint "promo_codes_code_key" (SQLSTATE 23505)`:
+			return utils.ErrPromoCodeAlreadyExists
+		case err.Error() == `ERROR: insert or update on table "promo_codes" violates foreign key constraint "promo_codes_fk_event_id_events_id" (SQLSTATE 23503)`:
+			return utils.ErrEventNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *PromoCodeRepository) Update(promoCode *domain.PromoCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE promo_codes
+		SET max_redemptions = $1, max_per_customer = $2, valid_from = $3, valid_until = $4
+		WHERE id = $5
+		RETURNING times_redeemed
+	`
+	args := []any{
+		promoCode.MaxRedemptions,
+		promoCode.MaxPerCustomer,
+		promoCode.ValidFrom,
+		promoCode.ValidUntil,
+		promoCode.ID,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(&promoCode.TimesRedeemed)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrPromoCodeNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *PromoCodeRepository) query(query string, args ...any) ([]*domain.PromoCode, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promoCodes := make([]*domain.PromoCode, 0)
+	for rows.Next() {
+		var promoCode domain.PromoCode
+
+		err := rows.Scan(
+			&promoCode.ID,
+			&promoCode.Code,
+			&promoCode.DiscountType,
+			&promoCode.DiscountValue,
+			&promoCode.Currency,
+			&promoCode.EventID,
+			&promoCode.MaxRedemptions,
+			&promoCode.MaxPerCustomer,
+			&promoCode.ValidFrom,
+			&promoCode.ValidUntil,
+			&promoCode.TimesRedeemed,
+			&promoCode.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		promoCodes = append(promoCodes, &promoCode)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return promoCodes, nil
+}
@@ -3,19 +3,51 @@ package repository
 import "database/sql"
 
 type Repositories struct {
-	Customers   ICustomerRepository
-	Events      IEventRepository
-	TicketTypes ITicketTypeRepository
-	Tickets     ITicketRepository
-	Orders      IOrderRepository
+	Customers          ICustomerRepository
+	Events             IEventRepository
+	EventSeries        IEventSeriesRepository
+	EventImages        IEventImageRepository
+	EventCancellations IEventCancellationRepository
+	TicketTypes        ITicketTypeRepository
+	Tickets            ITicketRepository
+	Presales           IPresaleRepository
+	PriceTiers         IPriceTierRepository
+	Orders             IOrderRepository
+	IssuedTickets      IIssuedTicketRepository
+	TicketTransfers    ITicketTransferRepository
+	ResaleListings     IResaleListingRepository
+	Waitlists          IWaitlistRepository
+	Venues             IVenueRepository
+	PromoCodes         IPromoCodeRepository
+	TaxRates           ITaxRateRepository
+	ExchangeRates      IExchangeRateRepository
+	TopUps             ITopUpRepository
+	PaymentRefunds     IPaymentRefundRepository
+	GiftCards          IGiftCardRepository
 }
 
 func NewRepositories(db *sql.DB) Repositories {
 	return Repositories{
-		Customers:   NewCustomerRepository(db),
-		Events:      NewEventRepository(db),
-		TicketTypes: NewTicketTypeRepository(db),
-		Tickets:     NewTicketRepository(db),
-		Orders:      NewOrderRepository(db),
+		Customers:          NewCustomerRepository(db),
+		Events:             NewEventRepository(db),
+		EventSeries:        NewEventSeriesRepository(db),
+		EventImages:        NewEventImageRepository(db),
+		EventCancellations: NewEventCancellationRepository(db),
+		TicketTypes:        NewTicketTypeRepository(db),
+		Tickets:            NewTicketRepository(db),
+		Presales:           NewPresaleRepository(db),
+		PriceTiers:         NewPriceTierRepository(db),
+		Orders:             NewOrderRepository(db),
+		IssuedTickets:      NewIssuedTicketRepository(db),
+		TicketTransfers:    NewTicketTransferRepository(db),
+		ResaleListings:     NewResaleListingRepository(db),
+		Waitlists:          NewWaitlistRepository(db),
+		Venues:             NewVenueRepository(db),
+		PromoCodes:         NewPromoCodeRepository(db),
+		TaxRates:           NewTaxRateRepository(db),
+		ExchangeRates:      NewExchangeRateRepository(db),
+		TopUps:             NewTopUpRepository(db),
+		PaymentRefunds:     NewPaymentRefundRepository(db),
+		GiftCards:          NewGiftCardRepository(db),
 	}
 }
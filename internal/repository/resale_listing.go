@@ -0,0 +1,342 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type ResaleListingRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewResaleListingRepository(db *sql.DB) IResaleListingRepository {
+	return &ResaleListingRepository{
+		db: db,
+	}
+}
+
+func (r *ResaleListingRepository) GetListed() ([]*domain.ResaleListing, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT L.id, L.issued_ticket_id, IT.event_id, IT.ticket_id, L.seller_id, L.buyer_id, L.price, L.fee, L.currency, L.status, L.created_at, L.sold_at
+		FROM resale_listings L
+		JOIN issued_tickets IT ON L.issued_ticket_id = IT.id
+		WHERE L.status = $1 AND IT.status = $2
+		ORDER BY L.id
+	`
+
+	return r.query(query, domain.ResaleListingStatusListed, domain.IssuedTicketStatusValid)
+}
+
+func (r *ResaleListingRepository) GetByID(listingID int64) (*domain.ResaleListing, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT L.id, L.issued_ticket_id, IT.event_id, IT.ticket_id, L.seller_id, L.buyer_id, L.price, L.fee, L.currency, L.status, L.created_at, L.sold_at
+		FROM resale_listings L
+		JOIN issued_tickets IT ON L.issued_ticket_id = IT.id
+		WHERE L.id = $1
+	`
+
+	listings, err := r.query(query, listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(listings) == 0 {
+		return nil, utils.ErrResaleListingNotFound
+	}
+
+	return listings[0], nil
+}
+
+// The ticket is locked while it is checked, so it can't be checked in, voided
+// or transferred at the same time.
+func (r *ResaleListingRepository) Add(listing *domain.ResaleListing, startsAfter time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = lockTransferableTickets(ctx, tx, []int64{listing.IssuedTicketID}, listing.SellerID, startsAfter)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM ticket_transfer_items TI
+			JOIN ticket_transfers T ON TI.transfer_id = T.id
+			WHERE TI.issued_ticket_id = $1 AND T.status = $2
+		)
+	`
+
+	var pending bool
+	err = tx.QueryRowContext(ctx, query, listing.IssuedTicketID, domain.TicketTransferStatusPending).Scan(&pending)
+	if err != nil {
+		return err
+	}
+
+	if pending {
+		return utils.ErrTicketTransferPending
+	}
+
+	query = `
+		INSERT INTO resale_listings (issued_ticket_id, seller_id, price, fee, currency, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	args := []any{listing.IssuedTicketID, listing.SellerID, listing.Price, listing.Fee, listing.Currency, listing.Status, listing.CreatedAt}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&listing.ID)
+	if err != nil {
+		switch {
+		case err.Error() == `ERROR: duplicate key value violates unique constraint "resale_listings_issued_ticket_id_listed_idx" (SQLSTATE 23505)`:
+			return utils.ErrTicketListedForResale
+		default:
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ResaleListingRepository) Purchase(listing *domain.ResaleListing, buyerID int64, code string, startsAfter time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE resale_listings
+		SET status = $1, buyer_id = $2, sold_at = NOW()
+		WHERE id = $3 AND status = $4
+		RETURNING sold_at
+	`
+	args := []any{domain.ResaleListingStatusSold, buyerID, listing.ID, domain.ResaleListingStatusListed}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var soldAt time.Time
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&soldAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrResaleListingNotAvailable
+		default:
+			return err
+		}
+	}
+
+	// The customers are locked in a fixed order, before the ticket, the same
+	// order an order cancellation takes them in.
+	query = `
+		SELECT id, currency
+		FROM customers
+		WHERE id = ANY($1)
+		ORDER BY id
+		FOR UPDATE
+	`
+
+	rows, err := tx.QueryContext(ctx, query, []int64{buyerID, listing.SellerID})
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	walletCurrencies := make(map[int64]string)
+	for rows.Next() {
+		var (
+			customerID int64
+			currency   string
+		)
+
+		err := rows.Scan(&customerID, &currency)
+		if err != nil {
+			return err
+		}
+
+		walletCurrencies[customerID] = currency
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	buyerRate, err := exchangeRate(ctx, tx, listing.Currency, walletCurrencies[buyerID])
+	if err != nil {
+		return err
+	}
+
+	sellerRate, err := exchangeRate(ctx, tx, listing.Currency, walletCurrencies[listing.SellerID])
+	if err != nil {
+		return err
+	}
+
+	err = lockTransferableTickets(ctx, tx, []int64{listing.IssuedTicketID}, listing.SellerID, startsAfter)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE customers
+		SET balance = balance - $1
+		WHERE id = $2
+	`
+
+	_, err = tx.ExecContext(ctx, query, buyerRate.Convert(listing.Price), buyerID)
+	if err != nil {
+		switch {
+		case err.Error() == `ERROR: new row for relation "customers" violates check constraint "customers_balance_check" (SQLSTATE 23514)`:
+			return utils.ErrInsufficientBalance
+		default:
+			return err
+		}
+	}
+
+	query = `
+		UPDATE customers
+		SET balance = balance + $1
+		WHERE id = $2
+	`
+
+	_, err = tx.ExecContext(ctx, query, sellerRate.Convert(listing.Price-listing.Fee), listing.SellerID)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE issued_tickets
+		SET customer_id = $1, code = $2
+		WHERE id = $3
+	`
+
+	_, err = tx.ExecContext(ctx, query, buyerID, code, listing.IssuedTicketID)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	listing.Status = domain.ResaleListingStatusSold
+	listing.BuyerID = &buyerID
+	listing.SoldAt = &soldAt
+
+	return nil
+}
+
+func (r *ResaleListingRepository) Cancel(listing *domain.ResaleListing) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE resale_listings
+		SET status = $1
+		WHERE id = $2 AND status = $3
+	`
+	args := []any{domain.ResaleListingStatusCancelled, listing.ID, domain.ResaleListingStatusListed}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return utils.ErrResaleListingNotAvailable
+	}
+
+	listing.Status = domain.ResaleListingStatusCancelled
+
+	return nil
+}
+
+func (r *ResaleListingRepository) query(query string, args ...any) ([]*domain.ResaleListing, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	listings := make([]*domain.ResaleListing, 0)
+	for rows.Next() {
+		var listing domain.ResaleListing
+
+		err := rows.Scan(
+			&listing.ID,
+			&listing.IssuedTicketID,
+			&listing.EventID,
+			&listing.TicketID,
+			&listing.SellerID,
+			&listing.BuyerID,
+			&listing.Price,
+			&listing.Fee,
+			&listing.Currency,
+			&listing.Status,
+			&listing.CreatedAt,
+			&listing.SoldAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		listings = append(listings, &listing)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return listings, nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type TaxRateRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewTaxRateRepository(db *sql.DB) ITaxRateRepository {
+	return &TaxRateRepository{
+		db: db,
+	}
+}
+
+func (r *TaxRateRepository) GetAll() ([]*domain.TaxRate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, jurisdiction, name, rate, created_at
+		FROM tax_rates
+		ORDER BY jurisdiction
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	taxRates := make([]*domain.TaxRate, 0)
+	for rows.Next() {
+		var taxRate domain.TaxRate
+
+		err := rows.Scan(
+			&taxRate.ID,
+			&taxRate.Jurisdiction,
+			&taxRate.Name,
+			&taxRate.Rate,
+			&taxRate.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		taxRates = append(taxRates, &taxRate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return taxRates, nil
+}
+
+func (r *TaxRateRepository) Add(taxRate *domain.TaxRate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		INSERT INTO tax_rates (jurisdiction, name, rate)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	args := []any{taxRate.Jurisdiction, taxRate.Name, taxRate.Rate}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(&taxRate.ID, &taxRate.CreatedAt)
+	if err != nil {
+		switch {
+		case err.Error() == `ERROR: duplicate key value violates unique constraint "tax_rates_jurisdiction_key" (SQLSTATE 23505)`:
+			return utils.ErrTaxRateAlreadyExists
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TaxRateRepository) Update(taxRate *domain.TaxRate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE tax_rates
+		SET name = $1, rate = $2
+		WHERE id = $3
+		RETURNING jurisdiction, created_at
+	`
+	args := []any{taxRate.Name, taxRate.Rate, taxRate.ID}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(&taxRate.Jurisdiction, &taxRate.CreatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrTaxRateNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TaxRateRepository) Delete(taxRateID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		DELETE FROM tax_rates
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, query, taxRateID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return utils.ErrTaxRateNotFound
+	}
+
+	return nil
+}
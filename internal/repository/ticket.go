@@ -27,7 +27,7 @@ func (r *TicketRepository) GetAll() ([]*domain.TicketDetail, error) {
 	defer r.mu.Unlock()
 
 	query := `
-		SELECT T.id, T.event_id, T.quantity, TT.id AS type_id, TT.name AS type_name, TT.price AS type_price
+		SELECT T.id, T.event_id, T.quantity, T.sales_start, T.sales_end, T.max_per_customer, TT.id AS type_id, TT.name AS type_name, TT.price AS type_price, TT.currency AS type_currency
 		FROM tickets T
 		JOIN ticket_types TT ON T.ticket_type_id = TT.id
 	`
@@ -55,9 +55,13 @@ func (r *TicketRepository) GetAll() ([]*domain.TicketDetail, error) {
 			&ticketDetail.ID,
 			&ticketDetail.EventID,
 			&ticketDetail.Quantity,
+			&ticketDetail.SalesStart,
+			&ticketDetail.SalesEnd,
+			&ticketDetail.MaxPerCustomer,
 			&ticketDetail.Type.ID,
 			&ticketDetail.Type.Name,
 			&ticketDetail.Type.Price,
+			&ticketDetail.Type.Currency,
 		)
 		if err != nil {
 			return nil, err
@@ -97,6 +101,11 @@ func (r *TicketRepository) Add(ticket *domain.Ticket) error {
 	}
 	defer tx.Rollback()
 
+	err = lockEvent(ctx, tx, ticket.EventID)
+	if err != nil {
+		return err
+	}
+
 	checkStmt, err := tx.PrepareContext(ctx, query)
 	if err != nil {
 		return err
@@ -113,11 +122,11 @@ func (r *TicketRepository) Add(ticket *domain.Ticket) error {
 	}
 
 	query = `
-		INSERT INTO tickets (event_id, ticket_type_id, quantity)
-		VALUES ($1, $2, $3)
+		INSERT INTO tickets (event_id, ticket_type_id, quantity, sales_start, sales_end, max_per_customer)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id
 	`
-	args = []any{ticket.EventID, ticket.TicketTypeID, ticket.Quantity}
+	args = []any{ticket.EventID, ticket.TicketTypeID, ticket.Quantity, ticket.SalesStart, ticket.SalesEnd, ticket.MaxPerCustomer}
 
 	insertStmt, err := tx.PrepareContext(ctx, query)
 	if err != nil {
@@ -130,6 +139,11 @@ func (r *TicketRepository) Add(ticket *domain.Ticket) error {
 		return err
 	}
 
+	err = checkVenueCapacity(ctx, tx, ticket.EventID)
+	if err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return err
 	}
@@ -142,7 +156,7 @@ func (r *TicketRepository) GetByID(ticketID int64) (*domain.TicketDetail, error)
 	defer r.mu.Unlock()
 
 	query := `
-		SELECT T.id, T.event_id, T.quantity, TT.id AS type_id, TT.name AS type_name, TT.price AS type_price
+		SELECT T.id, T.event_id, T.quantity, T.sales_start, T.sales_end, T.max_per_customer, TT.id AS type_id, TT.name AS type_name, TT.price AS type_price, TT.currency AS type_currency
 		FROM tickets T
 		JOIN ticket_types TT ON T.ticket_type_id = TT.id 
 		WHERE T.id = $1
@@ -163,9 +177,13 @@ func (r *TicketRepository) GetByID(ticketID int64) (*domain.TicketDetail, error)
 		&ticketDetail.ID,
 		&ticketDetail.EventID,
 		&ticketDetail.Quantity,
+		&ticketDetail.SalesStart,
+		&ticketDetail.SalesEnd,
+		&ticketDetail.MaxPerCustomer,
 		&ticketDetail.Type.ID,
 		&ticketDetail.Type.Name,
 		&ticketDetail.Type.Price,
+		&ticketDetail.Type.Currency,
 	)
 
 	if err != nil {
@@ -185,7 +203,7 @@ func (r *TicketRepository) GetByEventID(eventID int64) ([]*domain.TicketDetail,
 	defer r.mu.Unlock()
 
 	query := `
-		SELECT T.id, T.event_id, T.quantity, TT.id AS type_id, TT.name AS type_name, TT.price AS type_price
+		SELECT T.id, T.event_id, T.quantity, T.sales_start, T.sales_end, T.max_per_customer, TT.id AS type_id, TT.name AS type_name, TT.price AS type_price, TT.currency AS type_currency
 		FROM tickets T
 		JOIN ticket_types TT ON T.ticket_type_id = TT.id 
 		WHERE T.event_id = $1
@@ -214,9 +232,13 @@ func (r *TicketRepository) GetByEventID(eventID int64) ([]*domain.TicketDetail,
 			&ticketDetail.ID,
 			&ticketDetail.EventID,
 			&ticketDetail.Quantity,
+			&ticketDetail.SalesStart,
+			&ticketDetail.SalesEnd,
+			&ticketDetail.MaxPerCustomer,
 			&ticketDetail.Type.ID,
 			&ticketDetail.Type.Name,
 			&ticketDetail.Type.Price,
+			&ticketDetail.Type.Currency,
 		)
 		if err != nil {
 			return nil, err
@@ -232,15 +254,87 @@ func (r *TicketRepository) GetByEventID(eventID int64) ([]*domain.TicketDetail,
 	return ticketDetails, nil
 }
 
+// The event is locked first, so the new quantity can be checked against the
+// venue capacity.
 func (r *TicketRepository) AddQuantity(ticketID int64, quantity int) (*domain.Ticket, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	query := `
+		SELECT event_id
+		FROM tickets
+		WHERE id = $1
+	`
+
+	var eventID int64
+
+	err = tx.QueryRowContext(ctx, query, ticketID).Scan(&eventID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrTicketNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	err = lockEvent(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
 		UPDATE tickets
 		SET quantity = quantity + $1
 		WHERE id = $2
-		RETURNING id, event_id, ticket_type_id, quantity
+		RETURNING id, event_id, ticket_type_id, quantity, sales_start, sales_end, max_per_customer
+	`
+
+	var ticket domain.Ticket
+
+	err = tx.QueryRowContext(ctx, query, quantity, ticketID).Scan(
+		&ticket.ID,
+		&ticket.EventID,
+		&ticket.TicketTypeID,
+		&ticket.Quantity,
+		&ticket.SalesStart,
+		&ticket.SalesEnd,
+		&ticket.MaxPerCustomer,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkVenueCapacity(ctx, tx, ticket.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
+func (r *TicketRepository) DeductQuantity(ticketID int64, quantity int) (*domain.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE tickets
+		SET quantity = quantity - $1
+		WHERE id = $2
+		RETURNING id, event_id, ticket_type_id, quantity, sales_start, sales_end, max_per_customer
 	`
 
 	var ticket domain.Ticket
@@ -259,25 +353,79 @@ func (r *TicketRepository) AddQuantity(ticketID int64, quantity int) (*domain.Ti
 		&ticket.EventID,
 		&ticket.TicketTypeID,
 		&ticket.Quantity,
+		&ticket.SalesStart,
+		&ticket.SalesEnd,
+		&ticket.MaxPerCustomer,
 	)
 
+	if err != nil {
+		switch {
+		case err.Error() == `ERROR: new row for relation "tickets" violates check constraint "tickets_quantity_check" (SQLSTATE 23514)`:
+			return nil, utils.ErrInsufficientTicketQuantity
+		default:
+			return nil, err
+		}
+	}
+
+	return &ticket, nil
+}
+
+func (r *TicketRepository) UpdateSalesWindow(ticketID int64, salesStart, salesEnd *time.Time) (*domain.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE tickets
+		SET sales_start = $1, sales_end = $2
+		WHERE id = $3
+		RETURNING id, event_id, ticket_type_id, quantity, sales_start, sales_end, max_per_customer
+	`
+	args := []any{salesStart, salesEnd, ticketID}
+
+	var ticket domain.Ticket
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(
+		&ticket.ID,
+		&ticket.EventID,
+		&ticket.TicketTypeID,
+		&ticket.Quantity,
+		&ticket.SalesStart,
+		&ticket.SalesEnd,
+		&ticket.MaxPerCustomer,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrTicketNotFound
+		default:
+			return nil, err
+		}
+	}
 
 	return &ticket, nil
 }
 
-func (r *TicketRepository) DeductQuantity(ticketID int64, quantity int) (*domain.Ticket, error) {
+func (r *TicketRepository) UpdatePurchaseLimit(ticketID int64, maxPerCustomer *int) (*domain.Ticket, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	query := `
 		UPDATE tickets
-		SET quantity = quantity - $1
+		SET max_per_customer = $1
 		WHERE id = $2
-		RETURNING id, event_id, ticket_type_id, quantity
+		RETURNING id, event_id, ticket_type_id, quantity, sales_start, sales_end, max_per_customer
 	`
+	args := []any{maxPerCustomer, ticketID}
 
 	var ticket domain.Ticket
 
@@ -290,21 +438,186 @@ func (r *TicketRepository) DeductQuantity(ticketID int64, quantity int) (*domain
 	}
 	defer stmt.Close()
 
-	err = stmt.QueryRowContext(ctx, quantity, ticketID).Scan(
+	err = stmt.QueryRowContext(ctx, args...).Scan(
 		&ticket.ID,
 		&ticket.EventID,
 		&ticket.TicketTypeID,
 		&ticket.Quantity,
+		&ticket.SalesStart,
+		&ticket.SalesEnd,
+		&ticket.MaxPerCustomer,
 	)
 
 	if err != nil {
 		switch {
-		case err.Error() == `ERROR: new row for relation "tickets" violates check constraint "tickets_quantity_check" (SQLSTATE 23514)`:
-			return nil, utils.ErrInsufficientTicketQuantity
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrTicketNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &ticket, nil
+}
+
+// The event is locked so two tickets of the event can't be given the same seat
+// at the same time.
+func (r *TicketRepository) AssignSeats(ticketID int64, seatIDs []int64) (*domain.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT E.id, E.venue_id
+		FROM events E
+		JOIN tickets T ON T.event_id = E.id
+		WHERE T.id = $1
+		FOR UPDATE OF E
+	`
+
+	var (
+		eventID int64
+		venueID *int64
+	)
+
+	err = tx.QueryRowContext(ctx, query, ticketID).Scan(&eventID, &venueID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrTicketNotFound
 		default:
 			return nil, err
 		}
 	}
 
+	if venueID == nil {
+		return nil, utils.ErrEventWithoutVenue
+	}
+
+	query = `
+		SELECT id
+		FROM tickets
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	_, err = tx.ExecContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM issued_tickets
+			WHERE ticket_id = $1
+		)
+	`
+
+	var sold bool
+	err = tx.QueryRowContext(ctx, query, ticketID).Scan(&sold)
+	if err != nil {
+		return nil, err
+	}
+
+	if sold {
+		return nil, utils.ErrTicketSeatsLocked
+	}
+
+	query = `
+		SELECT COUNT(*)
+		FROM venue_seats S
+		JOIN venue_sections SS ON S.section_id = SS.id
+		WHERE S.id = ANY($1) AND SS.venue_id = $2
+	`
+
+	var found int
+	err = tx.QueryRowContext(ctx, query, seatIDs, *venueID).Scan(&found)
+	if err != nil {
+		return nil, err
+	}
+
+	if found != len(seatIDs) {
+		return nil, utils.ErrSeatNotFound
+	}
+
+	query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM ticket_seats TS
+			JOIN tickets T ON TS.ticket_id = T.id
+			WHERE T.event_id = $1 AND T.id <> $2 AND TS.seat_id = ANY($3)
+		)
+	`
+
+	var assigned bool
+	err = tx.QueryRowContext(ctx, query, eventID, ticketID, seatIDs).Scan(&assigned)
+	if err != nil {
+		return nil, err
+	}
+
+	if assigned {
+		return nil, utils.ErrSeatAlreadyAssigned
+	}
+
+	query = `
+		DELETE FROM ticket_seats
+		WHERE ticket_id = $1
+	`
+
+	_, err = tx.ExecContext(ctx, query, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+		INSERT INTO ticket_seats (ticket_id, seat_id)
+		SELECT $1, UNNEST($2::BIGINT[])
+	`
+
+	_, err = tx.ExecContext(ctx, query, ticketID, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+		UPDATE tickets
+		SET quantity = $1
+		WHERE id = $2
+		RETURNING id, event_id, ticket_type_id, quantity, sales_start, sales_end, max_per_customer
+	`
+
+	var ticket domain.Ticket
+
+	err = tx.QueryRowContext(ctx, query, len(seatIDs), ticketID).Scan(
+		&ticket.ID,
+		&ticket.EventID,
+		&ticket.TicketTypeID,
+		&ticket.Quantity,
+		&ticket.SalesStart,
+		&ticket.SalesEnd,
+		&ticket.MaxPerCustomer,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkVenueCapacity(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return &ticket, nil
 }
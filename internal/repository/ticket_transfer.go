@@ -0,0 +1,397 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type TicketTransferRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewTicketTransferRepository(db *sql.DB) ITicketTransferRepository {
+	return &TicketTransferRepository{
+		db: db,
+	}
+}
+
+func (r *TicketTransferRepository) GetByID(transferID int64) (*domain.TicketTransfer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT T.id, T.sender_id, T.recipient_id, T.status, T.created_at, T.responded_at,
+			ARRAY(SELECT TI.issued_ticket_id FROM ticket_transfer_items TI WHERE TI.transfer_id = T.id ORDER BY TI.issued_ticket_id)
+		FROM ticket_transfers T
+		WHERE T.id = $1
+	`
+
+	transfers, err := r.query(query, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transfers) == 0 {
+		return nil, utils.ErrTicketTransferNotFound
+	}
+
+	return transfers[0], nil
+}
+
+func (r *TicketTransferRepository) GetByCustomerID(customerID int64) ([]*domain.TicketTransfer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT T.id, T.sender_id, T.recipient_id, T.status, T.created_at, T.responded_at,
+			ARRAY(SELECT TI.issued_ticket_id FROM ticket_transfer_items TI WHERE TI.transfer_id = T.id ORDER BY TI.issued_ticket_id)
+		FROM ticket_transfers T
+		WHERE T.sender_id = $1 OR T.recipient_id = $1
+		ORDER BY T.id DESC
+	`
+
+	return r.query(query, customerID)
+}
+
+// The issued tickets are locked while they are checked, so a ticket can't be
+// checked in, voided, resold or transferred twice at the same time.
+func (r *TicketTransferRepository) Add(transfer *domain.TicketTransfer, startsAfter time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = lockTransferableTickets(ctx, tx, transfer.IssuedTicketIDs, transfer.SenderID, startsAfter)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM ticket_transfer_items TI
+			JOIN ticket_transfers T ON TI.transfer_id = T.id
+			WHERE TI.issued_ticket_id = ANY($1) AND T.status = $2
+		)
+	`
+
+	var pending bool
+	err = tx.QueryRowContext(ctx, query, transfer.IssuedTicketIDs, domain.TicketTransferStatusPending).Scan(&pending)
+	if err != nil {
+		return err
+	}
+
+	if pending {
+		return utils.ErrTicketTransferPending
+	}
+
+	query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM resale_listings
+			WHERE issued_ticket_id = ANY($1) AND status = $2
+		)
+	`
+
+	var listed bool
+	err = tx.QueryRowContext(ctx, query, transfer.IssuedTicketIDs, domain.ResaleListingStatusListed).Scan(&listed)
+	if err != nil {
+		return err
+	}
+
+	if listed {
+		return utils.ErrTicketListedForResale
+	}
+
+	query = `
+		INSERT INTO ticket_transfers (sender_id, recipient_id, status, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+	args := []any{transfer.SenderID, transfer.RecipientID, transfer.Status, transfer.CreatedAt}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&transfer.ID)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO ticket_transfer_items (transfer_id, issued_ticket_id)
+		VALUES ($1, $2)
+	`
+
+	itemStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer itemStmt.Close()
+
+	for _, issuedTicketID := range transfer.IssuedTicketIDs {
+		_, err = itemStmt.ExecContext(ctx, transfer.ID, issuedTicketID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Each ticket gets a new code, so the code the sender saw can't be used.
+func (r *TicketTransferRepository) Accept(transfer *domain.TicketTransfer, codes map[int64]string, startsAfter time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE ticket_transfers
+		SET status = $1, responded_at = NOW()
+		WHERE id = $2 AND status = $3
+		RETURNING responded_at
+	`
+	args := []any{domain.TicketTransferStatusAccepted, transfer.ID, domain.TicketTransferStatusPending}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var respondedAt time.Time
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&respondedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrTicketTransferNotPending
+		default:
+			return err
+		}
+	}
+
+	err = lockTransferableTickets(ctx, tx, transfer.IssuedTicketIDs, transfer.SenderID, startsAfter)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE issued_tickets
+		SET customer_id = $1, code = $2
+		WHERE id = $3
+	`
+
+	ticketStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer ticketStmt.Close()
+
+	for _, issuedTicketID := range transfer.IssuedTicketIDs {
+		_, err = ticketStmt.ExecContext(ctx, transfer.RecipientID, codes[issuedTicketID], issuedTicketID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	transfer.Status = domain.TicketTransferStatusAccepted
+	transfer.RespondedAt = &respondedAt
+
+	return nil
+}
+
+func (r *TicketTransferRepository) Close(transfer *domain.TicketTransfer, status domain.TicketTransferStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE ticket_transfers
+		SET status = $1, responded_at = NOW()
+		WHERE id = $2 AND status = $3
+		RETURNING responded_at
+	`
+	args := []any{status, transfer.ID, domain.TicketTransferStatusPending}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	var respondedAt time.Time
+	err = stmt.QueryRowContext(ctx, args...).Scan(&respondedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrTicketTransferNotPending
+		default:
+			return err
+		}
+	}
+
+	transfer.Status = status
+	transfer.RespondedAt = &respondedAt
+
+	return nil
+}
+
+func (r *TicketTransferRepository) query(query string, args ...any) ([]*domain.TicketTransfer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	typeMap := pgtype.NewMap()
+
+	transfers := make([]*domain.TicketTransfer, 0)
+	for rows.Next() {
+		var transfer domain.TicketTransfer
+
+		err := rows.Scan(
+			&transfer.ID,
+			&transfer.SenderID,
+			&transfer.RecipientID,
+			&transfer.Status,
+			&transfer.CreatedAt,
+			&transfer.RespondedAt,
+			typeMap.SQLScanner(&transfer.IssuedTicketIDs),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		transfers = append(transfers, &transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// Their events are locked too, so a schedule change can't slip past the
+// transfer cutoff.
+func lockTransferableTickets(ctx context.Context, tx *sql.Tx, issuedTicketIDs []int64, ownerID int64, startsAfter time.Time) error {
+	query := `
+		SELECT id, customer_id, status, checked_in_at
+		FROM issued_tickets
+		WHERE id = ANY($1)
+		ORDER BY id
+		FOR UPDATE
+	`
+
+	rows, err := tx.QueryContext(ctx, query, issuedTicketIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	locked := 0
+	for rows.Next() {
+		var (
+			id          int64
+			customerID  int64
+			status      domain.IssuedTicketStatus
+			checkedInAt *time.Time
+		)
+
+		err := rows.Scan(&id, &customerID, &status, &checkedInAt)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case customerID != ownerID:
+			return utils.ErrTicketNotTransferable
+		case status != domain.IssuedTicketStatusValid:
+			return utils.ErrIssuedTicketVoid
+		case checkedInAt != nil:
+			return utils.ErrTicketNotTransferable
+		}
+
+		locked++
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if locked != len(issuedTicketIDs) {
+		return utils.ErrIssuedTicketNotFound
+	}
+
+	query = `
+		SELECT status, starts_at
+		FROM events
+		WHERE id IN (
+			SELECT event_id
+			FROM issued_tickets
+			WHERE id = ANY($1)
+		)
+		ORDER BY id
+		FOR SHARE
+	`
+
+	eventRows, err := tx.QueryContext(ctx, query, issuedTicketIDs)
+	if err != nil {
+		return err
+	}
+	defer eventRows.Close()
+
+	for eventRows.Next() {
+		var (
+			status   domain.EventStatus
+			startsAt time.Time
+		)
+
+		err := eventRows.Scan(&status, &startsAt)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case status == domain.EventStatusCancelled:
+			return utils.ErrEventCancelled
+		case startsAt.Before(startsAfter):
+			return utils.ErrTicketTransferClosed
+		}
+	}
+
+	return eventRows.Err()
+}
@@ -25,7 +25,7 @@ func (r *TicketTypeRepository) GetAll() ([]*domain.TicketType, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	query := "SELECT id, name, price FROM ticket_types"
+	query := "SELECT id, name, price, currency FROM ticket_types"
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -46,7 +46,7 @@ func (r *TicketTypeRepository) GetAll() ([]*domain.TicketType, error) {
 	for rows.Next() {
 		var ticketType domain.TicketType
 
-		err = rows.Scan(&ticketType.ID, &ticketType.Name, &ticketType.Price)
+		err = rows.Scan(&ticketType.ID, &ticketType.Name, &ticketType.Price, &ticketType.Currency)
 		if err != nil {
 			return nil, err
 		}
@@ -66,11 +66,11 @@ func (r *TicketTypeRepository) Add(ticketType *domain.TicketType) error {
 	defer r.mu.Unlock()
 
 	query := `
-		INSERT INTO ticket_types (name, price)
-		VALUES ($1, $2)
+		INSERT INTO ticket_types (name, price, currency)
+		VALUES ($1, $2, $3)
 		RETURNING id
 	`
-	args := []any{ticketType.Name, ticketType.Price}
+	args := []any{ticketType.Name, ticketType.Price, ticketType.Currency}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -99,7 +99,7 @@ func (r *TicketTypeRepository) GetByName(ticketTypeName domain.TicketTypeName) (
 	defer r.mu.Unlock()
 
 	query := `
-		SELECT id, name, price
+		SELECT id, name, price, currency
 		FROM ticket_types
 		WHERE name = $1
 	`
@@ -119,6 +119,7 @@ func (r *TicketTypeRepository) GetByName(ticketTypeName domain.TicketTypeName) (
 		&ticketType.ID,
 		&ticketType.Name,
 		&ticketType.Price,
+		&ticketType.Currency,
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type TopUpRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewTopUpRepository(db *sql.DB) ITopUpRepository {
+	return &TopUpRepository{
+		db: db,
+	}
+}
+
+func (r *TopUpRepository) GetByCustomerID(customerID int64) ([]*domain.TopUp, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, customer_id, amount, currency, provider, intent_id, status, created_at, confirmed_at, refunded_at
+		FROM top_ups
+		WHERE customer_id = $1
+		ORDER BY id DESC
+	`
+
+	return r.query(query, customerID)
+}
+
+func (r *TopUpRepository) GetByID(topUpID int64) (*domain.TopUp, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, customer_id, amount, currency, provider, intent_id, status, created_at, confirmed_at, refunded_at
+		FROM top_ups
+		WHERE id = $1
+	`
+
+	topUps, err := r.query(query, topUpID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(topUps) == 0 {
+		return nil, utils.ErrTopUpNotFound
+	}
+
+	return topUps[0], nil
+}
+
+func (r *TopUpRepository) GetByIntentID(provider string, intentID string) (*domain.TopUp, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, customer_id, amount, currency, provider, intent_id, status, created_at, confirmed_at, refunded_at
+		FROM top_ups
+		WHERE provider = $1 AND intent_id = $2
+	`
+
+	topUps, err := r.query(query, provider, intentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(topUps) == 0 {
+		return nil, utils.ErrTopUpNotFound
+	}
+
+	return topUps[0], nil
+}
+
+func (r *TopUpRepository) Add(topUp *domain.TopUp) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		INSERT INTO top_ups (customer_id, amount, currency, provider, intent_id, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	args := []any{
+		topUp.CustomerID,
+		topUp.Amount,
+		topUp.Currency,
+		topUp.Provider,
+		topUp.IntentID,
+		topUp.Status,
+		topUp.CreatedAt,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(&topUp.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TopUpRepository) Confirm(topUp *domain.TopUp) error {
+	query := `
+		UPDATE top_ups
+		SET status = $1, confirmed_at = NOW()
+		WHERE id = $2 AND status = $3
+		RETURNING status, confirmed_at, refunded_at
+	`
+	args := []any{domain.TopUpStatusSucceeded, topUp.ID, domain.TopUpStatusPending}
+
+	return r.transition(topUp, query, args, topUp.Amount, utils.ErrTopUpNotPending)
+}
+
+func (r *TopUpRepository) Fail(topUp *domain.TopUp) error {
+	query := `
+		UPDATE top_ups
+		SET status = $1
+		WHERE id = $2 AND status = $3
+		RETURNING status, confirmed_at, refunded_at
+	`
+	args := []any{domain.TopUpStatusFailed, topUp.ID, domain.TopUpStatusPending}
+
+	return r.transition(topUp, query, args, 0, utils.ErrTopUpNotPending)
+}
+
+// Refund records what the provider owes back in the same transaction as the
+// debit, to be sent by the refund processor.
+func (r *TopUpRepository) Refund(topUp *domain.TopUp) error {
+	query := `
+		WITH refunded AS (
+			UPDATE top_ups
+			SET status = $1, refunded_at = NOW()
+			WHERE id = $2 AND status = $3
+			RETURNING id, provider, intent_id, amount, currency, status, confirmed_at, refunded_at
+		), refund AS (
+			INSERT INTO payment_refunds (top_up_id, provider, intent_id, amount, currency, status, created_at)
+			SELECT id, provider, intent_id, amount, currency, $4, refunded_at
+			FROM refunded
+		)
+		SELECT status, confirmed_at, refunded_at
+		FROM refunded
+	`
+	args := []any{domain.TopUpStatusRefunded, topUp.ID, domain.TopUpStatusSucceeded, domain.PaymentRefundStatusPending}
+
+	return r.transition(topUp, query, args, -topUp.Amount, utils.ErrTopUpNotRefundable)
+}
+
+// The query only matches the top-up in the status it moves from, so a top-up is
+// never credited or refunded twice.
+func (r *TopUpRepository) transition(topUp *domain.TopUp, query string, args []any, balanceChange float64, errNoMatch error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&topUp.Status, &topUp.ConfirmedAt, &topUp.RefundedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return errNoMatch
+		default:
+			return err
+		}
+	}
+
+	if balanceChange != 0 {
+		query = `
+			UPDATE customers
+			SET balance = balance + $1
+			WHERE id = $2
+		`
+
+		_, err = tx.ExecContext(ctx, query, balanceChange, topUp.CustomerID)
+		if err != nil {
+			switch {
+			case err.Error() == `ERROR: new row for relation "customers" violates check constraint "customers_balance_check" (SQLSTATE 23514)`:
+				return utils.ErrInsufficientBalance
+			default:
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TopUpRepository) query(query string, args ...any) ([]*domain.TopUp, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	topUps := make([]*domain.TopUp, 0)
+	for rows.Next() {
+		var topUp domain.TopUp
+
+		err := rows.Scan(
+			&topUp.ID,
+			&topUp.CustomerID,
+			&topUp.Amount,
+			&topUp.Currency,
+			&topUp.Provider,
+			&topUp.IntentID,
+			&topUp.Status,
+			&topUp.CreatedAt,
+			&topUp.ConfirmedAt,
+			&topUp.RefundedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		topUps = append(topUps, &topUp)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return topUps, nil
+}
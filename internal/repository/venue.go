@@ -0,0 +1,566 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type VenueRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewVenueRepository(db *sql.DB) IVenueRepository {
+	return &VenueRepository{
+		db: db,
+	}
+}
+
+func (r *VenueRepository) GetAll() ([]*domain.Venue, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, name, address, timezone, capacity, latitude, longitude, tax_jurisdiction
+		FROM venues
+		ORDER BY id
+	`
+
+	return r.query(query)
+}
+
+func (r *VenueRepository) GetByID(venueID int64) (*domain.Venue, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, name, address, timezone, capacity, latitude, longitude, tax_jurisdiction
+		FROM venues
+		WHERE id = $1
+	`
+
+	venues, err := r.query(query, venueID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(venues) == 0 {
+		return nil, utils.ErrVenueNotFound
+	}
+
+	return venues[0], nil
+}
+
+func (r *VenueRepository) GetSections(venueID int64) ([]*domain.VenueSection, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT SS.id, SS.name, S.id, S.row_label, S.number
+		FROM venue_sections SS
+		LEFT JOIN venue_seats S ON S.section_id = SS.id
+		WHERE SS.venue_id = $1
+		ORDER BY SS.position, S.row_position, S.number
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sections := make([]*domain.VenueSection, 0)
+
+	var section *domain.VenueSection
+	for rows.Next() {
+		var (
+			sectionID   int64
+			sectionName string
+			seatID      *int64
+			row         *string
+			number      *int
+		)
+
+		err := rows.Scan(&sectionID, &sectionName, &seatID, &row, &number)
+		if err != nil {
+			return nil, err
+		}
+
+		if section == nil || section.ID != sectionID {
+			section = &domain.VenueSection{
+				ID:      sectionID,
+				VenueID: venueID,
+				Name:    sectionName,
+				Seats:   make([]*domain.Seat, 0),
+			}
+			sections = append(sections, section)
+		}
+
+		if seatID == nil {
+			continue
+		}
+
+		section.Seats = append(section.Seats, &domain.Seat{
+			ID:        *seatID,
+			SectionID: sectionID,
+			Section:   sectionName,
+			Row:       *row,
+			Number:    *number,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
+
+func (r *VenueRepository) GetSeatsByIDs(seatIDs []int64) ([]*domain.Seat, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT S.id, S.section_id, SS.name, S.row_label, S.number
+		FROM venue_seats S
+		JOIN venue_sections SS ON S.section_id = SS.id
+		WHERE S.id = ANY($1)
+		ORDER BY SS.position, S.row_position, S.number
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seats := make([]*domain.Seat, 0)
+	for rows.Next() {
+		var seat domain.Seat
+
+		err := rows.Scan(&seat.ID, &seat.SectionID, &seat.Section, &seat.Row, &seat.Number)
+		if err != nil {
+			return nil, err
+		}
+
+		seats = append(seats, &seat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return seats, nil
+}
+
+func (r *VenueRepository) GetSeatsByEventID(eventID int64) ([]*domain.EventSeat, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT S.id, S.section_id, SS.name, S.row_label, S.number, TS.ticket_id,
+			TS.ticket_id IS NOT NULL AND NOT EXISTS (
+				SELECT 1
+				FROM issued_tickets IT
+				WHERE IT.event_id = E.id AND IT.seat_id = S.id AND IT.status IN ($2, $3)
+			)
+		FROM events E
+		JOIN venue_sections SS ON SS.venue_id = E.venue_id
+		JOIN venue_seats S ON S.section_id = SS.id
+		LEFT JOIN ticket_seats TS ON TS.seat_id = S.id AND TS.ticket_id IN (SELECT id FROM tickets WHERE event_id = E.id)
+		WHERE E.id = $1
+		ORDER BY SS.position, S.row_position, S.number
+	`
+	args := []any{eventID, domain.IssuedTicketStatusValid, domain.IssuedTicketStatusPending}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seats := make([]*domain.EventSeat, 0)
+	for rows.Next() {
+		var seat domain.EventSeat
+
+		err := rows.Scan(
+			&seat.ID,
+			&seat.SectionID,
+			&seat.Section,
+			&seat.Row,
+			&seat.Number,
+			&seat.TicketID,
+			&seat.Available,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		seats = append(seats, &seat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return seats, nil
+}
+
+func (r *VenueRepository) Add(venue *domain.Venue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO venues (name, address, timezone, capacity, latitude, longitude, tax_jurisdiction)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	args := []any{venue.Name, venue.Address, venue.Timezone, venue.Capacity, venue.Latitude, venue.Longitude, venue.TaxJurisdiction}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&venue.ID)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO venue_sections (venue_id, name, position)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	sectionStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer sectionStmt.Close()
+
+	query = `
+		INSERT INTO venue_seats (section_id, row_label, row_position, number)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	seatStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer seatStmt.Close()
+
+	for i, section := range venue.Sections {
+		section.VenueID = venue.ID
+
+		err = sectionStmt.QueryRowContext(ctx, section.VenueID, section.Name, i).Scan(&section.ID)
+		if err != nil {
+			return err
+		}
+
+		rowPositions := make(map[string]int)
+		for _, seat := range section.Seats {
+			rowPosition, ok := rowPositions[seat.Row]
+			if !ok {
+				rowPosition = len(rowPositions)
+				rowPositions[seat.Row] = rowPosition
+			}
+
+			seat.SectionID = section.ID
+			seat.Section = section.Name
+			args := []any{seat.SectionID, seat.Row, rowPosition, seat.Number}
+
+			err = seatStmt.QueryRowContext(ctx, args...).Scan(&seat.ID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *VenueRepository) Update(venue *domain.Venue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The events at the venue are locked first, the same order tickets are
+	// added to an event in.
+	query := `
+		SELECT id
+		FROM events
+		WHERE venue_id = $1
+		ORDER BY id
+		FOR UPDATE
+	`
+
+	rows, err := tx.QueryContext(ctx, query, venue.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	eventIDs := make([]int64, 0)
+	for rows.Next() {
+		var eventID int64
+
+		err := rows.Scan(&eventID)
+		if err != nil {
+			return err
+		}
+
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	query = `
+		UPDATE venues
+		SET name = $1, address = $2, timezone = $3, capacity = $4, latitude = $5, longitude = $6, tax_jurisdiction = $7
+		WHERE id = $8
+	`
+	args := []any{
+		venue.Name,
+		venue.Address,
+		venue.Timezone,
+		venue.Capacity,
+		venue.Latitude,
+		venue.Longitude,
+		venue.TaxJurisdiction,
+		venue.ID,
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return utils.ErrVenueNotFound
+	}
+
+	query = `
+		SELECT COUNT(*)
+		FROM venue_seats S
+		JOIN venue_sections SS ON S.section_id = SS.id
+		WHERE SS.venue_id = $1
+	`
+
+	var seats int
+	err = tx.QueryRowContext(ctx, query, venue.ID).Scan(&seats)
+	if err != nil {
+		return err
+	}
+
+	if seats > venue.Capacity {
+		return fmt.Errorf("%w: the venue has %d seats", utils.ErrVenueCapacityExceeded, seats)
+	}
+
+	for _, eventID := range eventIDs {
+		err = checkVenueCapacity(ctx, tx, eventID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *VenueRepository) Delete(venueID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		DELETE FROM venues
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, venueID)
+	if err != nil {
+		switch {
+		case err.Error() == `ERROR: update or delete on table "venues" violates foreign key constraint "events_fk_venue_id_venues_id" on table "events" (SQLSTATE 23503)`:
+			return utils.ErrVenueInUse
+		default:
+			return err
+		}
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return utils.ErrVenueNotFound
+	}
+
+	return nil
+}
+
+func (r *VenueRepository) query(query string, args ...any) ([]*domain.Venue, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	venues := make([]*domain.Venue, 0)
+	for rows.Next() {
+		var venue domain.Venue
+
+		err := rows.Scan(
+			&venue.ID,
+			&venue.Name,
+			&venue.Address,
+			&venue.Timezone,
+			&venue.Capacity,
+			&venue.Latitude,
+			&venue.Longitude,
+			&venue.TaxJurisdiction,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		venues = append(venues, &venue)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return venues, nil
+}
+
+func lockEvent(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	query := `
+		SELECT id
+		FROM events
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var id int64
+	err := tx.QueryRowContext(ctx, query, eventID).Scan(&id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.ErrEventNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkVenueCapacity(ctx context.Context, tx *sql.Tx, eventID int64) error {
+	query := `
+		SELECT V.capacity,
+			(SELECT COALESCE(SUM(T.quantity), 0) FROM tickets T WHERE T.event_id = E.id) +
+			(SELECT COUNT(*) FROM issued_tickets IT WHERE IT.event_id = E.id AND IT.status IN ($2, $3))
+		FROM events E
+		JOIN venues V ON E.venue_id = V.id
+		WHERE E.id = $1
+	`
+
+	var capacity, allocated int
+
+	err := tx.QueryRowContext(ctx, query, eventID, domain.IssuedTicketStatusValid, domain.IssuedTicketStatusPending).Scan(&capacity, &allocated)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil
+		default:
+			return err
+		}
+	}
+
+	if allocated > capacity {
+		return fmt.Errorf("%w: event %d would have %d tickets for a capacity of %d", utils.ErrVenueCapacityExceeded, eventID, allocated, capacity)
+	}
+
+	return nil
+}
@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type WaitlistRepository struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func NewWaitlistRepository(db *sql.DB) IWaitlistRepository {
+	return &WaitlistRepository{
+		db: db,
+	}
+}
+
+func (r *WaitlistRepository) GetByCustomerID(customerID int64) ([]*domain.WaitlistEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT id, ticket_id, customer_id, quantity, status, created_at, offered_at, offer_expires_at
+		FROM waitlist_entries
+		WHERE customer_id = $1
+		ORDER BY id DESC
+	`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.WaitlistEntry, 0)
+	for rows.Next() {
+		var entry domain.WaitlistEntry
+
+		err := rows.Scan(
+			&entry.ID,
+			&entry.TicketID,
+			&entry.CustomerID,
+			&entry.Quantity,
+			&entry.Status,
+			&entry.CreatedAt,
+			&entry.OfferedAt,
+			&entry.OfferExpiresAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *WaitlistRepository) GetPendingTicketIDs(at time.Time) ([]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		SELECT DISTINCT W.ticket_id
+		FROM waitlist_entries W
+		JOIN tickets T ON W.ticket_id = T.id
+		JOIN events E ON T.event_id = E.id
+		WHERE E.status <> $1 AND E.starts_at > $4 AND (W.status = $2 OR (W.status = $3 AND W.offer_expires_at <= $4))
+		ORDER BY W.ticket_id
+	`
+	args := []any{
+		domain.EventStatusCancelled,
+		domain.WaitlistEntryStatusWaiting,
+		domain.WaitlistEntryStatusOffered,
+		at,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ticketIDs := make([]int64, 0)
+	for rows.Next() {
+		var ticketID int64
+
+		err := rows.Scan(&ticketID)
+		if err != nil {
+			return nil, err
+		}
+
+		ticketIDs = append(ticketIDs, ticketID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ticketIDs, nil
+}
+
+func (r *WaitlistRepository) Add(entry *domain.WaitlistEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	available, err := lockAvailableQuantity(ctx, tx, entry.TicketID, entry.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM waitlist_entries
+			WHERE ticket_id = $1 AND status = $2
+		)
+	`
+
+	var waiting bool
+	err = tx.QueryRowContext(ctx, query, entry.TicketID, domain.WaitlistEntryStatusWaiting).Scan(&waiting)
+	if err != nil {
+		return err
+	}
+
+	if available >= entry.Quantity && !waiting {
+		return utils.ErrTicketAvailable
+	}
+
+	query = `
+		INSERT INTO waitlist_entries (ticket_id, customer_id, quantity, status, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	args := []any{entry.TicketID, entry.CustomerID, entry.Quantity, entry.Status, entry.CreatedAt}
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&entry.ID)
+	if err != nil {
+		switch {
+		case err.Error() == `ERROR: duplicate key value violates unique constraint "waitlist_entries_ticket_id_customer_id_active_idx" (SQLSTATE 23505)`:
+			return utils.ErrAlreadyWaitlisted
+		default:
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *WaitlistRepository) Cancel(ticketID int64, customerID int64) (*domain.WaitlistEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	query := `
+		UPDATE waitlist_entries
+		SET status = $1
+		WHERE ticket_id = $2 AND customer_id = $3 AND status IN ($4, $5)
+		RETURNING id, ticket_id, customer_id, quantity, status, created_at, offered_at, offer_expires_at
+	`
+	args := []any{
+		domain.WaitlistEntryStatusCancelled,
+		ticketID,
+		customerID,
+		domain.WaitlistEntryStatusWaiting,
+		domain.WaitlistEntryStatusOffered,
+	}
+
+	var entry domain.WaitlistEntry
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	err = stmt.QueryRowContext(ctx, args...).Scan(
+		&entry.ID,
+		&entry.TicketID,
+		&entry.CustomerID,
+		&entry.Quantity,
+		&entry.Status,
+		&entry.CreatedAt,
+		&entry.OfferedAt,
+		&entry.OfferExpiresAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, utils.ErrWaitlistEntryNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &entry, nil
+}
+
+func (r *WaitlistRepository) Offer(ticketID int64, at time.Time, claimWindow time.Duration) ([]*domain.WaitlistEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// The ticket is locked first, the same order an order placement takes the
+	// ticket and waitlist rows in.
+	available, err := lockAvailableQuantity(ctx, tx, ticketID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE waitlist_entries
+		SET status = $1
+		WHERE ticket_id = $2 AND status = $3 AND offer_expires_at <= $4
+	`
+	args := []any{domain.WaitlistEntryStatusExpired, ticketID, domain.WaitlistEntryStatusOffered, at}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT id, ticket_id, customer_id, quantity, status, created_at, offered_at, offer_expires_at
+		FROM waitlist_entries
+		WHERE ticket_id = $1 AND status = $2
+		ORDER BY id
+	`
+
+	rows, err := tx.QueryContext(ctx, query, ticketID, domain.WaitlistEntryStatusWaiting)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	offered := make([]*domain.WaitlistEntry, 0)
+	for rows.Next() {
+		var entry domain.WaitlistEntry
+
+		err := rows.Scan(
+			&entry.ID,
+			&entry.TicketID,
+			&entry.CustomerID,
+			&entry.Quantity,
+			&entry.Status,
+			&entry.CreatedAt,
+			&entry.OfferedAt,
+			&entry.OfferExpiresAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if entry.Quantity > available {
+			break
+		}
+
+		available -= entry.Quantity
+		offered = append(offered, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	query = `
+		UPDATE waitlist_entries
+		SET status = $1, offered_at = $2, offer_expires_at = $3
+		WHERE id = $4
+	`
+
+	offerStmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer offerStmt.Close()
+
+	expiresAt := at.Add(claimWindow)
+	for _, entry := range offered {
+		_, err = offerStmt.ExecContext(ctx, domain.WaitlistEntryStatusOffered, at, expiresAt, entry.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Status = domain.WaitlistEntryStatusOffered
+		entry.OfferedAt = &at
+		entry.OfferExpiresAt = &expiresAt
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return offered, nil
+}
+
+func lockAvailableQuantity(ctx context.Context, tx *sql.Tx, ticketID int64, at time.Time) (int, error) {
+	query := `
+		SELECT quantity
+		FROM tickets
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var quantity int
+	err := tx.QueryRowContext(ctx, query, ticketID).Scan(&quantity)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return 0, utils.ErrTicketNotFound
+		default:
+			return 0, err
+		}
+	}
+
+	reserved, err := reservedQuantity(ctx, tx, ticketID, 0, at)
+	if err != nil {
+		return 0, err
+	}
+
+	return quantity - reserved, nil
+}
+
+func reservedQuantity(ctx context.Context, tx *sql.Tx, ticketID int64, customerID int64, at time.Time) (int, error) {
+	query := `
+		SELECT COALESCE(SUM(quantity), 0)
+		FROM waitlist_entries
+		WHERE ticket_id = $1 AND customer_id <> $2 AND status = $3 AND offer_expires_at > $4
+	`
+	args := []any{ticketID, customerID, domain.WaitlistEntryStatusOffered, at}
+
+	var reserved int
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&reserved)
+	if err != nil {
+		return 0, err
+	}
+
+	return reserved, nil
+}
@@ -0,0 +1,8 @@
+package storage
+
+// Keys are made by the application, never by clients.
+type Storage interface {
+	Put(key string, data []byte) error
+	Get(key string) ([]byte, error)
+	Delete(key string) error
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/config"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type CheckInUsecase struct {
+	ticketSigningKey       ed25519.PrivateKey
+	issuedTicketRepository repository.IIssuedTicketRepository
+	eventRepository        repository.IEventRepository
+}
+
+func NewCheckInUsecase(
+	config *config.Config,
+	issuedTicketRepository repository.IIssuedTicketRepository,
+	eventRepository repository.IEventRepository,
+) ICheckInUsecase {
+	return &CheckInUsecase{
+		ticketSigningKey:       utils.NewTicketSigningKey(config.TicketSigning.Seed),
+		issuedTicketRepository: issuedTicketRepository,
+		eventRepository:        eventRepository,
+	}
+}
+
+func (u *CheckInUsecase) GetSigningKey() string {
+	publicKey := u.ticketSigningKey.Public().(ed25519.PublicKey)
+	return base64.StdEncoding.EncodeToString(publicKey)
+}
+
+func (u *CheckInUsecase) GetStats(eventID int64) (*domain.CheckInStats, error) {
+	_, err := u.eventRepository.GetByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.issuedTicketRepository.GetCheckInStats(eventID)
+}
+
+func (u *CheckInUsecase) CheckIn(input *request.CheckInRequest, scannerID int64) (*domain.IssuedTicket, error) {
+	code := input.Code
+
+	if input.Token != "" {
+		publicKey := u.ticketSigningKey.Public().(ed25519.PublicKey)
+
+		payload, err := utils.VerifyTicketToken(publicKey, input.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		if code != "" && code != payload.Code {
+			return nil, utils.ErrInvalidTicketToken
+		}
+
+		if payload.EventID != input.EventID {
+			return nil, utils.ErrTicketEventMismatch
+		}
+
+		code = payload.Code
+	}
+
+	return u.issuedTicketRepository.CheckIn(input.EventID, code, scannerID, time.Now())
+}
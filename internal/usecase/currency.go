@@ -0,0 +1,11 @@
+package usecase
+
+import "strings"
+
+func normalizeCurrency(currency string, defaultCurrency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return defaultCurrency
+	}
+	return currency
+}
@@ -86,6 +86,8 @@ func (u *CustomerUsecase) GetAll() ([]*response.CustomerResponse, error) {
 			ID:       customer.ID,
 			Username: customer.Username,
 			Balance:  customer.Balance,
+			Currency: customer.Currency,
+			Role:     customer.Role,
 			Orders:   orders,
 		}
 		customerResponses = append(customerResponses, customerResponse)
@@ -98,6 +100,7 @@ func (u *CustomerUsecase) Add(input *request.CustomerRequest) (*domain.Customer,
 	customer := &domain.Customer{
 		Username: input.Username,
 		Balance:  0,
+		Currency: normalizeCurrency(input.Currency, u.config.Currency),
 	}
 
 	err := customer.Password.Set(input.Password)
@@ -128,6 +131,8 @@ func (u *CustomerUsecase) GetByID(customerID int64) (*response.CustomerResponse,
 		ID:       customer.ID,
 		Username: customer.Username,
 		Balance:  customer.Balance,
+		Currency: customer.Currency,
+		Role:     customer.Role,
 		Orders:   orders,
 	}
 
@@ -1,54 +1,195 @@
 package usecase
 
 import (
+	"html"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/config"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+	"github.com/nadiannis/evento-api-fr-auth/internal/payment"
 	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+	"github.com/rs/zerolog/log"
 )
 
+const refundBatchSize = 100
+
 type EventUsecase struct {
-	eventRepository  repository.IEventRepository
-	ticketRepository repository.ITicketRepository
+	config                      *config.Config
+	eventRepository             repository.IEventRepository
+	eventCancellationRepository repository.IEventCancellationRepository
+	ticketRepository            repository.ITicketRepository
+	orderRepository             repository.IOrderRepository
+	venueRepository             repository.IVenueRepository
+	eventImageRepository        repository.IEventImageRepository
+	paymentRefundRepository     repository.IPaymentRefundRepository
+	provider                    payment.PaymentProvider
+	processing                  sync.Map
 }
 
-func NewEventUsecase(eventRepository repository.IEventRepository, ticketRepository repository.ITicketRepository) IEventUsecase {
+func NewEventUsecase(
+	config *config.Config,
+	eventRepository repository.IEventRepository,
+	eventCancellationRepository repository.IEventCancellationRepository,
+	ticketRepository repository.ITicketRepository,
+	orderRepository repository.IOrderRepository,
+	venueRepository repository.IVenueRepository,
+	eventImageRepository repository.IEventImageRepository,
+	paymentRefundRepository repository.IPaymentRefundRepository,
+	provider payment.PaymentProvider,
+) IEventUsecase {
 	return &EventUsecase{
-		eventRepository:  eventRepository,
-		ticketRepository: ticketRepository,
+		config:                      config,
+		eventRepository:             eventRepository,
+		eventCancellationRepository: eventCancellationRepository,
+		ticketRepository:            ticketRepository,
+		orderRepository:             orderRepository,
+		venueRepository:             venueRepository,
+		eventImageRepository:        eventImageRepository,
+		paymentRefundRepository:     paymentRefundRepository,
+		provider:                    provider,
 	}
 }
 
-func (u *EventUsecase) GetAll() ([]*response.EventResponse, error) {
-	events, err := u.eventRepository.GetAll()
+func (u *EventUsecase) GetAll(input *request.EventFilterRequest) ([]*response.EventResponse, *response.Metadata, error) {
+	filters := &domain.EventFilters{
+		Query:     input.Query,
+		From:      input.From,
+		To:        input.To,
+		VenueID:   input.VenueID,
+		Category:  input.Category,
+		Tag:       normalizeTag(input.Tag),
+		Available: input.Available,
+		Sort:      input.Sort,
+		Limit:     input.Limit + 1,
+	}
+
+	if input.Cursor != "" {
+		var cursor domain.EventCursor
+
+		err := utils.DecodeCursor(input.Cursor, &cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		filters.Cursor = &cursor
+	}
+
+	events, err := u.eventRepository.GetAll(filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	eventResponses := make([]*response.EventResponse, 0)
+	metadata := &response.Metadata{
+		Limit: input.Limit,
+	}
 
-	for _, event := range events {
-		tickets, err := u.ticketRepository.GetByEventID(event.ID)
+	if len(events) > input.Limit {
+		events = events[:input.Limit]
+
+		metadata.NextCursor, err = utils.EncodeCursor(domain.NewEventCursor(&events[len(events)-1].Event))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+	}
+
+	eventResponses := make([]*response.EventResponse, 0, len(events))
+
+	for _, event := range events {
+		event.Localize()
 
 		eventResponse := &response.EventResponse{
-			ID:      event.ID,
-			Name:    event.Name,
-			Date:    event.Date,
-			Tickets: tickets,
+			ID:                    event.ID,
+			Name:                  event.Name,
+			StartsAt:              event.StartsAt,
+			EndsAt:                event.EndsAt,
+			DoorsOpenAt:           event.DoorsOpenAt,
+			Timezone:              event.Timezone,
+			Local:                 event.Local,
+			Date:                  event.Date,
+			Status:                event.Status,
+			MaxTicketsPerCustomer: event.MaxTicketsPerCustomer,
+			Venue:                 event.Venue,
+			SeriesID:              event.SeriesID,
+			Description:           event.Description,
+			DescriptionHTML:       event.DescriptionHTML,
+			Category:              event.Category,
+			Tags:                  event.Tags,
+			MinAge:                event.MinAge,
+			Organizer:             event.Organizer,
+			BookingFee:            event.BookingFee,
+			Tickets:               event.Tickets,
 		}
 		eventResponses = append(eventResponses, eventResponse)
 	}
 
-	return eventResponses, nil
+	return eventResponses, metadata, nil
+}
+
+func (u *EventUsecase) Search(input *request.EventSearchRequest) ([]*response.EventSearchResponse, error) {
+	matches, err := u.eventRepository.Search(input.Query, input.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*response.EventSearchResponse, 0, len(matches))
+
+	for _, match := range matches {
+		match.Localize()
+
+		result := &response.EventSearchResponse{
+			Event: &match.Event,
+			Rank:  match.Rank,
+			Highlights: map[string]string{
+				"name": highlight(match.NameHeadline),
+			},
+		}
+		if match.VenueHeadline != nil {
+			result.Highlights["venue"] = highlight(*match.VenueHeadline)
+		}
+		if match.DescriptionHeadline != nil {
+			result.Highlights["description"] = highlight(*match.DescriptionHeadline)
+		}
+		if match.TagsHeadline != nil {
+			result.Highlights["tags"] = highlight(*match.TagsHeadline)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
 }
 
 func (u *EventUsecase) Add(input *request.EventRequest) (*domain.Event, error) {
 	event := &domain.Event{
-		Name: input.Name,
-		Date: input.Date,
+		Name:                  input.Name,
+		StartsAt:              input.StartsAt,
+		EndsAt:                input.EndsAt,
+		DoorsOpenAt:           input.DoorsOpenAt,
+		Timezone:              input.Timezone,
+		MaxTicketsPerCustomer: input.MaxTicketsPerCustomer,
+		VenueID:               input.VenueID,
+	}
+	setEventDetails(event, &input.EventDetailsRequest)
+
+	if event.StartsAt.IsZero() && input.Date != nil {
+		event.StartsAt = *input.Date
+	}
+
+	if event.Timezone == "" {
+		event.Timezone = "UTC"
+
+		if event.VenueID != nil {
+			venue, err := u.venueRepository.GetByID(*event.VenueID)
+			if err != nil {
+				return nil, err
+			}
+			event.Timezone = venue.Timezone
+		}
 	}
 
 	err := u.eventRepository.Add(event)
@@ -56,6 +197,8 @@ func (u *EventUsecase) Add(input *request.EventRequest) (*domain.Event, error) {
 		return nil, err
 	}
 
+	event.Localize()
+
 	return event, nil
 }
 
@@ -70,12 +213,325 @@ func (u *EventUsecase) GetByID(eventID int64) (*response.EventResponse, error) {
 		return nil, err
 	}
 
+	var venue *domain.Venue
+	if event.VenueID != nil {
+		venue, err = u.venueRepository.GetByID(*event.VenueID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	images, err := u.eventImageRepository.GetByEventID(event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, image := range images {
+		image.SetURLs()
+	}
+
 	eventResponse := &response.EventResponse{
-		ID:      event.ID,
-		Name:    event.Name,
-		Date:    event.Date,
-		Tickets: tickets,
+		ID:                    event.ID,
+		Name:                  event.Name,
+		StartsAt:              event.StartsAt,
+		EndsAt:                event.EndsAt,
+		DoorsOpenAt:           event.DoorsOpenAt,
+		Timezone:              event.Timezone,
+		Local:                 event.Local,
+		Date:                  event.Date,
+		Status:                event.Status,
+		MaxTicketsPerCustomer: event.MaxTicketsPerCustomer,
+		Venue:                 venue,
+		SeriesID:              event.SeriesID,
+		Description:           event.Description,
+		DescriptionHTML:       event.DescriptionHTML,
+		Category:              event.Category,
+		Tags:                  event.Tags,
+		MinAge:                event.MinAge,
+		Organizer:             event.Organizer,
+		BookingFee:            event.BookingFee,
+		Images:                images,
+		Tickets:               tickets,
 	}
 
 	return eventResponse, nil
 }
+
+func (u *EventUsecase) UpdatePurchaseLimit(eventID int64, input *request.EventPurchaseLimitRequest) (*domain.Event, error) {
+	event, err := u.eventRepository.UpdatePurchaseLimit(eventID, input.MaxTicketsPerCustomer)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Localize()
+
+	return event, nil
+}
+
+func (u *EventUsecase) UpdateBookingFee(eventID int64, input *request.EventBookingFeeRequest) (*domain.Event, error) {
+	fee := domain.BookingFee{
+		Fixed:      domain.RoundCents(input.Fixed),
+		Percentage: input.Percentage,
+	}
+	if fee.Fixed > 0 {
+		currency := normalizeCurrency(input.Currency, u.config.Currency)
+		fee.Currency = &currency
+	}
+
+	event, err := u.eventRepository.UpdateBookingFee(eventID, fee)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Localize()
+
+	return event, nil
+}
+
+func (u *EventUsecase) UpdateVenue(eventID int64, input *request.EventVenueRequest) (*domain.Event, error) {
+	event, err := u.eventRepository.UpdateVenue(eventID, input.VenueID)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Localize()
+
+	return event, nil
+}
+
+func (u *EventUsecase) UpdateSchedule(eventID int64, input *request.EventScheduleRequest) (*domain.Event, error) {
+	event, err := u.eventRepository.GetByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	event.StartsAt = input.StartsAt
+	event.EndsAt = input.EndsAt
+	event.DoorsOpenAt = input.DoorsOpenAt
+	if input.Timezone != "" {
+		event.Timezone = input.Timezone
+	}
+
+	err = u.eventRepository.UpdateSchedule(event)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Localize()
+
+	return event, nil
+}
+
+func (u *EventUsecase) UpdateDetails(eventID int64, input *request.EventDetailsRequest) (*domain.Event, error) {
+	event := &domain.Event{ID: eventID}
+	setEventDetails(event, input)
+
+	err := u.eventRepository.UpdateDetails(event)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Localize()
+
+	return event, nil
+}
+
+func (u *EventUsecase) GetCategories() ([]*domain.CategoryCount, error) {
+	counts, err := u.eventRepository.CountByCategory()
+	if err != nil {
+		return nil, err
+	}
+
+	eventCounts := make(map[domain.EventCategory]int, len(counts))
+	for _, count := range counts {
+		eventCounts[count.Name] = count.EventCount
+	}
+
+	categories := make([]*domain.CategoryCount, 0, len(domain.EventCategories))
+	for _, category := range domain.EventCategories {
+		categories = append(categories, &domain.CategoryCount{
+			Name:       category,
+			EventCount: eventCounts[category],
+		})
+	}
+
+	return categories, nil
+}
+
+func (u *EventUsecase) GetReminders(customerID int64) ([]*response.EventReminderResponse, error) {
+	now := time.Now()
+
+	events, err := u.eventRepository.GetUpcomingByCustomerID(customerID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := make([]*response.EventReminderResponse, 0)
+
+	for _, event := range events {
+		remindAt := event.ReminderAt()
+		if now.Before(remindAt) {
+			continue
+		}
+
+		event.Localize()
+
+		reminders = append(reminders, &response.EventReminderResponse{
+			Event:    event,
+			RemindAt: remindAt,
+		})
+	}
+
+	return reminders, nil
+}
+
+func (u *EventUsecase) GetSeats(eventID int64) ([]*domain.EventSeat, error) {
+	event, err := u.eventRepository.GetByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.VenueID == nil {
+		return nil, utils.ErrEventWithoutVenue
+	}
+
+	return u.venueRepository.GetSeatsByEventID(event.ID)
+}
+
+func (u *EventUsecase) Cancel(eventID int64) (*domain.EventCancellation, error) {
+	_, err := u.eventRepository.GetByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	cancellation := &domain.EventCancellation{
+		EventID:         eventID,
+		Status:          domain.EventCancellationStatusInProgress,
+		RefundedAmounts: make(map[string]float64),
+		CreatedAt:       time.Now(),
+	}
+
+	err = u.eventCancellationRepository.Add(cancellation)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Background(func() {
+		u.processCancellation(cancellation)
+	})
+
+	return cancellation, nil
+}
+
+func (u *EventUsecase) GetCancellation(eventID int64) (*domain.EventCancellation, error) {
+	_, err := u.eventRepository.GetByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.eventCancellationRepository.GetByEventID(eventID)
+}
+
+func (u *EventUsecase) ResumeCancellations() error {
+	cancellations, err := u.eventCancellationRepository.GetInProgress()
+	if err != nil {
+		return err
+	}
+
+	for _, cancellation := range cancellations {
+		utils.Background(func() {
+			u.processCancellation(cancellation)
+		})
+	}
+
+	return nil
+}
+
+// Every pass goes over all the orders still completed, so the orders whose
+// refund failed are picked up again when the cancellation is resumed.
+func (u *EventUsecase) processCancellation(cancellation *domain.EventCancellation) {
+	_, running := u.processing.LoadOrStore(cancellation.ID, struct{}{})
+	if running {
+		return
+	}
+	defer u.processing.Delete(cancellation.ID)
+
+	var afterID int64
+	failed := 0
+
+	for {
+		orders, err := u.orderRepository.GetCompletedByEventID(cancellation.EventID, afterID, refundBatchSize)
+		if err != nil {
+			log.Error().Int64("event_id", cancellation.EventID).Msg(err.Error())
+			return
+		}
+
+		if len(orders) == 0 {
+			break
+		}
+
+		for _, order := range orders {
+			afterID = order.ID
+
+			err := u.eventCancellationRepository.RefundOrder(cancellation, order)
+			if err != nil {
+				log.Error().Int64("event_id", cancellation.EventID).Int64("order_id", order.ID).Msg(err.Error())
+				failed++
+				continue
+			}
+
+			sendOrderRefunds(u.provider, u.paymentRefundRepository, order)
+		}
+	}
+
+	if failed != 0 {
+		log.Error().
+			Int64("event_id", cancellation.EventID).
+			Int("failed_orders", failed).
+			Msg("event cancellation left in progress")
+		return
+	}
+
+	err := u.eventCancellationRepository.Complete(cancellation)
+	if err != nil {
+		log.Error().Int64("event_id", cancellation.EventID).Msg(err.Error())
+		return
+	}
+
+	log.Info().
+		Int64("event_id", cancellation.EventID).
+		Int("refunded_orders", cancellation.RefundedOrders).
+		Interface("refunded_amounts", cancellation.RefundedAmounts).
+		Msg("event cancellation completed")
+}
+
+func highlight(headline string) string {
+	return strings.NewReplacer(
+		domain.HeadlineStart, "<mark>",
+		domain.HeadlineStop, "</mark>",
+	).Replace(html.EscapeString(headline))
+}
+
+func setEventDetails(event *domain.Event, input *request.EventDetailsRequest) {
+	event.Description = input.Description
+	event.DescriptionHTML = utils.RenderMarkdown(input.Description)
+	event.Category = input.Category
+	event.MinAge = input.MinAge
+	event.Organizer = domain.Organizer{
+		Name:    strings.TrimSpace(input.Organizer.Name),
+		Email:   strings.TrimSpace(input.Organizer.Email),
+		Website: strings.TrimSpace(input.Organizer.Website),
+	}
+
+	event.Tags = make([]string, 0, len(input.Tags))
+	for _, tag := range input.Tags {
+		tag = normalizeTag(tag)
+		if tag != "" && !slices.Contains(event.Tags, tag) {
+			event.Tags = append(event.Tags, tag)
+		}
+	}
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/storage"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type EventImageUsecase struct {
+	eventImageRepository repository.IEventImageRepository
+	eventRepository      repository.IEventRepository
+	storage              storage.Storage
+}
+
+func NewEventImageUsecase(
+	eventImageRepository repository.IEventImageRepository,
+	eventRepository repository.IEventRepository,
+	storage storage.Storage,
+) IEventImageUsecase {
+	return &EventImageUsecase{
+		eventImageRepository: eventImageRepository,
+		eventRepository:      eventRepository,
+		storage:              storage,
+	}
+}
+
+func (u *EventImageUsecase) GetAll(eventID int64) ([]*domain.EventImage, error) {
+	_, err := u.eventRepository.GetByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := u.eventImageRepository.GetByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, image := range images {
+		image.SetURLs()
+	}
+
+	return images, nil
+}
+
+func (u *EventImageUsecase) GetByID(imageID int64) (*domain.EventImage, error) {
+	image, err := u.eventImageRepository.GetByID(imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	image.SetURLs()
+
+	return image, nil
+}
+
+func (u *EventImageUsecase) Read(image *domain.EventImage, variant domain.ImageVariant) ([]byte, error) {
+	data, err := u.storage.Get(image.VariantKey(variant))
+	if err != nil {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return nil, utils.ErrEventImageNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+func (u *EventImageUsecase) Add(eventID int64, data []byte) (*domain.EventImage, error) {
+	_, err := u.eventRepository.GetByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, contentType, err := utils.DecodeImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	randomBytes := make([]byte, 16)
+	_, err = rand.Read(randomBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	image := &domain.EventImage{
+		EventID:     eventID,
+		Key:         fmt.Sprintf("events/%d/%s", eventID, hex.EncodeToString(randomBytes)),
+		ContentType: contentType,
+		Width:       decoded.Bounds().Dx(),
+		Height:      decoded.Bounds().Dy(),
+		Size:        int64(len(data)),
+	}
+
+	stored := make([]domain.ImageVariant, 0, len(domain.ImageVariants))
+	defer func() {
+		if image.ID == 0 {
+			u.removeFiles(image, stored)
+		}
+	}()
+
+	for _, variant := range domain.ImageVariants {
+		file := data
+		if variant != domain.ImageVariantOriginal {
+			resized := utils.ResizeImage(decoded, domain.ImageBounds[variant])
+
+			file, err = utils.EncodeImage(resized, image.VariantContentType(variant))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = u.storage.Put(image.VariantKey(variant), file)
+		if err != nil {
+			return nil, err
+		}
+		stored = append(stored, variant)
+	}
+
+	err = u.eventImageRepository.Add(image)
+	if err != nil {
+		return nil, err
+	}
+
+	image.SetURLs()
+
+	return image, nil
+}
+
+func (u *EventImageUsecase) Delete(eventID int64, imageID int64) error {
+	image, err := u.eventImageRepository.Delete(eventID, imageID)
+	if err != nil {
+		return err
+	}
+
+	u.removeFiles(image, domain.ImageVariants)
+
+	return nil
+}
+
+func (u *EventImageUsecase) removeFiles(image *domain.EventImage, variants []domain.ImageVariant) {
+	for _, variant := range variants {
+		err := u.storage.Delete(image.VariantKey(variant))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.Error().Str("key", image.VariantKey(variant)).Msg(err.Error())
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+const maxSeriesEvents = 366
+
+type EventSeriesUsecase struct {
+	eventSeriesRepository repository.IEventSeriesRepository
+	eventRepository       repository.IEventRepository
+}
+
+func NewEventSeriesUsecase(
+	eventSeriesRepository repository.IEventSeriesRepository,
+	eventRepository repository.IEventRepository,
+) IEventSeriesUsecase {
+	return &EventSeriesUsecase{
+		eventSeriesRepository: eventSeriesRepository,
+		eventRepository:       eventRepository,
+	}
+}
+
+func (u *EventSeriesUsecase) GetByID(seriesID int64) (*domain.EventSeries, error) {
+	series, err := u.eventSeriesRepository.GetByID(seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	series.Events, err = u.eventRepository.GetBySeriesID(series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range series.Events {
+		event.Localize()
+	}
+
+	return series, nil
+}
+
+func (u *EventSeriesUsecase) Add(input *request.EventSeriesRequest) (*domain.EventSeries, error) {
+	template, err := u.eventRepository.GetByID(input.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.Status == domain.EventStatusCancelled {
+		return nil, utils.ErrEventCancelled
+	}
+
+	if template.SeriesID != nil {
+		return nil, utils.ErrEventInSeries
+	}
+
+	series := &domain.EventSeries{
+		Name:     input.Name,
+		Timezone: template.Timezone,
+		Recurrence: domain.Recurrence{
+			Frequency: input.Recurrence.Frequency,
+			Interval:  max(input.Recurrence.Interval, 1),
+			ByDay:     input.Recurrence.ByDay,
+			Until:     input.Recurrence.Until,
+			Count:     input.Recurrence.Count,
+		},
+	}
+	if series.Name == "" {
+		series.Name = template.Name
+	}
+	if series.Recurrence.ByDay == nil {
+		series.Recurrence.ByDay = make([]string, 0)
+	}
+
+	startTimes, ok := series.Recurrence.Occurrences(template.StartsAt.In(template.Location()), maxSeriesEvents)
+	if !ok {
+		return nil, utils.ErrTooManyOccurrences
+	}
+
+	series.Events = make([]*domain.Event, 0, len(startTimes))
+	series.Events = append(series.Events, template)
+
+	for _, startsAt := range startTimes[1:] {
+		event := &domain.Event{
+			Name:                  template.Name,
+			StartsAt:              startsAt,
+			Timezone:              template.Timezone,
+			MaxTicketsPerCustomer: template.MaxTicketsPerCustomer,
+			VenueID:               template.VenueID,
+			Description:           template.Description,
+			DescriptionHTML:       template.DescriptionHTML,
+			Category:              template.Category,
+			Tags:                  template.Tags,
+			MinAge:                template.MinAge,
+			Organizer:             template.Organizer,
+			BookingFee:            template.BookingFee,
+		}
+		shiftEvent(event, template, startsAt.Sub(template.StartsAt))
+
+		series.Events = append(series.Events, event)
+	}
+
+	err = u.eventSeriesRepository.Add(series)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range series.Events {
+		event.Localize()
+	}
+
+	return series, nil
+}
+
+func (u *EventSeriesUsecase) Update(seriesID int64, input *request.EventSeriesUpdateRequest) (*domain.EventSeries, error) {
+	series, err := u.GetByID(seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		series.Name = *input.Name
+	}
+
+	var startTime time.Time
+	if input.StartTime != nil {
+		startTime, err = time.Parse("15:04", *input.StartTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	upcoming := make([]*domain.Event, 0)
+
+	for _, event := range series.Events {
+		if event.Status != domain.EventStatusScheduled || !event.StartsAt.After(now) {
+			continue
+		}
+
+		if input.Name != nil {
+			event.Name = *input.Name
+		}
+
+		if input.MaxTicketsPerCustomer != nil {
+			event.MaxTicketsPerCustomer = input.MaxTicketsPerCustomer
+		}
+
+		if input.StartTime != nil {
+			local := event.StartsAt.In(event.Location())
+			startsAt := time.Date(local.Year(), local.Month(), local.Day(), startTime.Hour(), startTime.Minute(), 0, 0, local.Location())
+			shiftEvent(event, event, startsAt.Sub(event.StartsAt))
+			event.StartsAt = startsAt
+		}
+
+		event.Localize()
+		upcoming = append(upcoming, event)
+	}
+
+	err = u.eventSeriesRepository.Update(series, upcoming)
+	if err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func shiftEvent(event *domain.Event, source *domain.Event, shift time.Duration) {
+	if source.EndsAt != nil {
+		endsAt := source.EndsAt.Add(shift)
+		event.EndsAt = &endsAt
+	}
+	if source.DoorsOpenAt != nil {
+		doorsOpenAt := source.DoorsOpenAt.Add(shift)
+		event.DoorsOpenAt = &doorsOpenAt
+	}
+}
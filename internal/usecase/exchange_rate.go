@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+)
+
+type ExchangeRateUsecase struct {
+	exchangeRateRepository repository.IExchangeRateRepository
+}
+
+func NewExchangeRateUsecase(exchangeRateRepository repository.IExchangeRateRepository) IExchangeRateUsecase {
+	return &ExchangeRateUsecase{
+		exchangeRateRepository: exchangeRateRepository,
+	}
+}
+
+func (u *ExchangeRateUsecase) GetAll() ([]*domain.ExchangeRate, error) {
+	return u.exchangeRateRepository.GetAll()
+}
+
+func (u *ExchangeRateUsecase) Set(input *request.ExchangeRateRequest) (*domain.ExchangeRate, error) {
+	rate := &domain.ExchangeRate{
+		BaseCurrency:  normalizeCurrency(input.BaseCurrency, ""),
+		QuoteCurrency: normalizeCurrency(input.QuoteCurrency, ""),
+		Rate:          input.Rate,
+	}
+
+	err := u.exchangeRateRepository.Set(rate)
+	if err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+func (u *ExchangeRateUsecase) Delete(rateID int64) error {
+	return u.exchangeRateRepository.Delete(rateID)
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/config"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+const giftCardValidity = 365 * 24 * time.Hour
+
+type GiftCardUsecase struct {
+	config             *config.Config
+	giftCardRepository repository.IGiftCardRepository
+	customerRepository repository.ICustomerRepository
+}
+
+func NewGiftCardUsecase(
+	config *config.Config,
+	giftCardRepository repository.IGiftCardRepository,
+	customerRepository repository.ICustomerRepository,
+) IGiftCardUsecase {
+	return &GiftCardUsecase{
+		config:             config,
+		giftCardRepository: giftCardRepository,
+		customerRepository: customerRepository,
+	}
+}
+
+func (u *GiftCardUsecase) GetByPurchaserID(customerID int64) ([]*domain.GiftCard, error) {
+	return u.giftCardRepository.GetByPurchaserID(customerID)
+}
+
+func (u *GiftCardUsecase) GetByCode(code string) (*domain.GiftCard, error) {
+	return u.giftCardRepository.GetByCode(normalizeGiftCardCode(code))
+}
+
+func (u *GiftCardUsecase) Add(input *request.GiftCardRequest, customerID int64) (*domain.GiftCard, error) {
+	customer, err := u.customerRepository.GetByID(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := utils.GenerateGiftCardCode()
+	if err != nil {
+		return nil, err
+	}
+
+	value := domain.RoundCents(input.Value)
+
+	giftCard := &domain.GiftCard{
+		Code:        code,
+		Value:       value,
+		Balance:     value,
+		PurchaserID: customer.ID,
+		CreatedAt:   time.Now(),
+	}
+
+	transaction := &domain.GiftCardTransaction{
+		CustomerID: customer.ID,
+		Amount:     value,
+		CreatedAt:  giftCard.CreatedAt,
+	}
+
+	if customer.Role == domain.RoleAdmin {
+		giftCard.Currency = normalizeCurrency(input.Currency, u.config.Currency)
+		giftCard.ExpiresAt = input.ExpiresAt
+		transaction.Type = domain.GiftCardTransactionIssue
+	} else {
+		if input.Currency != "" || input.ExpiresAt != nil {
+			return nil, utils.ErrGiftCardOptionsRestricted
+		}
+
+		expiresAt := giftCard.CreatedAt.Add(giftCardValidity)
+		giftCard.Currency = customer.Currency
+		giftCard.ExpiresAt = &expiresAt
+		transaction.Type = domain.GiftCardTransactionPurchase
+	}
+
+	giftCard.Transactions = []*domain.GiftCardTransaction{transaction}
+
+	err = u.giftCardRepository.Add(giftCard)
+	if err != nil {
+		return nil, err
+	}
+
+	return giftCard, nil
+}
+
+func (u *GiftCardUsecase) Redeem(input *request.GiftCardRedemptionRequest, customerID int64) (*domain.GiftCard, error) {
+	giftCard, err := u.giftCardRepository.GetByCode(normalizeGiftCardCode(input.Code))
+	if err != nil {
+		return nil, err
+	}
+
+	var amount *float64
+	if input.Amount != nil {
+		rounded := domain.RoundCents(*input.Amount)
+		amount = &rounded
+	}
+
+	err = u.giftCardRepository.Redeem(giftCard, customerID, amount, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return giftCard, nil
+}
+
+func normalizeGiftCardCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -23,12 +23,24 @@ type ICustomerUsecase interface {
 }
 
 type EventReader interface {
-	GetAll() ([]*response.EventResponse, error)
+	GetAll(input *request.EventFilterRequest) ([]*response.EventResponse, *response.Metadata, error)
 	GetByID(eventID int64) (*response.EventResponse, error)
+	Search(input *request.EventSearchRequest) ([]*response.EventSearchResponse, error)
+	GetCancellation(eventID int64) (*domain.EventCancellation, error)
+	GetSeats(eventID int64) ([]*domain.EventSeat, error)
+	GetReminders(customerID int64) ([]*response.EventReminderResponse, error)
+	GetCategories() ([]*domain.CategoryCount, error)
 }
 
 type EventWriter interface {
 	Add(input *request.EventRequest) (*domain.Event, error)
+	UpdatePurchaseLimit(eventID int64, input *request.EventPurchaseLimitRequest) (*domain.Event, error)
+	UpdateBookingFee(eventID int64, input *request.EventBookingFeeRequest) (*domain.Event, error)
+	UpdateVenue(eventID int64, input *request.EventVenueRequest) (*domain.Event, error)
+	UpdateSchedule(eventID int64, input *request.EventScheduleRequest) (*domain.Event, error)
+	UpdateDetails(eventID int64, input *request.EventDetailsRequest) (*domain.Event, error)
+	Cancel(eventID int64) (*domain.EventCancellation, error)
+	ResumeCancellations() error
 }
 
 type IEventUsecase interface {
@@ -36,6 +48,36 @@ type IEventUsecase interface {
 	EventWriter
 }
 
+type EventImageReader interface {
+	GetAll(eventID int64) ([]*domain.EventImage, error)
+	GetByID(imageID int64) (*domain.EventImage, error)
+	Read(image *domain.EventImage, variant domain.ImageVariant) ([]byte, error)
+}
+
+type EventImageWriter interface {
+	Add(eventID int64, data []byte) (*domain.EventImage, error)
+	Delete(eventID int64, imageID int64) error
+}
+
+type IEventImageUsecase interface {
+	EventImageReader
+	EventImageWriter
+}
+
+type EventSeriesReader interface {
+	GetByID(seriesID int64) (*domain.EventSeries, error)
+}
+
+type EventSeriesWriter interface {
+	Add(input *request.EventSeriesRequest) (*domain.EventSeries, error)
+	Update(seriesID int64, input *request.EventSeriesUpdateRequest) (*domain.EventSeries, error)
+}
+
+type IEventSeriesUsecase interface {
+	EventSeriesReader
+	EventSeriesWriter
+}
+
 type TicketTypeReader interface {
 	GetAll() ([]*domain.TicketType, error)
 }
@@ -52,11 +94,20 @@ type ITicketTypeUsecase interface {
 type TicketReader interface {
 	GetAll() ([]*domain.TicketDetail, error)
 	GetByID(ticketID int64) (*domain.TicketDetail, error)
+	GetPresales(ticketID int64) ([]*domain.Presale, error)
+	GetPrice(ticketID int64) (*response.TicketPriceResponse, error)
+	GetPriceTiers(ticketID int64) ([]*domain.PriceTier, error)
 }
 
 type TicketWriter interface {
 	Add(input *request.TicketRequest) (*domain.Ticket, error)
 	UpdateQuantity(ticketID int64, input *request.TicketQuantityRequest) (*domain.Ticket, error)
+	UpdateSalesWindow(ticketID int64, input *request.TicketSalesWindowRequest) (*domain.Ticket, error)
+	UpdatePurchaseLimit(ticketID int64, input *request.TicketPurchaseLimitRequest) (*domain.Ticket, error)
+	AssignSeats(ticketID int64, input *request.TicketSeatsRequest) (*domain.Ticket, error)
+	AddPresale(ticketID int64, input *request.PresaleRequest) (*domain.Presale, error)
+	AddPriceTier(ticketID int64, input *request.PriceTierRequest) (*domain.PriceTier, error)
+	DeletePriceTier(ticketID int64, tierID int64) (*domain.PriceTier, error)
 }
 
 type ITicketUsecase interface {
@@ -66,10 +117,18 @@ type ITicketUsecase interface {
 
 type OrderReader interface {
 	GetAll() ([]*domain.Order, error)
+	GetTickets(orderID int64, customerID int64) ([]*domain.IssuedTicket, error)
+	GetCustomerTickets(customerID int64) ([]*domain.IssuedTicket, error)
+	RenderOrderTicket(orderID int64, code string, customerID int64) ([]byte, error)
+	RenderOrderTickets(orderID int64, customerID int64) ([]byte, error)
+	RenderTicket(code string, customerID int64) ([]byte, error)
+	RenderTickets(customerID int64, eventID *int64) ([]byte, error)
 }
 
 type OrderWriter interface {
-	Add(input *request.OrderRequest) (*domain.Order, error)
+	Add(input *request.OrderRequest, customerID int64) (*domain.Order, error)
+	Cancel(orderID int64, customerID int64) (*domain.Order, error)
+	ExpireUnpaid() error
 	DeleteAll() error
 }
 
@@ -77,3 +136,154 @@ type IOrderUsecase interface {
 	OrderReader
 	OrderWriter
 }
+
+type CheckInReader interface {
+	GetSigningKey() string
+	GetStats(eventID int64) (*domain.CheckInStats, error)
+}
+
+type CheckInWriter interface {
+	CheckIn(input *request.CheckInRequest, scannerID int64) (*domain.IssuedTicket, error)
+}
+
+type ICheckInUsecase interface {
+	CheckInReader
+	CheckInWriter
+}
+
+type TicketTransferReader interface {
+	GetAll(customerID int64) ([]*domain.TicketTransfer, error)
+}
+
+type TicketTransferWriter interface {
+	Add(input *request.TicketTransferRequest, senderID int64) (*domain.TicketTransfer, error)
+	Accept(transferID int64, recipientID int64) (*domain.TicketTransfer, error)
+	Cancel(transferID int64, customerID int64) (*domain.TicketTransfer, error)
+}
+
+type ITicketTransferUsecase interface {
+	TicketTransferReader
+	TicketTransferWriter
+}
+
+type ResaleListingReader interface {
+	GetAll() ([]*domain.ResaleListing, error)
+}
+
+type ResaleListingWriter interface {
+	Add(input *request.ResaleListingRequest, sellerID int64) (*domain.ResaleListing, error)
+	Purchase(listingID int64, buyerID int64) (*domain.ResaleListing, error)
+	Cancel(listingID int64, sellerID int64) (*domain.ResaleListing, error)
+}
+
+type IResaleListingUsecase interface {
+	ResaleListingReader
+	ResaleListingWriter
+}
+
+type WaitlistReader interface {
+	GetAll(customerID int64) ([]*domain.WaitlistEntry, error)
+}
+
+type WaitlistWriter interface {
+	Join(ticketID int64, customerID int64, input *request.WaitlistRequest) (*domain.WaitlistEntry, error)
+	Leave(ticketID int64, customerID int64) (*domain.WaitlistEntry, error)
+	ProcessOffers() error
+}
+
+type IWaitlistUsecase interface {
+	WaitlistReader
+	WaitlistWriter
+}
+
+type VenueReader interface {
+	GetAll() ([]*domain.Venue, error)
+	GetByID(venueID int64) (*domain.Venue, error)
+}
+
+type VenueWriter interface {
+	Add(input *request.VenueRequest) (*domain.Venue, error)
+	Update(venueID int64, input *request.VenueUpdateRequest) (*domain.Venue, error)
+	Delete(venueID int64) error
+}
+
+type IVenueUsecase interface {
+	VenueReader
+	VenueWriter
+}
+
+type PromoCodeReader interface {
+	GetAll() ([]*domain.PromoCode, error)
+	GetByID(promoCodeID int64) (*domain.PromoCode, error)
+}
+
+type PromoCodeWriter interface {
+	Add(input *request.PromoCodeRequest) (*domain.PromoCode, error)
+	UpdateLimits(promoCodeID int64, input *request.PromoCodeLimitsRequest) (*domain.PromoCode, error)
+}
+
+type IPromoCodeUsecase interface {
+	PromoCodeReader
+	PromoCodeWriter
+}
+
+type PaymentReader interface {
+	GetTopUps(customerID int64) ([]*domain.TopUp, error)
+}
+
+type PaymentWriter interface {
+	AddTopUp(input *request.TopUpRequest, customerID int64) (*domain.TopUp, error)
+	RefundTopUp(topUpID int64) (*domain.TopUp, error)
+	HandleWebhook(payload []byte, signature string) error
+	ProcessRefunds() error
+	Simulate(input *request.PaymentSimulationRequest) error
+}
+
+type IPaymentUsecase interface {
+	PaymentReader
+	PaymentWriter
+}
+
+type GiftCardReader interface {
+	GetByPurchaserID(customerID int64) ([]*domain.GiftCard, error)
+	GetByCode(code string) (*domain.GiftCard, error)
+}
+
+type GiftCardWriter interface {
+	Add(input *request.GiftCardRequest, customerID int64) (*domain.GiftCard, error)
+	Redeem(input *request.GiftCardRedemptionRequest, customerID int64) (*domain.GiftCard, error)
+}
+
+type IGiftCardUsecase interface {
+	GiftCardReader
+	GiftCardWriter
+}
+
+type ExchangeRateReader interface {
+	GetAll() ([]*domain.ExchangeRate, error)
+}
+
+type ExchangeRateWriter interface {
+	Set(input *request.ExchangeRateRequest) (*domain.ExchangeRate, error)
+	Delete(rateID int64) error
+}
+
+type IExchangeRateUsecase interface {
+	ExchangeRateReader
+	ExchangeRateWriter
+}
+
+type TaxRateReader interface {
+	GetAll() ([]*domain.TaxRate, error)
+}
+
+type TaxRateWriter interface {
+	Add(input *request.TaxRateRequest) (*domain.TaxRate, error)
+	Update(taxRateID int64, input *request.TaxRateUpdateRequest) (*domain.TaxRate, error)
+	Delete(taxRateID int64) error
+}
+
+type ITaxRateUsecase interface {
+	TaxRateReader
+	TaxRateWriter
+}
@@ -1,31 +1,72 @@
 package usecase
 
 import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"slices"
 	"time"
 
+	"github.com/nadiannis/evento-api-fr-auth/internal/config"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/payment"
 	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
 )
 
+const expiryBatchSize = 100
+
 type OrderUsecase struct {
-	orderRepository      repository.IOrderRepository
-	customerRepository   repository.ICustomerRepository
-	ticketRepository     repository.ITicketRepository
-	ticketTypeRepository repository.ITicketTypeRepository
+	ticketSigningKey        ed25519.PrivateKey
+	orderRepository         repository.IOrderRepository
+	customerRepository      repository.ICustomerRepository
+	eventRepository         repository.IEventRepository
+	ticketRepository        repository.ITicketRepository
+	ticketTypeRepository    repository.ITicketTypeRepository
+	presaleRepository       repository.IPresaleRepository
+	issuedTicketRepository  repository.IIssuedTicketRepository
+	waitlistRepository      repository.IWaitlistRepository
+	venueRepository         repository.IVenueRepository
+	promoCodeRepository     repository.IPromoCodeRepository
+	giftCardRepository      repository.IGiftCardRepository
+	paymentRefundRepository repository.IPaymentRefundRepository
+	provider                payment.PaymentProvider
+	paymentTimeout          time.Duration
 }
 
 func NewOrderUsecase(
+	config *config.Config,
 	orderRepository repository.IOrderRepository,
 	customerRepository repository.ICustomerRepository,
+	eventRepository repository.IEventRepository,
 	ticketRepository repository.ITicketRepository,
 	ticketTypeRepository repository.ITicketTypeRepository,
+	presaleRepository repository.IPresaleRepository,
+	issuedTicketRepository repository.IIssuedTicketRepository,
+	waitlistRepository repository.IWaitlistRepository,
+	venueRepository repository.IVenueRepository,
+	promoCodeRepository repository.IPromoCodeRepository,
+	giftCardRepository repository.IGiftCardRepository,
+	paymentRefundRepository repository.IPaymentRefundRepository,
+	provider payment.PaymentProvider,
 ) IOrderUsecase {
 	return &OrderUsecase{
-		orderRepository:      orderRepository,
-		customerRepository:   customerRepository,
-		ticketRepository:     ticketRepository,
-		ticketTypeRepository: ticketTypeRepository,
+		ticketSigningKey:        utils.NewTicketSigningKey(config.TicketSigning.Seed),
+		orderRepository:         orderRepository,
+		customerRepository:      customerRepository,
+		eventRepository:         eventRepository,
+		ticketRepository:        ticketRepository,
+		ticketTypeRepository:    ticketTypeRepository,
+		presaleRepository:       presaleRepository,
+		issuedTicketRepository:  issuedTicketRepository,
+		waitlistRepository:      waitlistRepository,
+		venueRepository:         venueRepository,
+		promoCodeRepository:     promoCodeRepository,
+		giftCardRepository:      giftCardRepository,
+		paymentRefundRepository: paymentRefundRepository,
+		provider:                provider,
+		paymentTimeout:          config.Payments.Timeout,
 	}
 }
 
@@ -33,54 +74,621 @@ func (u *OrderUsecase) GetAll() ([]*domain.Order, error) {
 	return u.orderRepository.GetAll()
 }
 
-func (u *OrderUsecase) Add(input *request.OrderRequest) (*domain.Order, error) {
-	customer, err := u.customerRepository.GetByID(input.CustomerID)
+func (u *OrderUsecase) Add(input *request.OrderRequest, customerID int64) (*domain.Order, error) {
+	customer, err := u.customerRepository.GetByID(customerID)
 	if err != nil {
 		return nil, err
 	}
 
-	ticketDetail, err := u.ticketRepository.GetByID(input.TicketID)
+	order := &domain.Order{
+		CustomerID:    customer.ID,
+		PaymentMethod: domain.PaymentMethodBalance,
+		Status:        domain.OrderStatusCompleted,
+		CreatedAt:     time.Now(),
+		Items:         make([]*domain.OrderItem, 0),
+	}
+
+	issuedTicketStatus := domain.IssuedTicketStatusValid
+
+	if input.PaymentMethod == domain.PaymentMethodProvider {
+		if u.provider == nil {
+			return nil, utils.ErrPaymentProviderDisabled
+		}
+
+		expiresAt := order.CreatedAt.Add(u.paymentTimeout)
+		order.PaymentMethod = domain.PaymentMethodProvider
+		order.Status = domain.OrderStatusPending
+		order.ExpiresAt = &expiresAt
+		issuedTicketStatus = domain.IssuedTicketStatusPending
+	}
+
+	limit := &domain.PurchaseLimit{
+		MaxPerTicket: make(map[int64]int),
+	}
+
+	var event *domain.Event
+
+	for _, itemInput := range mergeLineItems(input.LineItems()) {
+		ticketDetail, err := u.ticketRepository.GetByID(itemInput.TicketID)
+		if err != nil {
+			return nil, err
+		}
+
+		if event == nil {
+			event, err = u.eventRepository.GetByID(ticketDetail.EventID)
+			if err != nil {
+				return nil, err
+			}
+
+			if event.Status == domain.EventStatusCancelled {
+				return nil, utils.ErrEventCancelled
+			}
+
+			if !time.Now().Before(event.SalesCutoff()) {
+				return nil, utils.ErrTicketSalesEnded
+			}
+
+			order.Currency = ticketDetail.Type.Currency
+		} else if ticketDetail.EventID != event.ID {
+			return nil, utils.ErrOrderItemsAcrossEvents
+		} else if ticketDetail.Type.Currency != order.Currency {
+			return nil, utils.ErrOrderItemsAcrossCurrencies
+		}
+
+		err = u.checkSalesWindow(ticketDetail, customer.ID, input.AccessCode)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(itemInput.SeatIDs) > 0 && !validSeatSelection(itemInput) {
+			return nil, fmt.Errorf("%w: ticket %d", utils.ErrInvalidSeatSelection, itemInput.TicketID)
+		}
+
+		if itemInput.Quantity < 1 || itemInput.Quantity > ticketDetail.Quantity {
+			return nil, fmt.Errorf("%w: ticket %d", utils.ErrInsufficientTicketQuantity, itemInput.TicketID)
+		}
+
+		if ticketDetail.MaxPerCustomer != nil && itemInput.Quantity > *ticketDetail.MaxPerCustomer {
+			return nil, fmt.Errorf("%w: at most %d per customer for ticket %d", utils.ErrPurchaseLimitExceeded, *ticketDetail.MaxPerCustomer, itemInput.TicketID)
+		}
+
+		item := &domain.OrderItem{
+			TicketID:   ticketDetail.ID,
+			Quantity:   itemInput.Quantity,
+			UnitPrice:  ticketDetail.Type.Price,
+			TotalPrice: float64(itemInput.Quantity) * ticketDetail.Type.Price,
+			SeatIDs:    itemInput.SeatIDs,
+		}
+		order.Items = append(order.Items, item)
+
+		if ticketDetail.MaxPerCustomer != nil {
+			limit.MaxPerTicket[ticketDetail.ID] = *ticketDetail.MaxPerCustomer
+		}
+	}
+
+	order.EventID = event.ID
+	limit.MaxPerEvent = event.MaxTicketsPerCustomer
+
+	if limit.MaxPerEvent != nil && order.Quantity() > *limit.MaxPerEvent {
+		return nil, fmt.Errorf("%w: at most %d per customer for this event", utils.ErrPurchaseLimitExceeded, *limit.MaxPerEvent)
+	}
+
+	for _, item := range order.Items {
+		for range item.Quantity {
+			code, err := utils.GenerateTicketCode()
+			if err != nil {
+				return nil, err
+			}
+
+			issuedTicket := &domain.IssuedTicket{
+				TicketID:   item.TicketID,
+				EventID:    event.ID,
+				CustomerID: customer.ID,
+				Code:       code,
+				Status:     issuedTicketStatus,
+				CreatedAt:  order.CreatedAt,
+			}
+			item.IssuedTickets = append(item.IssuedTickets, issuedTicket)
+		}
+	}
+
+	if input.PromoCode != "" {
+		err = u.applyPromoCode(order, input.PromoCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if input.GiftCardCode != "" {
+		err = u.applyGiftCard(order, input.GiftCardCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = u.orderRepository.Place(order, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = u.ticketRepository.DeductQuantity(ticketDetail.ID, input.Quantity)
+	if order.Status == domain.OrderStatusPending {
+		err = u.startPayment(order)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+func (u *OrderUsecase) startPayment(order *domain.Order) error {
+	if order.ChargedAmount == 0 {
+		return u.orderRepository.Complete(order)
+	}
+
+	intent, err := u.provider.CreateIntent(order.ChargedAmount, order.ChargedCurrency, fmt.Sprintf("Evento order %d", order.ID))
+	if err == nil {
+		providerName := u.provider.Name()
+		order.PaymentProvider = &providerName
+		order.PaymentIntentID = &intent.ID
+		err = u.orderRepository.SetPaymentIntent(order)
+	}
+	if err != nil {
+		releaseErr := releaseOrder(u.orderRepository, u.waitlistRepository, order, domain.OrderStatusFailed)
+		if releaseErr != nil {
+			return errors.Join(err, releaseErr)
+		}
+		return err
+	}
+
+	order.ClientSecret = intent.ClientSecret
+
+	return nil
+}
+
+func (u *OrderUsecase) ExpireUnpaid() error {
+	for {
+		orders, err := u.orderRepository.GetExpired(time.Now(), expiryBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(orders) == 0 {
+			return nil
+		}
+
+		for _, order := range orders {
+			err := releaseOrder(u.orderRepository, u.waitlistRepository, order, domain.OrderStatusExpired)
+			if err != nil && !errors.Is(err, utils.ErrOrderNotPending) {
+				return err
+			}
+		}
+	}
+}
+
+func (u *OrderUsecase) applyPromoCode(order *domain.Order, code string) error {
+	promoCode, err := u.promoCodeRepository.GetByCode(normalizePromoCode(code))
+	if err != nil {
+		return err
+	}
+
+	if !promoCode.AppliesTo(order.EventID) {
+		return utils.ErrPromoCodeNotApplicable
+	}
+
+	if !promoCode.Valid(order.CreatedAt) {
+		return utils.ErrPromoCodeUnavailable
+	}
+
+	order.PromoCodeID = &promoCode.ID
+
+	return nil
+}
+
+func (u *OrderUsecase) applyGiftCard(order *domain.Order, code string) error {
+	giftCard, err := u.giftCardRepository.GetByCode(normalizeGiftCardCode(code))
+	if err != nil {
+		return err
+	}
+
+	if giftCard.Expired(order.CreatedAt) || giftCard.Balance == 0 {
+		return utils.ErrGiftCardUnavailable
+	}
+
+	order.GiftCardID = &giftCard.ID
+
+	return nil
+}
+
+func (u *OrderUsecase) GetTickets(orderID int64, customerID int64) ([]*domain.IssuedTicket, error) {
+	issuedTickets, err := u.heldOrderTickets(orderID, customerID)
 	if err != nil {
 		return nil, err
 	}
 
-	totalPrice := float64(input.Quantity) * ticketDetail.Type.Price
-	_, err = u.customerRepository.DeductBalance(customer.ID, totalPrice)
+	err = u.signTickets(issuedTickets)
 	if err != nil {
-		u.ticketRepository.AddQuantity(ticketDetail.ID, input.Quantity)
+		return nil, err
+	}
+
+	return issuedTickets, nil
+}
 
+func (u *OrderUsecase) heldOrderTickets(orderID int64, customerID int64) ([]*domain.IssuedTicket, error) {
+	order, err := u.orderRepository.GetByID(orderID)
+	if err != nil {
 		return nil, err
 	}
 
-	order := &domain.Order{
-		CustomerID: customer.ID,
-		TicketID:   ticketDetail.ID,
-		Quantity:   input.Quantity,
-		TotalPrice: totalPrice,
-		CreatedAt:  time.Now(),
+	if order.CustomerID != customerID {
+		return nil, utils.ErrOrderNotFound
 	}
 
-	err = u.orderRepository.Add(order)
+	issuedTickets, err := u.issuedTicketRepository.GetByOrderID(order.ID)
 	if err != nil {
-		u.ticketRepository.AddQuantity(ticketDetail.ID, input.Quantity)
-		u.customerRepository.AddBalance(customer.ID, totalPrice)
+		return nil, err
+	}
+
+	ownedTickets := make([]*domain.IssuedTicket, 0, len(issuedTickets))
+	for _, issuedTicket := range issuedTickets {
+		if issuedTicket.CustomerID == customerID {
+			ownedTickets = append(ownedTickets, issuedTicket)
+		}
+	}
 
+	return ownedTickets, nil
+}
+
+func (u *OrderUsecase) GetCustomerTickets(customerID int64) ([]*domain.IssuedTicket, error) {
+	issuedTickets, err := u.issuedTicketRepository.GetByCustomerID(customerID)
+	if err != nil {
 		return nil, err
 	}
 
+	err = u.signTickets(issuedTickets)
+	if err != nil {
+		return nil, err
+	}
+
+	return issuedTickets, nil
+}
+
+func (u *OrderUsecase) RenderOrderTicket(orderID int64, code string, customerID int64) ([]byte, error) {
+	issuedTickets, err := u.heldOrderTickets(orderID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, issuedTicket := range issuedTickets {
+		if issuedTicket.Code == code {
+			return u.renderTicket(issuedTicket)
+		}
+	}
+
+	return nil, utils.ErrIssuedTicketNotFound
+}
+
+func (u *OrderUsecase) RenderOrderTickets(orderID int64, customerID int64) ([]byte, error) {
+	issuedTickets, err := u.heldOrderTickets(orderID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	validTickets := make([]*domain.IssuedTicket, 0, len(issuedTickets))
+	for _, issuedTicket := range issuedTickets {
+		if issuedTicket.Status == domain.IssuedTicketStatusPending {
+			return nil, utils.ErrOrderPaymentPending
+		}
+		if issuedTicket.Status == domain.IssuedTicketStatusValid {
+			validTickets = append(validTickets, issuedTicket)
+		}
+	}
+
+	if len(validTickets) == 0 {
+		return nil, utils.ErrIssuedTicketVoid
+	}
+
+	return u.renderTickets(validTickets)
+}
+
+func (u *OrderUsecase) RenderTicket(code string, customerID int64) ([]byte, error) {
+	issuedTicket, err := u.issuedTicketRepository.GetByCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	if issuedTicket.CustomerID != customerID {
+		return nil, utils.ErrIssuedTicketNotFound
+	}
+
+	return u.renderTicket(issuedTicket)
+}
+
+func (u *OrderUsecase) RenderTickets(customerID int64, eventID *int64) ([]byte, error) {
+	issuedTickets, err := u.issuedTicketRepository.GetByCustomerID(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	validTickets := make([]*domain.IssuedTicket, 0, len(issuedTickets))
+	for _, issuedTicket := range issuedTickets {
+		if eventID != nil && issuedTicket.EventID != *eventID {
+			continue
+		}
+		if issuedTicket.Status == domain.IssuedTicketStatusValid {
+			validTickets = append(validTickets, issuedTicket)
+		}
+	}
+
+	if len(validTickets) == 0 {
+		return nil, utils.ErrIssuedTicketNotFound
+	}
+
+	return u.renderTickets(validTickets)
+}
+
+func (u *OrderUsecase) renderTicket(issuedTicket *domain.IssuedTicket) ([]byte, error) {
+	if issuedTicket.Status == domain.IssuedTicketStatusPending {
+		return nil, utils.ErrOrderPaymentPending
+	}
+
+	if issuedTicket.Status != domain.IssuedTicketStatusValid {
+		return nil, utils.ErrIssuedTicketVoid
+	}
+
+	issuedTickets := []*domain.IssuedTicket{issuedTicket}
+
+	err := u.signTickets(issuedTickets)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := u.ticketCards(issuedTickets)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.RenderTicketPNG(cards[0])
+}
+
+func (u *OrderUsecase) renderTickets(issuedTickets []*domain.IssuedTicket) ([]byte, error) {
+	err := u.signTickets(issuedTickets)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := u.ticketCards(issuedTickets)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.RenderTicketsPDF(cards)
+}
+
+func (u *OrderUsecase) Cancel(orderID int64, customerID int64) (*domain.Order, error) {
+	order, err := u.orderRepository.GetByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.CustomerID != customerID {
+		return nil, utils.ErrOrderNotFound
+	}
+
+	event, err := u.eventRepository.GetByID(order.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !time.Now().Before(event.StartsAt) {
+		return nil, utils.ErrOrderNotCancellable
+	}
+
+	err = u.orderRepository.Cancel(order)
+	if err != nil {
+		return nil, err
+	}
+
+	sendOrderRefunds(u.provider, u.paymentRefundRepository, order)
+
+	offerReturnedStock(u.waitlistRepository, orderTicketIDs(order)...)
+
 	return order, nil
 }
 
+func releaseOrder(
+	orderRepository repository.IOrderRepository,
+	waitlistRepository repository.IWaitlistRepository,
+	order *domain.Order,
+	status domain.OrderStatus,
+) error {
+	err := orderRepository.Release(order, status)
+	if err != nil {
+		return err
+	}
+
+	offerReturnedStock(waitlistRepository, orderTicketIDs(order)...)
+
+	return nil
+}
+
+func orderTicketIDs(order *domain.Order) []int64 {
+	ticketIDs := make([]int64, 0, len(order.Items))
+	for _, item := range order.Items {
+		ticketIDs = append(ticketIDs, item.TicketID)
+	}
+	return ticketIDs
+}
+
+func (u *OrderUsecase) signTickets(issuedTickets []*domain.IssuedTicket) error {
+	for _, issuedTicket := range issuedTickets {
+		if issuedTicket.Status != domain.IssuedTicketStatusValid {
+			continue
+		}
+
+		payload := &utils.TicketTokenPayload{
+			Code:     issuedTicket.Code,
+			EventID:  issuedTicket.EventID,
+			TicketID: issuedTicket.TicketID,
+			IssuedAt: issuedTicket.CreatedAt.Unix(),
+		}
+
+		var err error
+		issuedTicket.Token, err = utils.SignTicketToken(u.ticketSigningKey, payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *OrderUsecase) ticketCards(issuedTickets []*domain.IssuedTicket) ([]*utils.TicketCard, error) {
+	events := make(map[int64]*domain.Event)
+	ticketDetails := make(map[int64]*domain.TicketDetail)
+
+	seatIDs := make([]int64, 0)
+	for _, issuedTicket := range issuedTickets {
+		if issuedTicket.SeatID != nil {
+			seatIDs = append(seatIDs, *issuedTicket.SeatID)
+		}
+	}
+
+	seats := make(map[int64]*domain.Seat)
+	if len(seatIDs) > 0 {
+		seatList, err := u.venueRepository.GetSeatsByIDs(seatIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, seat := range seatList {
+			seats[seat.ID] = seat
+		}
+	}
+
+	cards := make([]*utils.TicketCard, 0, len(issuedTickets))
+	for _, issuedTicket := range issuedTickets {
+		event, ok := events[issuedTicket.EventID]
+		if !ok {
+			var err error
+			event, err = u.eventRepository.GetByID(issuedTicket.EventID)
+			if err != nil {
+				return nil, err
+			}
+			events[issuedTicket.EventID] = event
+		}
+
+		ticketDetail, ok := ticketDetails[issuedTicket.TicketID]
+		if !ok {
+			var err error
+			ticketDetail, err = u.ticketRepository.GetByID(issuedTicket.TicketID)
+			if err != nil {
+				return nil, err
+			}
+			ticketDetails[issuedTicket.TicketID] = ticketDetail
+		}
+
+		card := &utils.TicketCard{
+			EventName: event.Name,
+			EventDate: event.StartsAt.In(event.Location()),
+			Category:  string(ticketDetail.Type.Name),
+			Code:      issuedTicket.Code,
+			Token:     issuedTicket.Token,
+		}
+
+		if issuedTicket.SeatID != nil {
+			if seat, ok := seats[*issuedTicket.SeatID]; ok {
+				card.Seat = fmt.Sprintf("Section %s, Row %s, Seat %d", seat.Section, seat.Row, seat.Number)
+			}
+		}
+
+		cards = append(cards, card)
+	}
+
+	return cards, nil
+}
+
+func (u *OrderUsecase) checkSalesWindow(ticketDetail *domain.TicketDetail, customerID int64, accessCode string) error {
+	now := time.Now()
+
+	if ticketDetail.SalesEnd != nil && !now.Before(*ticketDetail.SalesEnd) {
+		return utils.ErrTicketSalesEnded
+	}
+
+	if ticketDetail.OnSale(now) {
+		return nil
+	}
+
+	presales, err := u.presaleRepository.GetActiveByTicketID(ticketDetail.ID, now)
+	if err != nil {
+		return err
+	}
+
+	if len(presales) == 0 {
+		return utils.ErrTicketSalesNotStarted
+	}
+
+	for _, presale := range presales {
+		if presale.Allows(customerID, accessCode) {
+			return nil
+		}
+	}
+
+	return utils.ErrPresaleAccessDenied
+}
+
 func (u *OrderUsecase) DeleteAll() error {
-	err := u.orderRepository.DeleteAll()
+	orders, err := u.orderRepository.DeleteAll()
 	if err != nil {
 		return err
 	}
 
+	ticketIDs := make([]int64, 0)
+	for _, order := range orders {
+		ticketIDs = append(ticketIDs, orderTicketIDs(order)...)
+	}
+	slices.Sort(ticketIDs)
+
+	offerReturnedStock(u.waitlistRepository, slices.Compact(ticketIDs)...)
+
 	return nil
 }
+
+func mergeLineItems(itemInputs []*request.OrderItemRequest) []*request.OrderItemRequest {
+	merged := make([]*request.OrderItemRequest, 0, len(itemInputs))
+	byTicketID := make(map[int64]*request.OrderItemRequest)
+
+	for _, itemInput := range itemInputs {
+		if existing, ok := byTicketID[itemInput.TicketID]; ok {
+			existing.Quantity += itemInput.Quantity
+			existing.SeatIDs = append(existing.SeatIDs, itemInput.SeatIDs...)
+			continue
+		}
+
+		item := &request.OrderItemRequest{
+			TicketID: itemInput.TicketID,
+			Quantity: itemInput.Quantity,
+			SeatIDs:  append([]int64(nil), itemInput.SeatIDs...),
+		}
+		merged = append(merged, item)
+		byTicketID[item.TicketID] = item
+	}
+
+	return merged
+}
+
+func validSeatSelection(itemInput *request.OrderItemRequest) bool {
+	if len(itemInput.SeatIDs) != itemInput.Quantity {
+		return false
+	}
+
+	seen := make(map[int64]bool, len(itemInput.SeatIDs))
+	for _, seatID := range itemInput.SeatIDs {
+		if seen[seatID] {
+			return false
+		}
+		seen[seatID] = true
+	}
+
+	return true
+}
@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/payment"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+	"github.com/rs/zerolog/log"
+)
+
+const paymentRefundBatchSize = 100
+
+type PaymentUsecase struct {
+	provider                payment.PaymentProvider
+	topUpRepository         repository.ITopUpRepository
+	customerRepository      repository.ICustomerRepository
+	orderRepository         repository.IOrderRepository
+	waitlistRepository      repository.IWaitlistRepository
+	paymentRefundRepository repository.IPaymentRefundRepository
+}
+
+func NewPaymentUsecase(
+	provider payment.PaymentProvider,
+	topUpRepository repository.ITopUpRepository,
+	customerRepository repository.ICustomerRepository,
+	orderRepository repository.IOrderRepository,
+	waitlistRepository repository.IWaitlistRepository,
+	paymentRefundRepository repository.IPaymentRefundRepository,
+) IPaymentUsecase {
+	return &PaymentUsecase{
+		provider:                provider,
+		topUpRepository:         topUpRepository,
+		customerRepository:      customerRepository,
+		orderRepository:         orderRepository,
+		waitlistRepository:      waitlistRepository,
+		paymentRefundRepository: paymentRefundRepository,
+	}
+}
+
+func (u *PaymentUsecase) GetTopUps(customerID int64) ([]*domain.TopUp, error) {
+	return u.topUpRepository.GetByCustomerID(customerID)
+}
+
+func (u *PaymentUsecase) AddTopUp(input *request.TopUpRequest, customerID int64) (*domain.TopUp, error) {
+	if u.provider == nil {
+		return nil, utils.ErrPaymentProviderDisabled
+	}
+
+	customer, err := u.customerRepository.GetByID(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := domain.RoundCents(input.Amount)
+
+	intent, err := u.provider.CreateIntent(amount, customer.Currency, "Evento balance top-up")
+	if err != nil {
+		return nil, err
+	}
+
+	topUp := &domain.TopUp{
+		CustomerID:   customer.ID,
+		Amount:       amount,
+		Currency:     customer.Currency,
+		Provider:     u.provider.Name(),
+		IntentID:     intent.ID,
+		ClientSecret: intent.ClientSecret,
+		Status:       domain.TopUpStatusPending,
+		CreatedAt:    time.Now(),
+	}
+
+	err = u.topUpRepository.Add(topUp)
+	if err != nil {
+		return nil, err
+	}
+
+	return topUp, nil
+}
+
+func (u *PaymentUsecase) RefundTopUp(topUpID int64) (*domain.TopUp, error) {
+	topUp, err := u.topUpRepository.GetByID(topUpID)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.provider == nil || topUp.Provider != u.provider.Name() {
+		return nil, utils.ErrTopUpNotRefundable
+	}
+
+	err = u.topUpRepository.Refund(topUp)
+	if err != nil {
+		return nil, err
+	}
+
+	return topUp, nil
+}
+
+// Providers retry callbacks, so a payment already settled the same way is left
+// as it is.
+func (u *PaymentUsecase) HandleWebhook(payload []byte, signature string) error {
+	if u.provider == nil {
+		return utils.ErrPaymentProviderDisabled
+	}
+
+	event, err := u.provider.ParseWebhook(payload, signature)
+	if err != nil {
+		switch {
+		case errors.Is(err, payment.ErrInvalidSignature):
+			return utils.ErrInvalidWebhookSignature
+		default:
+			return err
+		}
+	}
+
+	topUp, err := u.topUpRepository.GetByIntentID(u.provider.Name(), event.IntentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrTopUpNotFound):
+			return u.settleOrder(event)
+		default:
+			return err
+		}
+	}
+
+	switch event.Type {
+	case payment.EventPaymentSucceeded:
+		if topUp.Status == domain.TopUpStatusSucceeded || topUp.Status == domain.TopUpStatusRefunded {
+			return nil
+		}
+
+		if domain.RoundCents(event.Amount) != domain.RoundCents(topUp.Amount) || event.Currency != topUp.Currency {
+			return utils.ErrPaymentMismatch
+		}
+
+		return u.topUpRepository.Confirm(topUp)
+	case payment.EventPaymentFailed:
+		if topUp.Status == domain.TopUpStatusFailed {
+			return nil
+		}
+
+		return u.topUpRepository.Fail(topUp)
+	default:
+		return nil
+	}
+}
+
+// A payment arriving after the order expired or its event was cancelled is owed
+// back in the same transaction, so the refund is never lost to a crash.
+func (u *PaymentUsecase) settleOrder(event *payment.Event) error {
+	order, err := u.orderRepository.GetByPaymentIntentID(u.provider.Name(), event.IntentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrOrderNotFound):
+			return utils.ErrPaymentNotFound
+		default:
+			return err
+		}
+	}
+
+	switch event.Type {
+	case payment.EventPaymentSucceeded:
+		switch order.Status {
+		case domain.OrderStatusFailed, domain.OrderStatusExpired:
+			err = u.orderRepository.RequestRefund(order)
+			if err != nil {
+				return err
+			}
+			sendOrderRefunds(u.provider, u.paymentRefundRepository, order)
+			return nil
+		case domain.OrderStatusPending:
+		default:
+			return nil
+		}
+
+		if domain.RoundCents(event.Amount) != domain.RoundCents(order.ChargedAmount) || event.Currency != order.ChargedCurrency {
+			return utils.ErrPaymentMismatch
+		}
+
+		err = u.orderRepository.Complete(order)
+		if errors.Is(err, utils.ErrEventCancelled) {
+			err = releaseOrder(u.orderRepository, u.waitlistRepository, order, domain.OrderStatusRefundPending)
+			if err != nil {
+				return err
+			}
+			sendOrderRefunds(u.provider, u.paymentRefundRepository, order)
+			return nil
+		}
+
+		return err
+	case payment.EventPaymentFailed:
+		if order.Status != domain.OrderStatusPending {
+			return nil
+		}
+
+		return releaseOrder(u.orderRepository, u.waitlistRepository, order, domain.OrderStatusFailed)
+	default:
+		return nil
+	}
+}
+
+func (u *PaymentUsecase) ProcessRefunds() error {
+	if u.provider == nil {
+		return nil
+	}
+
+	var afterID int64
+
+	for {
+		refunds, err := u.paymentRefundRepository.GetPending(u.provider.Name(), afterID, paymentRefundBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(refunds) == 0 {
+			return nil
+		}
+
+		for _, refund := range refunds {
+			afterID = refund.ID
+
+			err := sendRefund(u.provider, u.paymentRefundRepository, refund)
+			if err != nil {
+				log.Error().Interface("order_id", refund.OrderID).Interface("top_up_id", refund.TopUpID).Int64("refund_id", refund.ID).Msg(err.Error())
+			}
+		}
+	}
+}
+
+func sendOrderRefunds(provider payment.PaymentProvider, paymentRefundRepository repository.IPaymentRefundRepository, order *domain.Order) {
+	if provider == nil || order.PaymentMethod != domain.PaymentMethodProvider {
+		return
+	}
+
+	refunds, err := paymentRefundRepository.GetPendingByOrderID(order.ID)
+	if err != nil {
+		log.Error().Int64("order_id", order.ID).Msg(err.Error())
+		return
+	}
+
+	for _, refund := range refunds {
+		if refund.Provider != provider.Name() {
+			continue
+		}
+
+		err := sendRefund(provider, paymentRefundRepository, refund)
+		if err != nil {
+			log.Error().Int64("order_id", order.ID).Int64("refund_id", refund.ID).Msg(err.Error())
+		}
+	}
+}
+
+func sendRefund(provider payment.PaymentProvider, paymentRefundRepository repository.IPaymentRefundRepository, refund *domain.PaymentRefund) error {
+	err := provider.Refund(refund.IntentID, refund.Amount, refund.IdempotencyKey())
+	if err != nil {
+		failErr := paymentRefundRepository.Fail(refund, err.Error())
+		if failErr != nil {
+			return errors.Join(err, failErr)
+		}
+		return err
+	}
+
+	return paymentRefundRepository.Complete(refund)
+}
+
+func (u *PaymentUsecase) Simulate(input *request.PaymentSimulationRequest) error {
+	simulator, ok := u.provider.(payment.Simulator)
+	if !ok {
+		return utils.ErrPaymentSimulationDisabled
+	}
+
+	payload, signature, err := simulator.Simulate(input.IntentID, input.Outcome)
+	if err != nil {
+		switch {
+		case errors.Is(err, payment.ErrIntentNotFound):
+			return utils.ErrPaymentNotFound
+		case errors.Is(err, payment.ErrIntentCompleted):
+			return utils.ErrPaymentSettled
+		default:
+			return err
+		}
+	}
+
+	return u.HandleWebhook(payload, signature)
+}
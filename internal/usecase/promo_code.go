@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/config"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+)
+
+type PromoCodeUsecase struct {
+	config              *config.Config
+	promoCodeRepository repository.IPromoCodeRepository
+}
+
+func NewPromoCodeUsecase(config *config.Config, promoCodeRepository repository.IPromoCodeRepository) IPromoCodeUsecase {
+	return &PromoCodeUsecase{
+		config:              config,
+		promoCodeRepository: promoCodeRepository,
+	}
+}
+
+func (u *PromoCodeUsecase) GetAll() ([]*domain.PromoCode, error) {
+	return u.promoCodeRepository.GetAll()
+}
+
+func (u *PromoCodeUsecase) GetByID(promoCodeID int64) (*domain.PromoCode, error) {
+	return u.promoCodeRepository.GetByID(promoCodeID)
+}
+
+func (u *PromoCodeUsecase) Add(input *request.PromoCodeRequest) (*domain.PromoCode, error) {
+	promoCode := &domain.PromoCode{
+		Code:           normalizePromoCode(input.Code),
+		DiscountType:   input.DiscountType,
+		DiscountValue:  input.DiscountValue,
+		EventID:        input.EventID,
+		MaxRedemptions: input.MaxRedemptions,
+		MaxPerCustomer: input.MaxPerCustomer,
+		ValidFrom:      input.ValidFrom,
+		ValidUntil:     input.ValidUntil,
+	}
+	if input.DiscountType == domain.DiscountTypeFixed {
+		currency := normalizeCurrency(input.Currency, u.config.Currency)
+		promoCode.Currency = &currency
+	}
+
+	err := u.promoCodeRepository.Add(promoCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return promoCode, nil
+}
+
+func (u *PromoCodeUsecase) UpdateLimits(promoCodeID int64, input *request.PromoCodeLimitsRequest) (*domain.PromoCode, error) {
+	promoCode, err := u.promoCodeRepository.GetByID(promoCodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	promoCode.MaxRedemptions = input.MaxRedemptions
+	promoCode.MaxPerCustomer = input.MaxPerCustomer
+	promoCode.ValidFrom = input.ValidFrom
+	promoCode.ValidUntil = input.ValidUntil
+
+	err = u.promoCodeRepository.Update(promoCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return promoCode, nil
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/config"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type ResaleListingUsecase struct {
+	maxPriceRatio           float64
+	feeRate                 float64
+	transferCutoff          time.Duration
+	resaleListingRepository repository.IResaleListingRepository
+	issuedTicketRepository  repository.IIssuedTicketRepository
+	ticketRepository        repository.ITicketRepository
+	eventRepository         repository.IEventRepository
+}
+
+func NewResaleListingUsecase(
+	config *config.Config,
+	resaleListingRepository repository.IResaleListingRepository,
+	issuedTicketRepository repository.IIssuedTicketRepository,
+	ticketRepository repository.ITicketRepository,
+	eventRepository repository.IEventRepository,
+) IResaleListingUsecase {
+	return &ResaleListingUsecase{
+		maxPriceRatio:           config.Resale.MaxPriceRatio,
+		feeRate:                 config.Resale.FeeRate,
+		transferCutoff:          config.Transfers.Cutoff,
+		resaleListingRepository: resaleListingRepository,
+		issuedTicketRepository:  issuedTicketRepository,
+		ticketRepository:        ticketRepository,
+		eventRepository:         eventRepository,
+	}
+}
+
+func (u *ResaleListingUsecase) GetAll() ([]*domain.ResaleListing, error) {
+	return u.resaleListingRepository.GetListed()
+}
+
+func (u *ResaleListingUsecase) Add(input *request.ResaleListingRequest, sellerID int64) (*domain.ResaleListing, error) {
+	issuedTicket, err := u.issuedTicketRepository.GetByCode(input.TicketCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if issuedTicket.CustomerID != sellerID {
+		return nil, utils.ErrIssuedTicketNotFound
+	}
+
+	err = checkTicketsTransferable(u.eventRepository, []*domain.IssuedTicket{issuedTicket})
+	if err != nil {
+		return nil, err
+	}
+
+	ticketDetail, err := u.ticketRepository.GetByID(issuedTicket.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	maxPrice := domain.RoundCents(ticketDetail.Type.Price * u.maxPriceRatio)
+	if input.Price > maxPrice {
+		return nil, fmt.Errorf("%w: the price should be at most %.2f", utils.ErrResalePriceTooHigh, maxPrice)
+	}
+
+	listing := &domain.ResaleListing{
+		IssuedTicketID: issuedTicket.ID,
+		EventID:        issuedTicket.EventID,
+		TicketID:       issuedTicket.TicketID,
+		SellerID:       sellerID,
+		Price:          input.Price,
+		Fee:            domain.RoundCents(input.Price * u.feeRate),
+		Currency:       ticketDetail.Type.Currency,
+		Status:         domain.ResaleListingStatusListed,
+		CreatedAt:      time.Now(),
+	}
+
+	err = u.resaleListingRepository.Add(listing, time.Now().Add(u.transferCutoff))
+	if err != nil {
+		return nil, err
+	}
+
+	return listing, nil
+}
+
+func (u *ResaleListingUsecase) Purchase(listingID int64, buyerID int64) (*domain.ResaleListing, error) {
+	listing, err := u.resaleListingRepository.GetByID(listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if listing.Status != domain.ResaleListingStatusListed {
+		return nil, utils.ErrResaleListingNotAvailable
+	}
+
+	if listing.SellerID == buyerID {
+		return nil, utils.ErrResaleOwnListing
+	}
+
+	issuedTickets, err := u.issuedTicketRepository.GetByIDs([]int64{listing.IssuedTicketID})
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkTicketsTransferable(u.eventRepository, issuedTickets)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := utils.GenerateTicketCode()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.resaleListingRepository.Purchase(listing, buyerID, code, time.Now().Add(u.transferCutoff))
+	if err != nil {
+		return nil, err
+	}
+
+	return listing, nil
+}
+
+func (u *ResaleListingUsecase) Cancel(listingID int64, sellerID int64) (*domain.ResaleListing, error) {
+	listing, err := u.resaleListingRepository.GetByID(listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if listing.SellerID != sellerID {
+		return nil, utils.ErrResaleListingNotFound
+	}
+
+	err = u.resaleListingRepository.Cancel(listing)
+	if err != nil {
+		return nil, err
+	}
+
+	return listing, nil
+}
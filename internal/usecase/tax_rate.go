@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+)
+
+type TaxRateUsecase struct {
+	taxRateRepository repository.ITaxRateRepository
+}
+
+func NewTaxRateUsecase(taxRateRepository repository.ITaxRateRepository) ITaxRateUsecase {
+	return &TaxRateUsecase{
+		taxRateRepository: taxRateRepository,
+	}
+}
+
+func (u *TaxRateUsecase) GetAll() ([]*domain.TaxRate, error) {
+	return u.taxRateRepository.GetAll()
+}
+
+func (u *TaxRateUsecase) Add(input *request.TaxRateRequest) (*domain.TaxRate, error) {
+	taxRate := &domain.TaxRate{
+		Jurisdiction: *normalizeJurisdiction(input.Jurisdiction),
+		Name:         strings.TrimSpace(input.Name),
+		Rate:         input.Rate,
+	}
+
+	err := u.taxRateRepository.Add(taxRate)
+	if err != nil {
+		return nil, err
+	}
+
+	return taxRate, nil
+}
+
+func (u *TaxRateUsecase) Update(taxRateID int64, input *request.TaxRateUpdateRequest) (*domain.TaxRate, error) {
+	taxRate := &domain.TaxRate{
+		ID:   taxRateID,
+		Name: strings.TrimSpace(input.Name),
+		Rate: input.Rate,
+	}
+
+	err := u.taxRateRepository.Update(taxRate)
+	if err != nil {
+		return nil, err
+	}
+
+	return taxRate, nil
+}
+
+func (u *TaxRateUsecase) Delete(taxRateID int64) error {
+	return u.taxRateRepository.Delete(taxRateID)
+}
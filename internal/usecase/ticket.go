@@ -1,8 +1,12 @@
 package usecase
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
 	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
 	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
 )
@@ -11,17 +15,29 @@ type TicketUsecase struct {
 	ticketRepository     repository.ITicketRepository
 	ticketTypeRepository repository.ITicketTypeRepository
 	eventRepository      repository.IEventRepository
+	presaleRepository    repository.IPresaleRepository
+	priceTierRepository  repository.IPriceTierRepository
+	waitlistRepository   repository.IWaitlistRepository
+	venueRepository      repository.IVenueRepository
 }
 
 func NewTicketUsecase(
 	ticketRepository repository.ITicketRepository,
 	ticketTypeRepository repository.ITicketTypeRepository,
 	eventRepository repository.IEventRepository,
+	presaleRepository repository.IPresaleRepository,
+	priceTierRepository repository.IPriceTierRepository,
+	waitlistRepository repository.IWaitlistRepository,
+	venueRepository repository.IVenueRepository,
 ) ITicketUsecase {
 	return &TicketUsecase{
 		ticketRepository:     ticketRepository,
 		ticketTypeRepository: ticketTypeRepository,
 		eventRepository:      eventRepository,
+		presaleRepository:    presaleRepository,
+		priceTierRepository:  priceTierRepository,
+		waitlistRepository:   waitlistRepository,
+		venueRepository:      venueRepository,
 	}
 }
 
@@ -36,9 +52,12 @@ func (u *TicketUsecase) Add(input *request.TicketRequest) (*domain.Ticket, error
 	}
 
 	ticket := &domain.Ticket{
-		EventID:      input.EventID,
-		TicketTypeID: ticketType.ID,
-		Quantity:     input.Quantity,
+		EventID:        input.EventID,
+		TicketTypeID:   ticketType.ID,
+		Quantity:       input.Quantity,
+		SalesStart:     input.SalesStart,
+		SalesEnd:       input.SalesEnd,
+		MaxPerCustomer: input.MaxPerCustomer,
 	}
 
 	err = u.ticketRepository.Add(ticket)
@@ -69,6 +88,9 @@ func (u *TicketUsecase) UpdateQuantity(ticketID int64, input *request.TicketQuan
 	switch input.Action {
 	case request.ActionAdd:
 		ticket, err = u.ticketRepository.AddQuantity(ticketID, input.Quantity)
+		if err == nil {
+			offerReturnedStock(u.waitlistRepository, ticketID)
+		}
 	case request.ActionDeduct:
 		ticket, err = u.ticketRepository.DeductQuantity(ticketID, input.Quantity)
 	default:
@@ -77,3 +99,161 @@ func (u *TicketUsecase) UpdateQuantity(ticketID int64, input *request.TicketQuan
 
 	return ticket, err
 }
+
+func (u *TicketUsecase) UpdateSalesWindow(ticketID int64, input *request.TicketSalesWindowRequest) (*domain.Ticket, error) {
+	return u.ticketRepository.UpdateSalesWindow(ticketID, input.SalesStart, input.SalesEnd)
+}
+
+func (u *TicketUsecase) UpdatePurchaseLimit(ticketID int64, input *request.TicketPurchaseLimitRequest) (*domain.Ticket, error) {
+	return u.ticketRepository.UpdatePurchaseLimit(ticketID, input.MaxPerCustomer)
+}
+
+func (u *TicketUsecase) GetPresales(ticketID int64) ([]*domain.Presale, error) {
+	_, err := u.ticketRepository.GetByID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.presaleRepository.GetByTicketID(ticketID)
+}
+
+func (u *TicketUsecase) AddPresale(ticketID int64, input *request.PresaleRequest) (*domain.Presale, error) {
+	_, err := u.ticketRepository.GetByID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	customerIDs := input.CustomerIDs
+	if customerIDs == nil {
+		customerIDs = make([]int64, 0)
+	}
+
+	presale := &domain.Presale{
+		TicketID:    ticketID,
+		Name:        input.Name,
+		StartsAt:    input.StartsAt,
+		EndsAt:      input.EndsAt,
+		AccessCode:  input.AccessCode,
+		CustomerIDs: customerIDs,
+	}
+
+	err = u.presaleRepository.Add(presale)
+	if err != nil {
+		return nil, err
+	}
+
+	return presale, nil
+}
+
+func (u *TicketUsecase) GetPrice(ticketID int64) (*response.TicketPriceResponse, error) {
+	ticket, err := u.ticketRepository.GetByID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	tiers, err := u.priceTierRepository.GetByTicketID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, next := domain.CurrentPrices(tiers, ticket.Type.Price, time.Now())
+
+	return &response.TicketPriceResponse{
+		TicketID: ticket.ID,
+		Current:  current,
+		Next:     next,
+	}, nil
+}
+
+func (u *TicketUsecase) GetPriceTiers(ticketID int64) ([]*domain.PriceTier, error) {
+	_, err := u.ticketRepository.GetByID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.priceTierRepository.GetByTicketID(ticketID)
+}
+
+func (u *TicketUsecase) AddPriceTier(ticketID int64, input *request.PriceTierRequest) (*domain.PriceTier, error) {
+	tier := &domain.PriceTier{
+		TicketID: ticketID,
+		Name:     input.Name,
+		Price:    domain.RoundCents(input.Price),
+		EndsAt:   input.EndsAt,
+		MaxSold:  input.MaxSold,
+	}
+
+	err := u.priceTierRepository.Add(tier)
+	if err != nil {
+		return nil, err
+	}
+
+	return tier, nil
+}
+
+func (u *TicketUsecase) DeletePriceTier(ticketID int64, tierID int64) (*domain.PriceTier, error) {
+	return u.priceTierRepository.Delete(ticketID, tierID)
+}
+
+func (u *TicketUsecase) AssignSeats(ticketID int64, input *request.TicketSeatsRequest) (*domain.Ticket, error) {
+	ticketDetail, err := u.ticketRepository.GetByID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := u.eventRepository.GetByID(ticketDetail.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.VenueID == nil {
+		return nil, utils.ErrEventWithoutVenue
+	}
+
+	venueSections, err := u.venueRepository.GetSections(*event.VenueID)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make(map[string]*domain.VenueSection)
+	for _, section := range venueSections {
+		sections[section.Name] = section
+	}
+
+	seatIDs := make([]int64, 0)
+	assigned := make(map[int64]bool)
+
+	for _, block := range input.Blocks {
+		section, ok := sections[block.Section]
+		if !ok {
+			return nil, fmt.Errorf("%w: no section %q", utils.ErrSeatNotFound, block.Section)
+		}
+
+		rows := make(map[string]bool)
+		for _, row := range block.Rows {
+			rows[row] = false
+		}
+
+		for _, seat := range section.Seats {
+			if len(rows) > 0 {
+				if _, ok := rows[seat.Row]; !ok {
+					continue
+				}
+				rows[seat.Row] = true
+			}
+
+			if !assigned[seat.ID] {
+				assigned[seat.ID] = true
+				seatIDs = append(seatIDs, seat.ID)
+			}
+		}
+
+		for _, row := range block.Rows {
+			if !rows[row] {
+				return nil, fmt.Errorf("%w: no row %q in section %q", utils.ErrSeatNotFound, row, block.Section)
+			}
+		}
+	}
+
+	return u.ticketRepository.AssignSeats(ticketID, seatIDs)
+}
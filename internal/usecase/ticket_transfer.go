@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/config"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type TicketTransferUsecase struct {
+	transferCutoff           time.Duration
+	ticketTransferRepository repository.ITicketTransferRepository
+	customerRepository       repository.ICustomerRepository
+	eventRepository          repository.IEventRepository
+	orderRepository          repository.IOrderRepository
+	issuedTicketRepository   repository.IIssuedTicketRepository
+}
+
+func NewTicketTransferUsecase(
+	config *config.Config,
+	ticketTransferRepository repository.ITicketTransferRepository,
+	customerRepository repository.ICustomerRepository,
+	eventRepository repository.IEventRepository,
+	orderRepository repository.IOrderRepository,
+	issuedTicketRepository repository.IIssuedTicketRepository,
+) ITicketTransferUsecase {
+	return &TicketTransferUsecase{
+		transferCutoff:           config.Transfers.Cutoff,
+		ticketTransferRepository: ticketTransferRepository,
+		customerRepository:       customerRepository,
+		eventRepository:          eventRepository,
+		orderRepository:          orderRepository,
+		issuedTicketRepository:   issuedTicketRepository,
+	}
+}
+
+func (u *TicketTransferUsecase) GetAll(customerID int64) ([]*domain.TicketTransfer, error) {
+	return u.ticketTransferRepository.GetByCustomerID(customerID)
+}
+
+func (u *TicketTransferUsecase) Add(input *request.TicketTransferRequest, senderID int64) (*domain.TicketTransfer, error) {
+	recipient, err := u.customerRepository.GetByUsername(input.RecipientUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	if recipient.ID == senderID {
+		return nil, utils.ErrTicketTransferToSelf
+	}
+
+	var issuedTickets []*domain.IssuedTicket
+	if input.OrderID != 0 {
+		issuedTickets, err = u.getOrderTickets(input.OrderID, senderID)
+	} else {
+		issuedTickets, err = u.getTicketsByCode(input.TicketCodes, senderID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(issuedTickets) == 0 {
+		return nil, utils.ErrTicketNotTransferable
+	}
+
+	err = checkTicketsTransferable(u.eventRepository, issuedTickets)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := &domain.TicketTransfer{
+		SenderID:        senderID,
+		RecipientID:     recipient.ID,
+		Status:          domain.TicketTransferStatusPending,
+		CreatedAt:       time.Now(),
+		IssuedTicketIDs: make([]int64, 0, len(issuedTickets)),
+	}
+	for _, issuedTicket := range issuedTickets {
+		transfer.IssuedTicketIDs = append(transfer.IssuedTicketIDs, issuedTicket.ID)
+	}
+
+	err = u.ticketTransferRepository.Add(transfer, time.Now().Add(u.transferCutoff))
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (u *TicketTransferUsecase) Accept(transferID int64, recipientID int64) (*domain.TicketTransfer, error) {
+	transfer, err := u.ticketTransferRepository.GetByID(transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.RecipientID != recipientID {
+		return nil, utils.ErrTicketTransferNotFound
+	}
+
+	if transfer.Status != domain.TicketTransferStatusPending {
+		return nil, utils.ErrTicketTransferNotPending
+	}
+
+	issuedTickets, err := u.issuedTicketRepository.GetByIDs(transfer.IssuedTicketIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkTicketsTransferable(u.eventRepository, issuedTickets)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make(map[int64]string, len(issuedTickets))
+	for _, issuedTicket := range issuedTickets {
+		codes[issuedTicket.ID], err = utils.GenerateTicketCode()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = u.ticketTransferRepository.Accept(transfer, codes, time.Now().Add(u.transferCutoff))
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (u *TicketTransferUsecase) Cancel(transferID int64, customerID int64) (*domain.TicketTransfer, error) {
+	transfer, err := u.ticketTransferRepository.GetByID(transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	var status domain.TicketTransferStatus
+	switch customerID {
+	case transfer.SenderID:
+		status = domain.TicketTransferStatusCancelled
+	case transfer.RecipientID:
+		status = domain.TicketTransferStatusDeclined
+	default:
+		return nil, utils.ErrTicketTransferNotFound
+	}
+
+	err = u.ticketTransferRepository.Close(transfer, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (u *TicketTransferUsecase) getOrderTickets(orderID int64, senderID int64) ([]*domain.IssuedTicket, error) {
+	order, err := u.orderRepository.GetByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.CustomerID != senderID {
+		return nil, utils.ErrOrderNotFound
+	}
+
+	issuedTickets, err := u.issuedTicketRepository.GetByOrderID(order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ownedTickets := make([]*domain.IssuedTicket, 0, len(issuedTickets))
+	for _, issuedTicket := range issuedTickets {
+		if issuedTicket.CustomerID == senderID && issuedTicket.Status == domain.IssuedTicketStatusValid && issuedTicket.CheckedInAt == nil {
+			ownedTickets = append(ownedTickets, issuedTicket)
+		}
+	}
+
+	return ownedTickets, nil
+}
+
+func (u *TicketTransferUsecase) getTicketsByCode(codes []string, senderID int64) ([]*domain.IssuedTicket, error) {
+	issuedTickets := make([]*domain.IssuedTicket, 0, len(codes))
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		issuedTicket, err := u.issuedTicketRepository.GetByCode(code)
+		if err != nil {
+			return nil, err
+		}
+
+		if issuedTicket.CustomerID != senderID {
+			return nil, utils.ErrIssuedTicketNotFound
+		}
+
+		issuedTickets = append(issuedTickets, issuedTicket)
+	}
+
+	return issuedTickets, nil
+}
+
+func checkTicketsTransferable(eventRepository repository.IEventRepository, issuedTickets []*domain.IssuedTicket) error {
+	events := make(map[int64]*domain.Event)
+	for _, issuedTicket := range issuedTickets {
+		if issuedTicket.Status != domain.IssuedTicketStatusValid {
+			return utils.ErrIssuedTicketVoid
+		}
+
+		if issuedTicket.CheckedInAt != nil {
+			return utils.ErrTicketNotTransferable
+		}
+
+		if _, ok := events[issuedTicket.EventID]; ok {
+			continue
+		}
+
+		event, err := eventRepository.GetByID(issuedTicket.EventID)
+		if err != nil {
+			return err
+		}
+		events[event.ID] = event
+
+		if event.Status == domain.EventStatusCancelled {
+			return utils.ErrEventCancelled
+		}
+	}
+
+	return nil
+}
@@ -1,18 +1,21 @@
 package usecase
 
 import (
+	"github.com/nadiannis/evento-api-fr-auth/internal/config"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
 	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
 )
 
 type TicketTypeUsecase struct {
-	repository repository.ITicketTypeRepository
+	repository      repository.ITicketTypeRepository
+	defaultCurrency string
 }
 
-func NewTicketTypeUsecase(repository repository.ITicketTypeRepository) ITicketTypeUsecase {
+func NewTicketTypeUsecase(config *config.Config, repository repository.ITicketTypeRepository) ITicketTypeUsecase {
 	return &TicketTypeUsecase{
-		repository: repository,
+		repository:      repository,
+		defaultCurrency: config.Currency,
 	}
 }
 
@@ -22,8 +25,9 @@ func (u *TicketTypeUsecase) GetAll() ([]*domain.TicketType, error) {
 
 func (u *TicketTypeUsecase) Add(input *request.TicketTypeRequest) (*domain.TicketType, error) {
 	ticketType := &domain.TicketType{
-		Name:  input.Name,
-		Price: input.Price,
+		Name:     input.Name,
+		Price:    input.Price,
+		Currency: normalizeCurrency(input.Currency, u.defaultCurrency),
 	}
 
 	err := u.repository.Add(ticketType)
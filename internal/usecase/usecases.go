@@ -2,28 +2,107 @@ package usecase
 
 import (
 	"github.com/nadiannis/evento-api-fr-auth/internal/config"
+	"github.com/nadiannis/evento-api-fr-auth/internal/payment"
 	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/storage"
 )
 
 type Usecases struct {
-	Customers   ICustomerUsecase
-	Events      IEventUsecase
-	TicketTypes ITicketTypeUsecase
-	Tickets     ITicketUsecase
-	Orders      IOrderUsecase
+	Customers     ICustomerUsecase
+	Events        IEventUsecase
+	EventSeries   IEventSeriesUsecase
+	EventImages   IEventImageUsecase
+	TicketTypes   ITicketTypeUsecase
+	Tickets       ITicketUsecase
+	Orders        IOrderUsecase
+	CheckIns      ICheckInUsecase
+	Transfers     ITicketTransferUsecase
+	Resales       IResaleListingUsecase
+	Waitlists     IWaitlistUsecase
+	Venues        IVenueUsecase
+	PromoCodes    IPromoCodeUsecase
+	TaxRates      ITaxRateUsecase
+	ExchangeRates IExchangeRateUsecase
+	Payments      IPaymentUsecase
+	GiftCards     IGiftCardUsecase
 }
 
-func NewUsecases(config *config.Config, repositories repository.Repositories) Usecases {
+func NewUsecases(
+	config *config.Config,
+	repositories repository.Repositories,
+	storage storage.Storage,
+	provider payment.PaymentProvider,
+) Usecases {
 	return Usecases{
-		Customers:   NewCustomerUsecase(config, repositories.Customers, repositories.Orders),
-		Events:      NewEventUsecase(repositories.Events, repositories.Tickets),
-		TicketTypes: NewTicketTypeUsecase(repositories.TicketTypes),
-		Tickets:     NewTicketUsecase(repositories.Tickets, repositories.TicketTypes, repositories.Events),
+		Customers: NewCustomerUsecase(config, repositories.Customers, repositories.Orders),
+		Events: NewEventUsecase(
+			config,
+			repositories.Events,
+			repositories.EventCancellations,
+			repositories.Tickets,
+			repositories.Orders,
+			repositories.Venues,
+			repositories.EventImages,
+			repositories.PaymentRefunds,
+			provider,
+		),
+		EventSeries: NewEventSeriesUsecase(repositories.EventSeries, repositories.Events),
+		EventImages: NewEventImageUsecase(repositories.EventImages, repositories.Events, storage),
+		TicketTypes: NewTicketTypeUsecase(config, repositories.TicketTypes),
+		Tickets: NewTicketUsecase(
+			repositories.Tickets,
+			repositories.TicketTypes,
+			repositories.Events,
+			repositories.Presales,
+			repositories.PriceTiers,
+			repositories.Waitlists,
+			repositories.Venues,
+		),
 		Orders: NewOrderUsecase(
+			config,
 			repositories.Orders,
 			repositories.Customers,
+			repositories.Events,
 			repositories.Tickets,
 			repositories.TicketTypes,
+			repositories.Presales,
+			repositories.IssuedTickets,
+			repositories.Waitlists,
+			repositories.Venues,
+			repositories.PromoCodes,
+			repositories.GiftCards,
+			repositories.PaymentRefunds,
+			provider,
+		),
+		CheckIns: NewCheckInUsecase(config, repositories.IssuedTickets, repositories.Events),
+		Transfers: NewTicketTransferUsecase(
+			config,
+			repositories.TicketTransfers,
+			repositories.Customers,
+			repositories.Events,
+			repositories.Orders,
+			repositories.IssuedTickets,
+		),
+		Resales: NewResaleListingUsecase(
+			config,
+			repositories.ResaleListings,
+			repositories.IssuedTickets,
+			repositories.Tickets,
+			repositories.Events,
+		),
+		Waitlists:     NewWaitlistUsecase(repositories.Waitlists, repositories.Tickets, repositories.Events),
+		Venues:        NewVenueUsecase(repositories.Venues),
+		PromoCodes:    NewPromoCodeUsecase(config, repositories.PromoCodes),
+		TaxRates:      NewTaxRateUsecase(repositories.TaxRates),
+		ExchangeRates: NewExchangeRateUsecase(repositories.ExchangeRates),
+		Payments: NewPaymentUsecase(
+			provider,
+			repositories.TopUps,
+			repositories.Customers,
+			repositories.Orders,
+			repositories.Waitlists,
+			repositories.PaymentRefunds,
 		),
+		GiftCards: NewGiftCardUsecase(config, repositories.GiftCards, repositories.Customers),
 	}
 }
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+)
+
+type VenueUsecase struct {
+	venueRepository repository.IVenueRepository
+}
+
+func NewVenueUsecase(venueRepository repository.IVenueRepository) IVenueUsecase {
+	return &VenueUsecase{
+		venueRepository: venueRepository,
+	}
+}
+
+func (u *VenueUsecase) GetAll() ([]*domain.Venue, error) {
+	return u.venueRepository.GetAll()
+}
+
+func (u *VenueUsecase) GetByID(venueID int64) (*domain.Venue, error) {
+	venue, err := u.venueRepository.GetByID(venueID)
+	if err != nil {
+		return nil, err
+	}
+
+	venue.Sections, err = u.venueRepository.GetSections(venue.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (u *VenueUsecase) Add(input *request.VenueRequest) (*domain.Venue, error) {
+	venue := &domain.Venue{
+		Name:      input.Name,
+		Address:   input.Address,
+		Timezone:  input.Timezone,
+		Capacity:  input.Capacity,
+		Latitude:  input.Latitude,
+		Longitude: input.Longitude,
+		Sections:  make([]*domain.VenueSection, 0, len(input.Sections)),
+	}
+	venue.TaxJurisdiction = normalizeJurisdiction(input.TaxJurisdiction)
+
+	if venue.Timezone == "" {
+		venue.Timezone = "UTC"
+	}
+
+	for _, sectionInput := range input.Sections {
+		section := &domain.VenueSection{
+			Name:  sectionInput.Name,
+			Seats: make([]*domain.Seat, 0),
+		}
+
+		for _, rowInput := range sectionInput.Rows {
+			for number := 1; number <= rowInput.Seats; number++ {
+				section.Seats = append(section.Seats, &domain.Seat{
+					Row:    rowInput.Label,
+					Number: number,
+				})
+			}
+		}
+
+		venue.Sections = append(venue.Sections, section)
+	}
+
+	if seats := venue.SeatCount(); seats > venue.Capacity {
+		return nil, fmt.Errorf("%w: the venue has %d seats", utils.ErrVenueCapacityExceeded, seats)
+	}
+
+	err := u.venueRepository.Add(venue)
+	if err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (u *VenueUsecase) Update(venueID int64, input *request.VenueUpdateRequest) (*domain.Venue, error) {
+	venue, err := u.venueRepository.GetByID(venueID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		venue.Name = *input.Name
+	}
+	if input.Address != nil {
+		venue.Address = *input.Address
+	}
+	if input.Timezone != nil {
+		venue.Timezone = *input.Timezone
+	}
+	if input.Capacity != nil {
+		venue.Capacity = *input.Capacity
+	}
+	if input.Latitude != nil && input.Longitude != nil {
+		venue.Latitude = input.Latitude
+		venue.Longitude = input.Longitude
+	}
+	if input.TaxJurisdiction != nil {
+		venue.TaxJurisdiction = normalizeJurisdiction(*input.TaxJurisdiction)
+	}
+
+	err = u.venueRepository.Update(venue)
+	if err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (u *VenueUsecase) Delete(venueID int64) error {
+	return u.venueRepository.Delete(venueID)
+}
+
+func normalizeJurisdiction(jurisdiction string) *string {
+	jurisdiction = strings.ToUpper(strings.TrimSpace(jurisdiction))
+	if jurisdiction == "" {
+		return nil
+	}
+	return &jurisdiction
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/request"
+	"github.com/nadiannis/evento-api-fr-auth/internal/repository"
+	"github.com/nadiannis/evento-api-fr-auth/internal/utils"
+	"github.com/rs/zerolog/log"
+)
+
+const waitlistClaimWindow = 30 * time.Minute
+
+type WaitlistUsecase struct {
+	waitlistRepository repository.IWaitlistRepository
+	ticketRepository   repository.ITicketRepository
+	eventRepository    repository.IEventRepository
+}
+
+func NewWaitlistUsecase(
+	waitlistRepository repository.IWaitlistRepository,
+	ticketRepository repository.ITicketRepository,
+	eventRepository repository.IEventRepository,
+) IWaitlistUsecase {
+	return &WaitlistUsecase{
+		waitlistRepository: waitlistRepository,
+		ticketRepository:   ticketRepository,
+		eventRepository:    eventRepository,
+	}
+}
+
+func (u *WaitlistUsecase) GetAll(customerID int64) ([]*domain.WaitlistEntry, error) {
+	return u.waitlistRepository.GetByCustomerID(customerID)
+}
+
+func (u *WaitlistUsecase) Join(ticketID int64, customerID int64, input *request.WaitlistRequest) (*domain.WaitlistEntry, error) {
+	ticketDetail, err := u.ticketRepository.GetByID(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := u.eventRepository.GetByID(ticketDetail.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Status == domain.EventStatusCancelled {
+		return nil, utils.ErrEventCancelled
+	}
+
+	now := time.Now()
+	if ticketDetail.SalesEnd != nil && !now.Before(*ticketDetail.SalesEnd) {
+		return nil, utils.ErrTicketSalesEnded
+	}
+	if !now.Before(event.SalesCutoff()) {
+		return nil, utils.ErrTicketSalesEnded
+	}
+
+	entry := &domain.WaitlistEntry{
+		TicketID:   ticketID,
+		CustomerID: customerID,
+		Quantity:   input.Quantity,
+		Status:     domain.WaitlistEntryStatusWaiting,
+		CreatedAt:  now,
+	}
+
+	err = u.waitlistRepository.Add(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (u *WaitlistUsecase) Leave(ticketID int64, customerID int64) (*domain.WaitlistEntry, error) {
+	entry, err := u.waitlistRepository.Cancel(ticketID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	offerReturnedStock(u.waitlistRepository, ticketID)
+
+	return entry, nil
+}
+
+func (u *WaitlistUsecase) ProcessOffers() error {
+	ticketIDs, err := u.waitlistRepository.GetPendingTicketIDs(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, ticketID := range ticketIDs {
+		offerWaitlist(u.waitlistRepository, ticketID)
+	}
+
+	return nil
+}
+
+// A failure is only logged, as the periodic run of ProcessOffers picks the
+// ticket up again.
+func offerReturnedStock(waitlistRepository repository.IWaitlistRepository, ticketIDs ...int64) {
+	utils.Background(func() {
+		for _, ticketID := range ticketIDs {
+			offerWaitlist(waitlistRepository, ticketID)
+		}
+	})
+}
+
+func offerWaitlist(waitlistRepository repository.IWaitlistRepository, ticketID int64) {
+	entries, err := waitlistRepository.Offer(ticketID, time.Now(), waitlistClaimWindow)
+	if err != nil {
+		log.Error().Int64("ticket_id", ticketID).Msg(err.Error())
+		return
+	}
+
+	for _, entry := range entries {
+		log.Info().
+			Int64("ticket_id", ticketID).
+			Int64("customer_id", entry.CustomerID).
+			Int("quantity", entry.Quantity).
+			Time("offer_expires_at", *entry.OfferExpiresAt).
+			Msg("waitlisted tickets offered")
+	}
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+var backgroundWG sync.WaitGroup
+
+func Background(fn func()) {
+	backgroundWG.Add(1)
+
+	go func() {
+		defer backgroundWG.Done()
+
+		defer func() {
+			if err := recover(); err != nil {
+				log.Error().Msg(fmt.Sprintf("%v", err))
+			}
+		}()
+
+		fn()
+	}()
+}
+
+func WaitBackground() {
+	backgroundWG.Wait()
+}
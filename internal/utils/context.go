@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
+)
+
+const CustomerCtxKey string = "customer"
+
+func SetCustomer(ctx *gin.Context, customer *response.CustomerResponse) {
+	ctx.Set(CustomerCtxKey, customer)
+}
+
+func GetCustomer(ctx *gin.Context) *response.CustomerResponse {
+	customer, ok := ctx.Value(CustomerCtxKey).(*response.CustomerResponse)
+	if !ok {
+		return nil
+	}
+	return customer
+}
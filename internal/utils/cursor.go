@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+func EncodeCursor(position any) (string, error) {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func DecodeCursor(cursor string, position any) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	err = json.Unmarshal(data, position)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/nadiannis/evento-api-fr-auth/internal/domain/response"
@@ -17,13 +18,89 @@ var (
 	ErrTicketNotFound             = errors.New("ticket not found")
 	ErrEventNotFound              = errors.New("event not found")
 	ErrOrderNotFound              = errors.New("order not found")
+	ErrEventCancellationNotFound  = errors.New("event cancellation not found")
+	ErrIssuedTicketNotFound       = errors.New("issued ticket not found")
+	ErrTicketTransferNotFound     = errors.New("ticket transfer not found")
+	ErrResaleListingNotFound      = errors.New("resale listing not found")
+	ErrWaitlistEntryNotFound      = errors.New("waitlist entry not found")
+	ErrVenueNotFound              = errors.New("venue not found")
+	ErrSeatNotFound               = errors.New("seat not found")
+	ErrEventSeriesNotFound        = errors.New("event series not found")
+	ErrEventImageNotFound         = errors.New("event image not found")
+	ErrPromoCodeNotFound          = errors.New("promo code not found")
+	ErrPriceTierNotFound          = errors.New("price tier not found")
+	ErrTaxRateNotFound            = errors.New("tax rate not found")
+	ErrExchangeRateNotFound       = errors.New("exchange rate not found")
+	ErrTopUpNotFound              = errors.New("top-up not found")
+	ErrGiftCardNotFound           = errors.New("gift card not found")
 	ErrCustomerAlreadyExists      = errors.New("customer already exists")
 	ErrTicketTypeAlreadyExists    = errors.New("ticket type already exists")
 	ErrTicketAlreadyExists        = errors.New("ticket already exists for the event")
 	ErrInsufficientTicketQuantity = errors.New("insufficient ticket quantity")
 	ErrInsufficientBalance        = errors.New("insufficient balance")
+	ErrEventAlreadyCancelled      = errors.New("event already cancelled")
+	ErrEventCancelled             = errors.New("event has been cancelled")
+	ErrTicketSalesNotStarted      = errors.New("ticket sales have not started")
+	ErrTicketSalesEnded           = errors.New("ticket sales have ended")
+	ErrPresaleAccessDenied        = errors.New("presale requires a valid access code or invitation")
+	ErrPurchaseLimitExceeded      = errors.New("purchase limit exceeded")
+	ErrOrderItemsAcrossEvents     = errors.New("all order items should be for the same event")
+	ErrOrderItemsAcrossCurrencies = errors.New("all order items should be priced in the same currency")
+	ErrCurrencyNotConvertible     = errors.New("no exchange rate to pay in this currency")
+	ErrTopUpNotPending            = errors.New("top-up has already been settled")
+	ErrTopUpNotRefundable         = errors.New("top-up can no longer be refunded")
+	ErrPaymentNotFound            = errors.New("payment not found")
+	ErrPaymentSettled             = errors.New("payment has already been settled")
+	ErrPaymentMismatch            = errors.New("payment does not match the amount due")
+	ErrInvalidWebhookSignature    = errors.New("invalid webhook signature")
+	ErrPaymentSimulationDisabled  = errors.New("payment provider cannot simulate payments")
+	ErrPaymentProviderDisabled    = errors.New("no payment provider is set up")
+	ErrGiftCardUnavailable        = errors.New("gift card has expired or has no balance left")
+	ErrGiftCardOptionsRestricted  = errors.New("only admins can pick the currency & expiry of a gift card")
+	ErrGiftCardBalanceExceeded    = errors.New("amount exceeds the balance of the gift card")
+	ErrOrderNotCancellable        = errors.New("order can no longer be cancelled")
+	ErrOrdersNotDeletable         = errors.New("orders paid through the payment provider are still open")
+	ErrOrderNotPending            = errors.New("order is no longer awaiting payment")
+	ErrOrderPaymentPending        = errors.New("order is awaiting payment")
+	ErrInvalidTicketToken         = errors.New("invalid ticket token")
+	ErrIssuedTicketVoid           = errors.New("ticket is no longer valid")
+	ErrQRCodeDataTooLong          = errors.New("data too long for a QR code")
+	ErrTicketAlreadyCheckedIn     = errors.New("ticket already checked in")
+	ErrTicketEventMismatch        = errors.New("ticket is not for this event")
+	ErrTicketNotTransferable      = errors.New("ticket can no longer be transferred")
+	ErrTicketTransferPending      = errors.New("ticket already has a pending transfer")
+	ErrTicketTransferNotPending   = errors.New("ticket transfer is no longer pending")
+	ErrTicketTransferClosed       = errors.New("ticket transfers are closed for this event")
+	ErrTicketTransferToSelf       = errors.New("tickets cannot be transferred to yourself")
+	ErrTicketListedForResale      = errors.New("ticket is listed for resale")
+	ErrResaleListingNotAvailable  = errors.New("resale listing is no longer available")
+	ErrResalePriceTooHigh         = errors.New("resale price exceeds the allowed maximum")
+	ErrResaleOwnListing           = errors.New("you cannot buy your own listing")
+	ErrAlreadyWaitlisted          = errors.New("already on the waitlist for this ticket")
+	ErrTicketAvailable            = errors.New("ticket is still available")
+	ErrEventWithoutVenue          = errors.New("event has no venue")
+	ErrVenueInUse                 = errors.New("venue still has events")
+	ErrVenueCapacityExceeded      = errors.New("venue capacity exceeded")
+	ErrEventSeatsAssigned         = errors.New("venue can no longer be changed once seats have been assigned")
+	ErrTicketNotSeated            = errors.New("ticket has no assigned seating")
+	ErrTicketSeatsLocked          = errors.New("seats can no longer be changed once tickets have been sold")
+	ErrSeatAlreadyAssigned        = errors.New("seat already belongs to another ticket of the event")
+	ErrSeatUnavailable            = errors.New("seat is no longer available")
+	ErrInvalidSeatSelection       = errors.New("pick one distinct seat for every ticket of the item")
+	ErrEventInSeries              = errors.New("event already belongs to a series")
+	ErrTooManyOccurrences         = errors.New("recurrence has too many occurrences")
+	ErrUnsupportedImageType       = errors.New("image should be a JPEG, PNG, or GIF")
+	ErrInvalidImage               = errors.New("image could not be decoded")
+	ErrImageTooLarge              = errors.New("image is too large")
+	ErrImageMissing               = errors.New("image file is required")
+	ErrPromoCodeAlreadyExists     = errors.New("promo code already exists")
+	ErrPromoCodeNotApplicable     = errors.New("promo code does not apply to this event")
+	ErrPromoCodeUnavailable       = errors.New("promo code is not valid at this time or has been used up")
+	ErrPromoCodeLimitReached      = errors.New("promo code redemption limit reached")
+	ErrTaxRateAlreadyExists       = errors.New("tax rate already exists for the jurisdiction")
 	ErrInvalidID                  = errors.New("invalid id")
 	ErrInvalidAction              = errors.New("invalid action")
+	ErrInvalidCursor              = errors.New("invalid cursor")
 	ErrInvalidCredentials         = errors.New("invalid authentication credentials")
 	ErrUnknownClaimsType          = errors.New("unknown claims type")
 )
@@ -54,6 +131,35 @@ func NotFoundResponse(c *gin.Context, err error) {
 	errorResponse(c, http.StatusNotFound, err.Error())
 }
 
+func ForbiddenResponse(c *gin.Context, err error) {
+	errorResponse(c, http.StatusForbidden, err.Error())
+}
+
+func ConflictResponse(c *gin.Context, err error) {
+	errorResponse(c, http.StatusConflict, err.Error())
+}
+
+func ContentTooLargeResponse(c *gin.Context, err error) {
+	errorResponse(c, http.StatusRequestEntityTooLarge, err.Error())
+}
+
+func UnsupportedMediaTypeResponse(c *gin.Context, err error) {
+	errorResponse(c, http.StatusUnsupportedMediaType, err.Error())
+}
+
+func NotPermittedResponse(c *gin.Context) {
+	message := "your account doesn't have the necessary permissions to access this resource"
+	errorResponse(c, http.StatusForbidden, message)
+}
+
+func AlreadyCheckedInResponse(c *gin.Context, err error, checkedInAt *time.Time) {
+	message := map[string]any{
+		"message":       err.Error(),
+		"checked_in_at": checkedInAt,
+	}
+	errorResponse(c, http.StatusConflict, message)
+}
+
 func FailedValidationResponse(c *gin.Context, errors map[string]string) {
 	errorResponse(c, http.StatusUnprocessableEntity, errors)
 }
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestGlyphs(t *testing.T) {
+	for r, g := range glyphs {
+		for _, row := range g {
+			if len(row) != glyphWidth || strings.Trim(row, ".#") != "" {
+				t.Errorf("glyph %q has an invalid row %q", r, row)
+			}
+		}
+	}
+
+	tests := []struct {
+		r    rune
+		want rune
+	}{
+		{'a', 'A'},
+		{'z', 'Z'},
+		{'é', '?'},
+		{'~', '?'},
+	}
+
+	for _, tt := range tests {
+		if glyph(tt.r) != glyphs[tt.want] {
+			t.Errorf("glyph %q is not drawn as %q", tt.r, tt.want)
+		}
+	}
+}
+
+func TestDrawText(t *testing.T) {
+	text := "Evento 2026: A&B (Row 12), Seat #7!"
+
+	img := image.NewGray(image.Rect(0, 0, len(text)*(glyphWidth+1), glyphHeight))
+	fillRect(img, 0, 0, img.Bounds().Dx(), img.Bounds().Dy(), color.Gray{Y: 0xFF})
+	drawText(img, text, 0, 0, 1)
+
+	var got bytes.Buffer
+	for y := range img.Bounds().Dy() {
+		for x := range img.Bounds().Dx() {
+			if img.GrayAt(x, y).Y == 0 {
+				got.WriteByte('#')
+			} else {
+				got.WriteByte('.')
+			}
+		}
+		got.WriteByte('\n')
+	}
+
+	want := readGolden(t, "text.golden", got.Bytes())
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Fatalf("got\n%s\nwant\n%s", got.Bytes(), want)
+	}
+}
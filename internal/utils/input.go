@@ -2,15 +2,91 @@ package utils
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 func ReadIDParam(c *gin.Context) (int64, error) {
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	return ReadNamedIDParam(c, "id")
+}
+
+func ReadNamedIDParam(c *gin.Context, name string) (int64, error) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
 	if err != nil || id < 1 {
 		return 0, ErrInvalidID
 	}
 
 	return id, nil
 }
+
+func ReadStringQuery(c *gin.Context, key string, defaultValue string) string {
+	value := c.Query(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+func ReadIntQuery(c *gin.Context, key string, defaultValue int, v *Validator) int {
+	value := c.Query(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		v.AddError(key, "must be an integer value")
+		return defaultValue
+	}
+
+	return i
+}
+
+func ReadIDQuery(c *gin.Context, key string, v *Validator) *int64 {
+	value := c.Query(key)
+	if value == "" {
+		return nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 1 {
+		v.AddError(key, "must be a valid id")
+		return nil
+	}
+
+	return &id
+}
+
+func ReadBoolQuery(c *gin.Context, key string, v *Validator) bool {
+	value := c.Query(key)
+	if value == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		v.AddError(key, "must be true or false")
+		return false
+	}
+
+	return b
+}
+
+func ReadTimeQuery(c *gin.Context, key string, v *Validator) *time.Time {
+	value := c.Query(key)
+	if value == "" {
+		return nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return &t
+		}
+	}
+
+	v.AddError(key, "must be an RFC 3339 time or a date like 2024-08-17")
+	return nil
+}
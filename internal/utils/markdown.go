@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownHeadingRX     = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	markdownUnorderedRX   = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	markdownOrderedRX     = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	markdownQuoteRX       = regexp.MustCompile(`^&gt;\s?(.*)$`)
+	markdownLinkRX        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownStrongRX      = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	markdownEmphasisRX    = regexp.MustCompile(`\*([^*]+)\*`)
+	markdownSafeURLPrefix = []string{"https://", "http://", "mailto:"}
+)
+
+// The source is escaped before anything is rendered, and links only keep http,
+// https and mailto URLs.
+func RenderMarkdown(source string) string {
+	var out strings.Builder
+
+	block := ""
+	closeBlock := func() {
+		switch block {
+		case "p":
+			out.WriteString("</p>\n")
+		case "ul", "ol":
+			fmt.Fprintf(&out, "</li></%s>\n", block)
+		case "blockquote":
+			out.WriteString("</p></blockquote>\n")
+		case "pre":
+			out.WriteString("</code></pre>\n")
+		}
+		block = ""
+	}
+
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			if block == "pre" {
+				closeBlock()
+			} else {
+				closeBlock()
+				out.WriteString("<pre><code>")
+				block = "pre"
+			}
+			continue
+		}
+
+		if block == "pre" {
+			out.WriteString(html.EscapeString(line))
+			out.WriteString("\n")
+			continue
+		}
+
+		text := html.EscapeString(strings.TrimSpace(line))
+
+		if text == "" {
+			closeBlock()
+			continue
+		}
+
+		if match := markdownHeadingRX.FindStringSubmatch(text); match != nil {
+			closeBlock()
+			level := len(match[1])
+			fmt.Fprintf(&out, "<h%d>%s</h%d>\n", level, renderMarkdownInline(match[2]), level)
+			continue
+		}
+
+		listKind, item := "", ""
+		if match := markdownUnorderedRX.FindStringSubmatch(text); match != nil {
+			listKind, item = "ul", match[1]
+		} else if match := markdownOrderedRX.FindStringSubmatch(text); match != nil {
+			listKind, item = "ol", match[1]
+		}
+
+		if listKind != "" {
+			if block == listKind {
+				out.WriteString("</li>\n")
+			} else {
+				closeBlock()
+				fmt.Fprintf(&out, "<%s>\n", listKind)
+				block = listKind
+			}
+			fmt.Fprintf(&out, "<li>%s", renderMarkdownInline(item))
+			continue
+		}
+
+		if match := markdownQuoteRX.FindStringSubmatch(text); match != nil {
+			if block == "blockquote" {
+				out.WriteString("\n")
+			} else {
+				closeBlock()
+				out.WriteString("<blockquote><p>")
+				block = "blockquote"
+			}
+			out.WriteString(renderMarkdownInline(match[1]))
+			continue
+		}
+
+		switch block {
+		case "p":
+			out.WriteString("\n")
+		case "ul", "ol", "blockquote":
+			out.WriteString(" ")
+		default:
+			out.WriteString("<p>")
+			block = "p"
+		}
+		out.WriteString(renderMarkdownInline(text))
+	}
+	closeBlock()
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+func renderMarkdownInline(text string) string {
+	parts := strings.Split(text, "`")
+	if len(parts)%2 == 0 {
+		parts[len(parts)-2] += "`" + parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+	}
+
+	var out strings.Builder
+	for i, part := range parts {
+		if i%2 == 1 {
+			fmt.Fprintf(&out, "<code>%s</code>", part)
+			continue
+		}
+
+		part = markdownLinkRX.ReplaceAllStringFunc(part, func(link string) string {
+			match := markdownLinkRX.FindStringSubmatch(link)
+			for _, prefix := range markdownSafeURLPrefix {
+				if strings.HasPrefix(strings.ToLower(match[2]), prefix) {
+					href := strings.ReplaceAll(match[2], "*", "&#42;")
+					return fmt.Sprintf(`<a href="%s" rel="nofollow noopener">%s</a>`, href, match[1])
+				}
+			}
+			return match[1]
+		})
+		part = markdownStrongRX.ReplaceAllString(part, "<strong>$1</strong>")
+		part = markdownEmphasisRX.ReplaceAllString(part, "<em>$1</em>")
+		out.WriteString(part)
+	}
+
+	return out.String()
+}
@@ -0,0 +1,491 @@
+package utils
+
+// Error correction codewords per block and number of blocks, indexed by error
+// correction level (L, M, Q, H) and version (1-40), as in ISO/IEC 18004.
+var qrECCCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+var qrNumErrorCorrectionBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+const qrECLevelMedium = 1
+
+var qrFormatECBits = [4]int{1, 0, 3, 2}
+
+type QRCode struct {
+	Size       int
+	version    int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+func EncodeQRCode(data []byte) (*QRCode, error) {
+	version := 1
+	for ; ; version++ {
+		if version > 40 {
+			return nil, ErrQRCodeDataTooLong
+		}
+
+		capacityBits := qrNumDataCodewords(version) * 8
+		if 4+qrCharCountBits(version)+len(data)*8 <= capacityBits {
+			break
+		}
+	}
+
+	var bits qrBitBuffer
+	bits.append(0b0100, 4)
+	bits.append(len(data), qrCharCountBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	capacityBits := qrNumDataCodewords(version) * 8
+	bits.append(0, min(4, capacityBits-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for padByte := 0xEC; len(bits) < capacityBits; padByte ^= 0xEC ^ 0x11 {
+		bits.append(padByte, 8)
+	}
+
+	dataCodewords := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			dataCodewords[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+
+	q := newQRCode(version)
+	q.drawFunctionPatterns()
+	q.drawCodewords(qrAddECCAndInterleave(version, dataCodewords))
+
+	bestMask := 0
+	minPenalty := -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+
+		penalty := q.penaltyScore()
+		if minPenalty == -1 || penalty < minPenalty {
+			bestMask = mask
+			minPenalty = penalty
+		}
+
+		q.applyMask(mask)
+	}
+
+	q.applyMask(bestMask)
+	q.drawFormatBits(bestMask)
+
+	return q, nil
+}
+
+func (q *QRCode) Module(x, y int) bool {
+	return q.modules[y][x]
+}
+
+func newQRCode(version int) *QRCode {
+	size := version*4 + 17
+
+	q := &QRCode{
+		Size:       size,
+		version:    version,
+		modules:    make([][]bool, size),
+		isFunction: make([][]bool, size),
+	}
+	for i := range size {
+		q.modules[i] = make([]bool, size)
+		q.isFunction[i] = make([]bool, size)
+	}
+
+	return q
+}
+
+func (q *QRCode) setFunctionModule(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.isFunction[y][x] = true
+}
+
+func (q *QRCode) drawFunctionPatterns() {
+	for i := range q.Size {
+		q.setFunctionModule(6, i, i%2 == 0)
+		q.setFunctionModule(i, 6, i%2 == 0)
+	}
+
+	q.drawFinderPattern(3, 3)
+	q.drawFinderPattern(q.Size-4, 3)
+	q.drawFinderPattern(3, q.Size-4)
+
+	positions := qrAlignmentPatternPositions(q.version)
+	n := len(positions)
+	for i := range n {
+		for j := range n {
+			if (i == 0 && j == 0) || (i == 0 && j == n-1) || (i == n-1 && j == 0) {
+				continue
+			}
+			q.drawAlignmentPattern(positions[i], positions[j])
+		}
+	}
+
+	// Reserve the format areas; the real bits are drawn once the mask is known.
+	q.drawFormatBits(0)
+	q.drawVersion()
+}
+
+func (q *QRCode) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= q.Size || yy < 0 || yy >= q.Size {
+				continue
+			}
+
+			dist := max(abs(dx), abs(dy))
+			q.setFunctionModule(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (q *QRCode) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.setFunctionModule(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+func (q *QRCode) drawFormatBits(mask int) {
+	data := qrFormatECBits[qrECLevelMedium]<<3 | mask
+	rem := data
+	for range 10 {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	for i := 0; i <= 5; i++ {
+		q.setFunctionModule(8, i, qrBit(bits, i))
+	}
+	q.setFunctionModule(8, 7, qrBit(bits, 6))
+	q.setFunctionModule(8, 8, qrBit(bits, 7))
+	q.setFunctionModule(7, 8, qrBit(bits, 8))
+	for i := 9; i < 15; i++ {
+		q.setFunctionModule(14-i, 8, qrBit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.setFunctionModule(q.Size-1-i, 8, qrBit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunctionModule(8, q.Size-15+i, qrBit(bits, i))
+	}
+	q.setFunctionModule(8, q.Size-8, true)
+}
+
+func (q *QRCode) drawVersion() {
+	if q.version < 7 {
+		return
+	}
+
+	rem := q.version
+	for range 12 {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := q.version<<12 | rem
+
+	for i := range 18 {
+		bit := qrBit(bits, i)
+		a := q.Size - 11 + i%3
+		b := i / 3
+		q.setFunctionModule(a, b, bit)
+		q.setFunctionModule(b, a, bit)
+	}
+}
+
+func (q *QRCode) drawCodewords(codewords []byte) {
+	i := 0
+	for right := q.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+
+		for vert := range q.Size {
+			for j := range 2 {
+				x := right - j
+				upward := (right+1)&2 == 0
+
+				y := vert
+				if upward {
+					y = q.Size - 1 - vert
+				}
+
+				if !q.isFunction[y][x] && i < len(codewords)*8 {
+					q.modules[y][x] = qrBit(int(codewords[i>>3]), 7-(i&7))
+					i++
+				}
+			}
+		}
+	}
+}
+
+// Applying the same mask twice undoes it.
+func (q *QRCode) applyMask(mask int) {
+	for y := range q.Size {
+		for x := range q.Size {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+
+			if invert && !q.isFunction[y][x] {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+func (q *QRCode) penaltyScore() int {
+	penalty := 0
+	size := q.Size
+
+	line := func(get func(i int) bool) {
+		runLength := 0
+		for i := range size {
+			if i > 0 && get(i) == get(i-1) {
+				runLength++
+			} else {
+				if runLength >= 5 {
+					penalty += 3 + runLength - 5
+				}
+				runLength = 1
+			}
+		}
+		if runLength >= 5 {
+			penalty += 3 + runLength - 5
+		}
+
+		// Finder-like 1:1:3:1:1 patterns with four light modules on one side.
+		pattern := []bool{true, false, true, true, true, false, true}
+		for i := 0; i+7 <= size; i++ {
+			matches := true
+			for k, dark := range pattern {
+				if get(i+k) != dark {
+					matches = false
+					break
+				}
+			}
+			if !matches {
+				continue
+			}
+
+			if qrLightRun(get, i-4, i, size) || qrLightRun(get, i+7, i+11, size) {
+				penalty += 40
+			}
+		}
+	}
+
+	for y := range size {
+		line(func(i int) bool { return q.modules[y][i] })
+	}
+	for x := range size {
+		line(func(i int) bool { return q.modules[i][x] })
+	}
+
+	for y := 0; y < size-1; y++ {
+		for x := 0; x < size-1; x++ {
+			color := q.modules[y][x]
+			if color == q.modules[y][x+1] && color == q.modules[y+1][x] && color == q.modules[y+1][x+1] {
+				penalty += 3
+			}
+		}
+	}
+
+	dark := 0
+	for y := range size {
+		for x := range size {
+			if q.modules[y][x] {
+				dark++
+			}
+		}
+	}
+	total := size * size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	penalty += k * 10
+
+	return penalty
+}
+
+func qrLightRun(get func(i int) bool, from, to, size int) bool {
+	for i := from; i < to; i++ {
+		if i >= 0 && i < size && get(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func qrAlignmentPatternPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+
+	numAlign := version/7 + 2
+	step := 26
+	if version != 32 {
+		step = (version*4 + numAlign*2 + 1) / (numAlign*2 - 2) * 2
+	}
+
+	size := version*4 + 17
+	positions := make([]int, numAlign)
+	positions[0] = 6
+	for i, pos := numAlign-1, size-7; i >= 1; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+
+	return positions
+}
+
+func qrNumRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+func qrNumDataCodewords(version int) int {
+	return qrNumRawDataModules(version)/8 -
+		qrECCCodewordsPerBlock[qrECLevelMedium][version]*qrNumErrorCorrectionBlocks[qrECLevelMedium][version]
+}
+
+func qrCharCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+func qrAddECCAndInterleave(version int, data []byte) []byte {
+	numBlocks := qrNumErrorCorrectionBlocks[qrECLevelMedium][version]
+	blockECCLen := qrECCCodewordsPerBlock[qrECLevelMedium][version]
+	rawCodewords := qrNumRawDataModules(version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := qrReedSolomonDivisor(blockECCLen)
+
+	blocks := make([][]byte, 0, numBlocks)
+	k := 0
+	for i := range numBlocks {
+		dataLen := shortBlockLen - blockECCLen
+		if i >= numShortBlocks {
+			dataLen++
+		}
+
+		block := make([]byte, 0, shortBlockLen+1)
+		block = append(block, data[k:k+dataLen]...)
+		ecc := qrReedSolomonRemainder(data[k:k+dataLen], divisor)
+		k += dataLen
+
+		if i < numShortBlocks {
+			block = append(block, 0)
+		}
+		block = append(block, ecc...)
+		blocks = append(blocks, block)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortBlockLen-blockECCLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+
+	return result
+}
+
+func qrReedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+	for range degree {
+		for j := range result {
+			result[j] = qrGFMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = qrGFMultiply(root, 0x02)
+	}
+
+	return result
+}
+
+func qrReedSolomonRemainder(data []byte, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= qrGFMultiply(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// qrGFMultiply multiplies two elements of GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func qrGFMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+func qrBit(x int, i int) bool {
+	return (x>>i)&1 != 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+type qrBitBuffer []bool
+
+func (b *qrBitBuffer) append(value int, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 != 0)
+	}
+}
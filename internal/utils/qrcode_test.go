@@ -0,0 +1,293 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEncodeQRCodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", []byte{}},
+		{"ticket code", []byte("JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP")},
+		{"binary", []byte{0x00, 0xFF, 0x10, 0x80, 0x7F}},
+		{"version 10", bytes.Repeat([]byte("a"), 200)},
+		{"version 40", bytes.Repeat([]byte("z"), 2331)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qr, err := EncodeQRCode(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := decodeQRCode(qr.Size, qr.Module)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(got, tt.data) {
+				t.Fatalf("got %q, want %q", got, tt.data)
+			}
+		})
+	}
+}
+
+func TestDecodeQRCodeCorrupted(t *testing.T) {
+	qr, err := EncodeQRCode([]byte("JBSWY3DPEHPK3PXP"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flipped := func(x, y int) bool {
+		if x == qr.Size-1 && y == qr.Size-1 {
+			return !qr.Module(x, y)
+		}
+		return qr.Module(x, y)
+	}
+
+	_, err = decodeQRCode(qr.Size, flipped)
+	if err == nil {
+		t.Fatal("got no error decoding a corrupted code")
+	}
+}
+
+func TestEncodeQRCodeTooLong(t *testing.T) {
+	_, err := EncodeQRCode(bytes.Repeat([]byte("z"), 2332))
+	if !errors.Is(err, ErrQRCodeDataTooLong) {
+		t.Fatalf("got %v, want %v", err, ErrQRCodeDataTooLong)
+	}
+}
+
+func decodeQRCode(size int, module func(x, y int) bool) ([]byte, error) {
+	version := (size - 17) / 4
+	if version < 1 || version > 40 || version*4+17 != size {
+		return nil, fmt.Errorf("invalid size %d", size)
+	}
+
+	for _, corner := range [][2]int{{0, 0}, {size - 7, 0}, {0, size - 7}} {
+		for dy := range 7 {
+			for dx := range 7 {
+				dist := max(abs(dx-3), abs(dy-3))
+				if module(corner[0]+dx, corner[1]+dy) != (dist != 2) {
+					return nil, fmt.Errorf("invalid finder pattern at %d,%d", corner[0], corner[1])
+				}
+			}
+		}
+	}
+
+	ecLevel, mask, err := decodeQRFormat(module)
+	if err != nil {
+		return nil, err
+	}
+	if ecLevel != qrFormatECBits[qrECLevelMedium] {
+		return nil, fmt.Errorf("got error correction bits %d, want %d", ecLevel, qrFormatECBits[qrECLevelMedium])
+	}
+
+	layout := newQRCode(version)
+	layout.drawFunctionPatterns()
+
+	rawCodewords := qrNumRawDataModules(version) / 8
+	codewords := make([]byte, rawCodewords)
+	i := 0
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+
+		for vert := range size {
+			for j := range 2 {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = size - 1 - vert
+				}
+
+				if layout.isFunction[y][x] || i >= rawCodewords*8 {
+					continue
+				}
+
+				if module(x, y) != qrMaskBit(mask, x, y) {
+					codewords[i>>3] |= 1 << (7 - i&7)
+				}
+				i++
+			}
+		}
+	}
+
+	data, err := deinterleaveQRBlocks(version, codewords)
+	if err != nil {
+		return nil, err
+	}
+
+	bits := qrBitReader{data: data}
+	if mode := bits.read(4); mode != 0b0100 {
+		return nil, fmt.Errorf("got mode %04b, want byte mode", mode)
+	}
+
+	length := bits.read(qrCharCountBits(version))
+	if length*8 > len(data)*8-bits.pos {
+		return nil, fmt.Errorf("length %d overflows the data", length)
+	}
+
+	decoded := make([]byte, length)
+	for i := range decoded {
+		decoded[i] = byte(bits.read(8))
+	}
+
+	return decoded, nil
+}
+
+func decodeQRFormat(module func(x, y int) bool) (int, int, error) {
+	positions := make([][2]int, 0, 15)
+	for i := 0; i <= 5; i++ {
+		positions = append(positions, [2]int{8, i})
+	}
+	positions = append(positions, [2]int{8, 7}, [2]int{8, 8}, [2]int{7, 8})
+	for i := 9; i < 15; i++ {
+		positions = append(positions, [2]int{14 - i, 8})
+	}
+
+	read := 0
+	for i, position := range positions {
+		if module(position[0], position[1]) {
+			read |= 1 << i
+		}
+	}
+
+	bestData, bestDistance := -1, 16
+	for data := range 32 {
+		rem := data
+		for range 10 {
+			rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+		}
+
+		distance := 0
+		for diff := read ^ ((data<<10 | rem) ^ 0x5412); diff != 0; diff &= diff - 1 {
+			distance++
+		}
+
+		if distance < bestDistance {
+			bestData, bestDistance = data, distance
+		}
+	}
+
+	if bestDistance > 3 {
+		return 0, 0, errors.New("unreadable format information")
+	}
+
+	return bestData >> 3, bestData & 7, nil
+}
+
+func qrMaskBit(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+func deinterleaveQRBlocks(version int, codewords []byte) ([]byte, error) {
+	numBlocks := qrNumErrorCorrectionBlocks[qrECLevelMedium][version]
+	eccLen := qrECCCodewordsPerBlock[qrECLevelMedium][version]
+	numShortBlocks := numBlocks - len(codewords)%numBlocks
+	shortDataLen := len(codewords)/numBlocks - eccLen
+
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i <= shortDataLen; i++ {
+		for j := range blocks {
+			if i == shortDataLen && j < numShortBlocks {
+				continue
+			}
+			blocks[j] = append(blocks[j], codewords[k])
+			k++
+		}
+	}
+	for range eccLen {
+		for j := range blocks {
+			blocks[j] = append(blocks[j], codewords[k])
+			k++
+		}
+	}
+
+	data := make([]byte, 0, len(codewords)-numBlocks*eccLen)
+	for j, block := range blocks {
+		for i := range eccLen {
+			root := qrGFPow(2, i)
+
+			var syndrome byte
+			for _, b := range block {
+				syndrome = qrGFMultiply(syndrome, root) ^ b
+			}
+
+			if syndrome != 0 {
+				return nil, fmt.Errorf("block %d is corrupted", j)
+			}
+		}
+
+		data = append(data, block[:len(block)-eccLen]...)
+	}
+
+	return data, nil
+}
+
+func qrGFPow(x byte, n int) byte {
+	result := byte(1)
+	for range n {
+		result = qrGFMultiply(result, x)
+	}
+	return result
+}
+
+type qrBitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *qrBitReader) read(length int) int {
+	value := 0
+	for range length {
+		bit := 0
+		if r.pos < len(r.data)*8 && r.data[r.pos>>3]&(1<<(7-r.pos&7)) != 0 {
+			bit = 1
+		}
+		value = value<<1 | bit
+		r.pos++
+	}
+	return value
+}
+
+func qrString(size int, module func(x, y int) bool) string {
+	var b strings.Builder
+	for y := range size {
+		for x := range size {
+			if module(x, y) {
+				b.WriteString("##")
+			} else {
+				b.WriteString("  ")
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,238 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"time"
+)
+
+type TicketCard struct {
+	EventName string
+	EventDate time.Time
+	Category  string
+	Seat      string
+	Code      string
+	Token     string
+}
+
+const (
+	ticketImageWidth   = 600
+	ticketImageMargin  = 32
+	qrQuietZoneModules = 4
+)
+
+func RenderTicketPNG(card *TicketCard) ([]byte, error) {
+	qr, err := EncodeQRCode([]byte(card.Token))
+	if err != nil {
+		return nil, err
+	}
+
+	qrModules := qr.Size + qrQuietZoneModules*2
+	moduleSize := max((ticketImageWidth-ticketImageMargin*2)/qrModules, 1)
+	qrWidth := qrModules * moduleSize
+
+	type line struct {
+		text  string
+		scale int
+	}
+
+	lines := []line{
+		{card.EventName, 4},
+		{formatTicketDate(card.EventDate), 2},
+		{card.Category, 3},
+	}
+	if card.Seat != "" {
+		lines = append(lines, line{card.Seat, 2})
+	}
+
+	height := ticketImageMargin
+	for _, line := range lines {
+		height += (glyphHeight + 4) * line.scale
+	}
+	height += qrWidth + (glyphHeight+4)*2 + ticketImageMargin
+
+	img := image.NewGray(image.Rect(0, 0, ticketImageWidth, height))
+	fillRect(img, 0, 0, ticketImageWidth, height, color.Gray{Y: 0xFF})
+
+	y := ticketImageMargin
+	for _, line := range lines {
+		drawText(img, line.text, ticketImageMargin, y, line.scale)
+		y += (glyphHeight + 4) * line.scale
+	}
+
+	qrX := (ticketImageWidth - qrWidth) / 2
+	for my := range qr.Size {
+		for mx := range qr.Size {
+			if qr.Module(mx, my) {
+				fillRect(
+					img,
+					qrX+(mx+qrQuietZoneModules)*moduleSize,
+					y+(my+qrQuietZoneModules)*moduleSize,
+					moduleSize,
+					moduleSize,
+					color.Gray{Y: 0x00},
+				)
+			}
+		}
+	}
+	y += qrWidth
+
+	codeWidth := len(card.Code) * (glyphWidth + 1) * 2
+	drawText(img, card.Code, max((ticketImageWidth-codeWidth)/2, 0), y, 2)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func RenderTicketsPDF(cards []*TicketCard) ([]byte, error) {
+	const (
+		pageWidth  = 595
+		pageHeight = 842
+		margin     = 56
+		qrWidth    = 300
+	)
+
+	pages := make([]string, 0, len(cards))
+	for _, card := range cards {
+		qr, err := EncodeQRCode([]byte(card.Token))
+		if err != nil {
+			return nil, err
+		}
+
+		var content strings.Builder
+		y := pageHeight - margin - 24
+		fmt.Fprintf(&content, "BT /F2 24 Tf %d %d Td (%s) Tj ET\n", margin, y, pdfEscape(card.EventName))
+		y -= 28
+		fmt.Fprintf(&content, "BT /F1 12 Tf %d %d Td (%s) Tj ET\n", margin, y, pdfEscape(formatTicketDate(card.EventDate)))
+		y -= 28
+		fmt.Fprintf(&content, "BT /F2 16 Tf %d %d Td (%s) Tj ET\n", margin, y, pdfEscape(card.Category))
+		y -= 24
+		if card.Seat != "" {
+			fmt.Fprintf(&content, "BT /F1 12 Tf %d %d Td (%s) Tj ET\n", margin, y, pdfEscape(card.Seat))
+			y -= 24
+		}
+
+		moduleSize := float64(qrWidth) / float64(qr.Size+qrQuietZoneModules*2)
+		qrX := float64(pageWidth-qrWidth) / 2
+		qrTop := float64(y)
+
+		content.WriteString("0 g\n")
+		for my := range qr.Size {
+			for mx := range qr.Size {
+				if qr.Module(mx, my) {
+					fmt.Fprintf(
+						&content,
+						"%.2f %.2f %.2f %.2f re\n",
+						qrX+float64(mx+qrQuietZoneModules)*moduleSize,
+						qrTop-float64(my+qrQuietZoneModules+1)*moduleSize,
+						moduleSize,
+						moduleSize,
+					)
+				}
+			}
+		}
+		content.WriteString("f\n")
+
+		y -= qrWidth + 16
+		fmt.Fprintf(&content, "BT /F3 12 Tf %d %d Td (%s) Tj ET\n", margin, y, pdfEscape(card.Code))
+
+		pages = append(pages, content.String())
+	}
+
+	// Objects: 1 catalog, 2 page tree, 3-5 fonts, then a page and its content
+	// stream for every ticket.
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>",
+	}
+
+	kids := make([]string, 0, len(pages))
+	for _, content := range pages {
+		pageID := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageID))
+		objects = append(
+			objects,
+			fmt.Sprintf(
+				"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R /F3 5 0 R >> >> /Contents %d 0 R >>",
+				pageWidth, pageHeight, pageID+1,
+			),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.Bytes(), nil
+}
+
+func formatTicketDate(date time.Time) string {
+	return date.Format("Mon, 02 Jan 2006 15:04 MST")
+}
+
+func drawText(img *image.Gray, text string, x, y, scale int) {
+	for _, r := range text {
+		if x+glyphWidth*scale > img.Bounds().Dx() {
+			break
+		}
+
+		g := glyph(r)
+		for row, bits := range g {
+			for col, bit := range bits {
+				if bit == '#' {
+					fillRect(img, x+col*scale, y+row*scale, scale, scale, color.Gray{Y: 0x00})
+				}
+			}
+		}
+		x += (glyphWidth + 1) * scale
+	}
+}
+
+func fillRect(img *image.Gray, x, y, width, height int, c color.Gray) {
+	for dy := range height {
+		for dx := range width {
+			img.SetGray(x+dx, y+dy, c)
+		}
+	}
+}
+
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7E:
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,219 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func testTicketCards(t *testing.T) []*TicketCard {
+	t.Helper()
+
+	key := NewTicketSigningKey("test-ticket-signing-seed")
+	eventDate := time.Date(2026, time.November, 14, 19, 30, 0, 0, time.UTC)
+
+	cards := []*TicketCard{
+		{
+			EventName: "Evento Live (Night 1)",
+			EventDate: eventDate,
+			Category:  "CAT1",
+			Seat:      "Section A, Row 3, Seat 12",
+			Code:      "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP",
+		},
+		{
+			EventName: "Evento Live (Night 1)",
+			EventDate: eventDate,
+			Category:  "VIP",
+			Code:      "MFRGGZDFMZTWQ2LKNNWG23TPOBYXE43U",
+		},
+	}
+
+	for i, card := range cards {
+		token, err := SignTicketToken(key, &TicketTokenPayload{
+			Code:     card.Code,
+			EventID:  1,
+			TicketID: int64(i + 1),
+			IssuedAt: eventDate.AddDate(0, -1, 0).Unix(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		card.Token = token
+	}
+
+	return cards
+}
+
+func TestRenderTicketPNG(t *testing.T) {
+	card := testTicketCards(t)[0]
+
+	got, err := RenderTicketPNG(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(got))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	size, module, err := locatePNGQRCode(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := decodeQRCode(size, module)
+	if err != nil {
+		t.Fatalf("%v\n%s", err, qrString(size, module))
+	}
+
+	if string(token) != card.Token {
+		t.Fatalf("got token %q, want %q", token, card.Token)
+	}
+
+	want, err := png.Decode(bytes.NewReader(readGolden(t, "ticket.png.golden", got)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The pixels are compared rather than the bytes, which depend on the
+	// compression of the encoder.
+	if img.Bounds() != want.Bounds() {
+		t.Fatalf("got bounds %v, want %v", img.Bounds(), want.Bounds())
+	}
+	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+			if img.At(x, y) != want.At(x, y) {
+				t.Fatalf("pixel %d,%d differs from the golden file", x, y)
+			}
+		}
+	}
+}
+
+func TestRenderTicketsPDF(t *testing.T) {
+	cards := testTicketCards(t)
+
+	got, err := RenderTicketsPDF(cards)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	streams := pdfStreamRX.FindAllSubmatch(got, -1)
+	if len(streams) != len(cards) {
+		t.Fatalf("got %d pages, want %d", len(streams), len(cards))
+	}
+
+	for i, stream := range streams {
+		size, module, err := locatePDFQRCode(stream[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		token, err := decodeQRCode(size, module)
+		if err != nil {
+			t.Fatalf("page %d: %v\n%s", i+1, err, qrString(size, module))
+		}
+
+		if string(token) != cards[i].Token {
+			t.Fatalf("page %d: got token %q, want %q", i+1, token, cards[i].Token)
+		}
+	}
+
+	want := readGolden(t, "tickets.pdf.golden", got)
+	if !bytes.Equal(got, want) {
+		t.Fatal("the document differs from the golden file")
+	}
+}
+
+func locatePNGQRCode(img image.Image) (int, func(x, y int) bool, error) {
+	bounds := img.Bounds()
+	dark := func(x, y int) bool {
+		if !(image.Point{x, y}).In(bounds) {
+			return false
+		}
+		r, _, _, _ := img.At(x, y).RGBA()
+		return r < 0x8000
+	}
+
+	for x := bounds.Min.X; x < bounds.Max.X; x++ {
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			if !dark(x, y) {
+				continue
+			}
+
+			run := 0
+			for dark(x, y+run) {
+				run++
+			}
+
+			if run <= glyphHeight*4 || run%7 != 0 {
+				y += run
+				continue
+			}
+
+			moduleSize := run / 7
+			row := y + moduleSize/2
+
+			right := bounds.Max.X - 1
+			for right > x && !dark(right, row) {
+				right--
+			}
+
+			size := (right - x + 1) / moduleSize
+			module := func(mx, my int) bool {
+				return dark(x+mx*moduleSize+moduleSize/2, y+my*moduleSize+moduleSize/2)
+			}
+
+			return size, module, nil
+		}
+	}
+
+	return 0, nil, fmt.Errorf("no QR code found")
+}
+
+var (
+	pdfStreamRX = regexp.MustCompile(`(?s)stream\n(.*?)endstream`)
+	pdfRectRX   = regexp.MustCompile(`([\d.]+) ([\d.]+) ([\d.]+) ([\d.]+) re\n`)
+)
+
+func locatePDFQRCode(content []byte) (int, func(x, y int) bool, error) {
+	rects := pdfRectRX.FindAllSubmatch(content, -1)
+	if len(rects) == 0 {
+		return 0, nil, fmt.Errorf("no QR code found")
+	}
+
+	var moduleSize float64
+	minX, maxX, maxY := math.Inf(1), math.Inf(-1), math.Inf(-1)
+	points := make([][2]float64, 0, len(rects))
+	for _, rect := range rects {
+		x, _ := strconv.ParseFloat(string(rect[1]), 64)
+		y, _ := strconv.ParseFloat(string(rect[2]), 64)
+		moduleSize, _ = strconv.ParseFloat(string(rect[3]), 64)
+
+		minX, maxX = min(minX, x), max(maxX, x)
+		maxY = max(maxY, y)
+		points = append(points, [2]float64{x, y})
+	}
+
+	size := int(math.Round((maxX-minX)/moduleSize)) + 1
+
+	modules := make(map[[2]int]bool, len(points))
+	for _, point := range points {
+		mx := int(math.Round((point[0] - minX) / moduleSize))
+		my := int(math.Round((maxY - point[1]) / moduleSize))
+		modules[[2]int{mx, my}] = true
+	}
+
+	module := func(x, y int) bool {
+		return modules[[2]int{x, y}]
+	}
+
+	return size, module, nil
+}
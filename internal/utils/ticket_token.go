@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+type TicketTokenPayload struct {
+	Code     string `json:"code"`
+	EventID  int64  `json:"event_id"`
+	TicketID int64  `json:"ticket_id"`
+	IssuedAt int64  `json:"issued_at"`
+}
+
+func GenerateTicketCode() (string, error) {
+	randomBytes := make([]byte, 20)
+
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(randomBytes), nil
+}
+
+func GenerateGiftCardCode() (string, error) {
+	randomBytes := make([]byte, 10)
+
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return base32.StdEncoding.EncodeToString(randomBytes), nil
+}
+
+func NewTicketSigningKey(seed string) ed25519.PrivateKey {
+	hash := sha256.Sum256([]byte(seed))
+	return ed25519.NewKeyFromSeed(hash[:])
+}
+
+func SignTicketToken(key ed25519.PrivateKey, payload *TicketTokenPayload) (string, error) {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	signature := ed25519.Sign(key, payloadJSON)
+
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(payloadJSON) + "." + encoding.EncodeToString(signature), nil
+}
+
+func VerifyTicketToken(key ed25519.PublicKey, token string) (*TicketTokenPayload, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, ErrInvalidTicketToken
+	}
+
+	encoding := base64.RawURLEncoding
+
+	payloadJSON, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidTicketToken
+	}
+
+	signature, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidTicketToken
+	}
+
+	if !ed25519.Verify(key, payloadJSON, signature) {
+		return nil, ErrInvalidTicketToken
+	}
+
+	var payload TicketTokenPayload
+
+	err = json.Unmarshal(payloadJSON, &payload)
+	if err != nil {
+		return nil, ErrInvalidTicketToken
+	}
+
+	return &payload, nil
+}
@@ -1,8 +1,17 @@
 package utils
 
-import "regexp"
-
-var UsernameRX = regexp.MustCompile("^[A-Za-z][A-Za-z0-9_]{2,29}$")
+import (
+	"regexp"
+	"time"
+)
+
+var (
+	UsernameRX     = regexp.MustCompile("^[A-Za-z][A-Za-z0-9_]{2,29}$")
+	EmailRX        = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	WebsiteRX      = regexp.MustCompile(`^https?://[^\s]+$`)
+	JurisdictionRX = regexp.MustCompile(`^[A-Za-z]{2}(-[A-Za-z0-9]{1,3})?$`)
+	CurrencyRX     = regexp.MustCompile(`^[A-Za-z]{3}$`)
+)
 
 type Validator struct {
 	Errors map[string]string
@@ -42,3 +51,11 @@ func PermittedValue[T comparable](value T, permittedValues ...T) bool {
 	}
 	return false
 }
+
+func ValidTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
@@ -0,0 +1 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS status VARCHAR(255) NOT NULL DEFAULT 'scheduled';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS orders_ticket_id_idx;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS status;
@@ -0,0 +1,3 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS status VARCHAR(255) NOT NULL DEFAULT 'completed';
+
+CREATE INDEX IF NOT EXISTS orders_ticket_id_idx ON orders (ticket_id);
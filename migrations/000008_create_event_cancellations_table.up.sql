@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS event_cancellations (
+  id BIGSERIAL PRIMARY KEY,
+  event_id BIGINT NOT NULL UNIQUE,
+  status VARCHAR(255) NOT NULL DEFAULT 'in_progress',
+  refunded_orders INT NOT NULL DEFAULT 0,
+  refunded_amount NUMERIC NOT NULL DEFAULT 0,
+  last_order_id BIGINT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  completed_at TIMESTAMP(0) WITH TIME ZONE
+);
+
+ALTER TABLE event_cancellations ADD CONSTRAINT event_cancellations_fk_event_id_events_id FOREIGN KEY (event_id) REFERENCES events(id);
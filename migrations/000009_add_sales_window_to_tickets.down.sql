@@ -0,0 +1,5 @@
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_sales_window_check;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS sales_end;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS sales_start;
@@ -0,0 +1,5 @@
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS sales_start TIMESTAMP(0) WITH TIME ZONE;
+
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS sales_end TIMESTAMP(0) WITH TIME ZONE;
+
+ALTER TABLE tickets ADD CONSTRAINT tickets_sales_window_check CHECK (sales_start IS NULL OR sales_end IS NULL OR sales_start < sales_end);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS presale_customers;
+
+DROP TABLE IF EXISTS presales;
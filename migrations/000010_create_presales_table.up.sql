@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS presales (
+  id BIGSERIAL PRIMARY KEY,
+  ticket_id BIGINT NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  starts_at TIMESTAMP(0) WITH TIME ZONE NOT NULL,
+  ends_at TIMESTAMP(0) WITH TIME ZONE NOT NULL,
+  access_code VARCHAR(255)
+);
+
+ALTER TABLE presales ADD CONSTRAINT presales_fk_ticket_id_tickets_id FOREIGN KEY (ticket_id) REFERENCES tickets(id);
+
+ALTER TABLE presales ADD CONSTRAINT presales_window_check CHECK (starts_at < ends_at);
+
+CREATE TABLE IF NOT EXISTS presale_customers (
+  presale_id BIGINT NOT NULL,
+  customer_id BIGINT NOT NULL,
+  PRIMARY KEY (presale_id, customer_id)
+);
+
+ALTER TABLE presale_customers ADD CONSTRAINT presale_customers_fk_presale_id_presales_id FOREIGN KEY (presale_id) REFERENCES presales(id) ON DELETE CASCADE;
+
+ALTER TABLE presale_customers ADD CONSTRAINT presale_customers_fk_customer_id_customers_id FOREIGN KEY (customer_id) REFERENCES customers(id);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS orders_customer_id_idx;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_max_tickets_per_customer_check;
+
+ALTER TABLE events DROP COLUMN IF EXISTS max_tickets_per_customer;
+
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_max_per_customer_check;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS max_per_customer;
@@ -0,0 +1,9 @@
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS max_per_customer INT;
+
+ALTER TABLE tickets ADD CONSTRAINT tickets_max_per_customer_check CHECK (max_per_customer IS NULL OR max_per_customer > 0);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS max_tickets_per_customer INT;
+
+ALTER TABLE events ADD CONSTRAINT events_max_tickets_per_customer_check CHECK (max_tickets_per_customer IS NULL OR max_tickets_per_customer > 0);
+
+CREATE INDEX IF NOT EXISTS orders_customer_id_idx ON orders (customer_id);
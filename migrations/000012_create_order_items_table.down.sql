@@ -0,0 +1,26 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS ticket_id BIGINT;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS quantity INT NOT NULL DEFAULT 0;
+
+UPDATE orders O
+SET ticket_id = OI.ticket_id, quantity = OI.quantity
+FROM (
+  SELECT DISTINCT ON (order_id) order_id, ticket_id, quantity
+  FROM order_items
+  ORDER BY order_id, id
+) OI
+WHERE O.id = OI.order_id;
+
+DELETE FROM orders WHERE ticket_id IS NULL;
+
+ALTER TABLE orders ALTER COLUMN ticket_id SET NOT NULL;
+
+ALTER TABLE orders ADD CONSTRAINT orders_fk_ticket_id_tickets_id FOREIGN KEY (ticket_id) REFERENCES tickets(id);
+
+CREATE INDEX IF NOT EXISTS orders_ticket_id_idx ON orders (ticket_id);
+
+DROP INDEX IF EXISTS orders_event_id_idx;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS event_id;
+
+DROP TABLE IF EXISTS order_items;
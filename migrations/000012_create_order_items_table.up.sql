@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS order_items (
+  id BIGSERIAL PRIMARY KEY,
+  order_id BIGINT NOT NULL,
+  ticket_id BIGINT NOT NULL,
+  quantity INT NOT NULL,
+  unit_price NUMERIC NOT NULL DEFAULT 0,
+  total_price NUMERIC NOT NULL DEFAULT 0
+);
+
+ALTER TABLE order_items ADD CONSTRAINT order_items_fk_order_id_orders_id FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE;
+
+ALTER TABLE order_items ADD CONSTRAINT order_items_fk_ticket_id_tickets_id FOREIGN KEY (ticket_id) REFERENCES tickets(id);
+
+ALTER TABLE order_items ADD CONSTRAINT order_items_quantity_check CHECK (quantity > 0);
+
+CREATE INDEX IF NOT EXISTS order_items_order_id_idx ON order_items (order_id);
+
+CREATE INDEX IF NOT EXISTS order_items_ticket_id_idx ON order_items (ticket_id);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS event_id BIGINT;
+
+UPDATE orders O SET event_id = T.event_id FROM tickets T WHERE O.ticket_id = T.id;
+
+INSERT INTO order_items (order_id, ticket_id, quantity, unit_price, total_price)
+SELECT id, ticket_id, quantity, total_price / quantity, total_price
+FROM orders
+WHERE quantity > 0;
+
+ALTER TABLE orders ALTER COLUMN event_id SET NOT NULL;
+
+ALTER TABLE orders ADD CONSTRAINT orders_fk_event_id_events_id FOREIGN KEY (event_id) REFERENCES events(id);
+
+CREATE INDEX IF NOT EXISTS orders_event_id_idx ON orders (event_id);
+
+DROP INDEX IF EXISTS orders_ticket_id_idx;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS ticket_id;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS quantity;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS issued_tickets (
+  id BIGSERIAL PRIMARY KEY,
+  order_id BIGINT NOT NULL,
+  order_item_id BIGINT NOT NULL,
+  ticket_id BIGINT NOT NULL,
+  event_id BIGINT NOT NULL,
+  customer_id BIGINT NOT NULL,
+  code VARCHAR(255) NOT NULL UNIQUE,
+  status VARCHAR(255) NOT NULL DEFAULT 'valid',
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE issued_tickets ADD CONSTRAINT issued_tickets_fk_order_id_orders_id FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE;
+
+ALTER TABLE issued_tickets ADD CONSTRAINT issued_tickets_fk_order_item_id_order_items_id FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE CASCADE;
+
+ALTER TABLE issued_tickets ADD CONSTRAINT issued_tickets_fk_ticket_id_tickets_id FOREIGN KEY (ticket_id) REFERENCES tickets(id);
+
+ALTER TABLE issued_tickets ADD CONSTRAINT issued_tickets_fk_event_id_events_id FOREIGN KEY (event_id) REFERENCES events(id);
+
+ALTER TABLE issued_tickets ADD CONSTRAINT issued_tickets_fk_customer_id_customers_id FOREIGN KEY (customer_id) REFERENCES customers(id);
+
+CREATE INDEX IF NOT EXISTS issued_tickets_order_id_idx ON issued_tickets (order_id);
@@ -0,0 +1 @@
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS role VARCHAR(255) NOT NULL DEFAULT 'customer';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS issued_tickets_event_id_idx;
+
+ALTER TABLE issued_tickets DROP CONSTRAINT IF EXISTS issued_tickets_fk_checked_in_by_customers_id;
+
+ALTER TABLE issued_tickets DROP COLUMN IF EXISTS checked_in_by;
+
+ALTER TABLE issued_tickets DROP COLUMN IF EXISTS checked_in_at;
@@ -0,0 +1,7 @@
+ALTER TABLE issued_tickets ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP(0) WITH TIME ZONE;
+
+ALTER TABLE issued_tickets ADD COLUMN IF NOT EXISTS checked_in_by BIGINT;
+
+ALTER TABLE issued_tickets ADD CONSTRAINT issued_tickets_fk_checked_in_by_customers_id FOREIGN KEY (checked_in_by) REFERENCES customers(id);
+
+CREATE INDEX IF NOT EXISTS issued_tickets_event_id_idx ON issued_tickets (event_id);
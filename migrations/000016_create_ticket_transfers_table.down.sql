@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS ticket_transfer_items;
+
+DROP TABLE IF EXISTS ticket_transfers;
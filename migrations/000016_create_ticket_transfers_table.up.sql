@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS ticket_transfers (
+  id BIGSERIAL PRIMARY KEY,
+  sender_id BIGINT NOT NULL,
+  recipient_id BIGINT NOT NULL,
+  status VARCHAR(255) NOT NULL DEFAULT 'pending',
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  responded_at TIMESTAMP(0) WITH TIME ZONE
+);
+
+ALTER TABLE ticket_transfers ADD CONSTRAINT ticket_transfers_fk_sender_id_customers_id FOREIGN KEY (sender_id) REFERENCES customers(id);
+
+ALTER TABLE ticket_transfers ADD CONSTRAINT ticket_transfers_fk_recipient_id_customers_id FOREIGN KEY (recipient_id) REFERENCES customers(id);
+
+CREATE INDEX IF NOT EXISTS ticket_transfers_sender_id_idx ON ticket_transfers (sender_id);
+
+CREATE INDEX IF NOT EXISTS ticket_transfers_recipient_id_idx ON ticket_transfers (recipient_id);
+
+CREATE TABLE IF NOT EXISTS ticket_transfer_items (
+  transfer_id BIGINT NOT NULL,
+  issued_ticket_id BIGINT NOT NULL,
+  PRIMARY KEY (transfer_id, issued_ticket_id)
+);
+
+ALTER TABLE ticket_transfer_items ADD CONSTRAINT ticket_transfer_items_fk_transfer_id_ticket_transfers_id FOREIGN KEY (transfer_id) REFERENCES ticket_transfers(id) ON DELETE CASCADE;
+
+ALTER TABLE ticket_transfer_items ADD CONSTRAINT ticket_transfer_items_fk_issued_ticket_id_issued_tickets_id FOREIGN KEY (issued_ticket_id) REFERENCES issued_tickets(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS ticket_transfer_items_issued_ticket_id_idx ON ticket_transfer_items (issued_ticket_id);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS resale_listings (
+  id BIGSERIAL PRIMARY KEY,
+  issued_ticket_id BIGINT NOT NULL,
+  seller_id BIGINT NOT NULL,
+  buyer_id BIGINT,
+  price NUMERIC NOT NULL,
+  fee NUMERIC NOT NULL DEFAULT 0,
+  status VARCHAR(255) NOT NULL DEFAULT 'listed',
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  sold_at TIMESTAMP(0) WITH TIME ZONE
+);
+
+ALTER TABLE resale_listings ADD CONSTRAINT resale_listings_price_check CHECK (price > 0);
+
+ALTER TABLE resale_listings ADD CONSTRAINT resale_listings_fk_issued_ticket_id_issued_tickets_id FOREIGN KEY (issued_ticket_id) REFERENCES issued_tickets(id) ON DELETE CASCADE;
+
+ALTER TABLE resale_listings ADD CONSTRAINT resale_listings_fk_seller_id_customers_id FOREIGN KEY (seller_id) REFERENCES customers(id);
+
+ALTER TABLE resale_listings ADD CONSTRAINT resale_listings_fk_buyer_id_customers_id FOREIGN KEY (buyer_id) REFERENCES customers(id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS resale_listings_issued_ticket_id_listed_idx ON resale_listings (issued_ticket_id) WHERE status = 'listed';
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+  id BIGSERIAL PRIMARY KEY,
+  ticket_id BIGINT NOT NULL,
+  customer_id BIGINT NOT NULL,
+  quantity INT NOT NULL DEFAULT 1,
+  status VARCHAR(255) NOT NULL DEFAULT 'waiting',
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  offered_at TIMESTAMP(0) WITH TIME ZONE,
+  offer_expires_at TIMESTAMP(0) WITH TIME ZONE
+);
+
+ALTER TABLE waitlist_entries ADD CONSTRAINT waitlist_entries_quantity_check CHECK (quantity > 0);
+
+ALTER TABLE waitlist_entries ADD CONSTRAINT waitlist_entries_fk_ticket_id_tickets_id FOREIGN KEY (ticket_id) REFERENCES tickets(id) ON DELETE CASCADE;
+
+ALTER TABLE waitlist_entries ADD CONSTRAINT waitlist_entries_fk_customer_id_customers_id FOREIGN KEY (customer_id) REFERENCES customers(id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS waitlist_entries_ticket_id_customer_id_active_idx ON waitlist_entries (ticket_id, customer_id) WHERE status IN ('waiting', 'offered');
+
+CREATE INDEX IF NOT EXISTS waitlist_entries_ticket_id_status_idx ON waitlist_entries (ticket_id, status);
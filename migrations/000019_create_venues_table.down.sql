@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS venue_seats;
+
+DROP TABLE IF EXISTS venue_sections;
+
+DROP TABLE IF EXISTS venues;
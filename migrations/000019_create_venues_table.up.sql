@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS venues (
+  id BIGSERIAL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS venue_sections (
+  id BIGSERIAL PRIMARY KEY,
+  venue_id BIGINT NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  position INT NOT NULL
+);
+
+ALTER TABLE venue_sections ADD CONSTRAINT venue_sections_fk_venue_id_venues_id FOREIGN KEY (venue_id) REFERENCES venues(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS venue_sections_venue_id_name_idx ON venue_sections (venue_id, name);
+
+CREATE TABLE IF NOT EXISTS venue_seats (
+  id BIGSERIAL PRIMARY KEY,
+  section_id BIGINT NOT NULL,
+  row_label VARCHAR(255) NOT NULL,
+  row_position INT NOT NULL,
+  number INT NOT NULL
+);
+
+ALTER TABLE venue_seats ADD CONSTRAINT venue_seats_number_check CHECK (number > 0);
+
+ALTER TABLE venue_seats ADD CONSTRAINT venue_seats_fk_section_id_venue_sections_id FOREIGN KEY (section_id) REFERENCES venue_sections(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS venue_seats_section_id_row_label_number_idx ON venue_seats (section_id, row_label, number);
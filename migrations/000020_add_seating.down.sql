@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS issued_tickets_event_id_seat_id_valid_idx;
+
+ALTER TABLE issued_tickets DROP COLUMN IF EXISTS seat_id;
+
+DROP TABLE IF EXISTS ticket_seats;
+
+ALTER TABLE events DROP COLUMN IF EXISTS venue_id;
@@ -0,0 +1,21 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS venue_id BIGINT;
+
+ALTER TABLE events ADD CONSTRAINT events_fk_venue_id_venues_id FOREIGN KEY (venue_id) REFERENCES venues(id);
+
+CREATE TABLE IF NOT EXISTS ticket_seats (
+  ticket_id BIGINT NOT NULL,
+  seat_id BIGINT NOT NULL,
+  PRIMARY KEY (ticket_id, seat_id)
+);
+
+ALTER TABLE ticket_seats ADD CONSTRAINT ticket_seats_fk_ticket_id_tickets_id FOREIGN KEY (ticket_id) REFERENCES tickets(id) ON DELETE CASCADE;
+
+ALTER TABLE ticket_seats ADD CONSTRAINT ticket_seats_fk_seat_id_venue_seats_id FOREIGN KEY (seat_id) REFERENCES venue_seats(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS ticket_seats_seat_id_idx ON ticket_seats (seat_id);
+
+ALTER TABLE issued_tickets ADD COLUMN IF NOT EXISTS seat_id BIGINT;
+
+ALTER TABLE issued_tickets ADD CONSTRAINT issued_tickets_fk_seat_id_venue_seats_id FOREIGN KEY (seat_id) REFERENCES venue_seats(id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS issued_tickets_event_id_seat_id_valid_idx ON issued_tickets (event_id, seat_id) WHERE seat_id IS NOT NULL AND status = 'valid';
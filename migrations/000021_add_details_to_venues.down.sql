@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS events_venue_id_idx;
+
+ALTER TABLE venues DROP CONSTRAINT IF EXISTS venues_coordinates_check;
+
+ALTER TABLE venues DROP CONSTRAINT IF EXISTS venues_capacity_check;
+
+ALTER TABLE venues DROP COLUMN IF EXISTS longitude;
+
+ALTER TABLE venues DROP COLUMN IF EXISTS latitude;
+
+ALTER TABLE venues DROP COLUMN IF EXISTS capacity;
+
+ALTER TABLE venues DROP COLUMN IF EXISTS timezone;
+
+ALTER TABLE venues DROP COLUMN IF EXISTS address;
@@ -0,0 +1,26 @@
+ALTER TABLE venues ADD COLUMN IF NOT EXISTS address VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE venues ADD COLUMN IF NOT EXISTS timezone VARCHAR(255) NOT NULL DEFAULT 'UTC';
+
+ALTER TABLE venues ADD COLUMN IF NOT EXISTS capacity INT NOT NULL DEFAULT 0;
+
+UPDATE venues V
+SET capacity = (
+  SELECT COUNT(*)
+  FROM venue_seats VS
+  JOIN venue_sections S ON VS.section_id = S.id
+  WHERE S.venue_id = V.id
+);
+
+ALTER TABLE venues ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+
+ALTER TABLE venues ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+ALTER TABLE venues ADD CONSTRAINT venues_capacity_check CHECK (capacity >= 0);
+
+ALTER TABLE venues ADD CONSTRAINT venues_coordinates_check CHECK (
+  (latitude IS NULL AND longitude IS NULL) OR
+  (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180)
+);
+
+CREATE INDEX IF NOT EXISTS events_venue_id_idx ON events (venue_id);
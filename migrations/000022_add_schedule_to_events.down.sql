@@ -0,0 +1,11 @@
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_doors_open_at_check;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_ends_at_check;
+
+ALTER TABLE events DROP COLUMN IF EXISTS timezone;
+
+ALTER TABLE events DROP COLUMN IF EXISTS doors_open_at;
+
+ALTER TABLE events DROP COLUMN IF EXISTS ends_at;
+
+ALTER TABLE events RENAME COLUMN starts_at TO date;
@@ -0,0 +1,16 @@
+ALTER TABLE events RENAME COLUMN date TO starts_at;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS ends_at TIMESTAMP(0) WITH TIME ZONE;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS doors_open_at TIMESTAMP(0) WITH TIME ZONE;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS timezone VARCHAR(255) NOT NULL DEFAULT 'UTC';
+
+UPDATE events E
+SET timezone = V.timezone
+FROM venues V
+WHERE E.venue_id = V.id;
+
+ALTER TABLE events ADD CONSTRAINT events_ends_at_check CHECK (ends_at > starts_at);
+
+ALTER TABLE events ADD CONSTRAINT events_doors_open_at_check CHECK (doors_open_at <= starts_at);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS events_series_id_idx;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_fk_series_id_event_series_id;
+
+ALTER TABLE events DROP COLUMN IF EXISTS series_id;
+
+DROP TABLE IF EXISTS event_series;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS event_series (
+  id BIGSERIAL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  timezone VARCHAR(255) NOT NULL,
+  frequency VARCHAR(255) NOT NULL,
+  frequency_interval INT NOT NULL DEFAULT 1,
+  by_day TEXT[] NOT NULL DEFAULT '{}',
+  until TIMESTAMP(0) WITH TIME ZONE,
+  count INT
+);
+
+ALTER TABLE event_series ADD CONSTRAINT event_series_frequency_check CHECK (frequency IN ('daily', 'weekly'));
+
+ALTER TABLE event_series ADD CONSTRAINT event_series_frequency_interval_check CHECK (frequency_interval > 0);
+
+ALTER TABLE event_series ADD CONSTRAINT event_series_end_check CHECK (until IS NOT NULL OR count > 0);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS series_id BIGINT;
+
+ALTER TABLE events ADD CONSTRAINT events_fk_series_id_event_series_id FOREIGN KEY (series_id) REFERENCES event_series(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS events_series_id_idx ON events (series_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS tickets_event_id_idx;
+
+DROP INDEX IF EXISTS events_name_id_idx;
+
+DROP INDEX IF EXISTS events_starts_at_id_idx;
+
+DROP INDEX IF EXISTS events_name_trgm_idx;
@@ -0,0 +1,9 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS events_name_trgm_idx ON events USING GIN (name gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS events_starts_at_id_idx ON events (starts_at, id);
+
+CREATE INDEX IF NOT EXISTS events_name_id_idx ON events (name, id);
+
+CREATE INDEX IF NOT EXISTS tickets_event_id_idx ON tickets (event_id);
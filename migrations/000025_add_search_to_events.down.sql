@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS events_search_vector_idx;
+
+DROP TRIGGER IF EXISTS venues_search_vector_update ON venues;
+
+DROP FUNCTION IF EXISTS venues_search_vector_trigger();
+
+DROP TRIGGER IF EXISTS events_search_vector_update ON events;
+
+DROP FUNCTION IF EXISTS events_search_vector_trigger();
+
+DROP FUNCTION IF EXISTS event_search_vector(TEXT, BIGINT);
+
+ALTER TABLE events DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,33 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+CREATE OR REPLACE FUNCTION event_search_vector(event_name TEXT, event_venue_id BIGINT) RETURNS TSVECTOR AS $$
+  SELECT
+    setweight(to_tsvector('english', COALESCE(event_name, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE((SELECT name || ' ' || address FROM venues WHERE id = event_venue_id), '')), 'C')
+$$ LANGUAGE SQL STABLE;
+
+CREATE OR REPLACE FUNCTION events_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  NEW.search_vector := event_search_vector(NEW.name, NEW.venue_id);
+  RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER events_search_vector_update
+BEFORE INSERT OR UPDATE OF name, venue_id ON events
+FOR EACH ROW EXECUTE FUNCTION events_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION venues_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  UPDATE events SET search_vector = event_search_vector(name, venue_id) WHERE venue_id = NEW.id;
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER venues_search_vector_update
+AFTER UPDATE OF name, address ON venues
+FOR EACH ROW EXECUTE FUNCTION venues_search_vector_trigger();
+
+UPDATE events SET search_vector = event_search_vector(name, venue_id);
+
+CREATE INDEX IF NOT EXISTS events_search_vector_idx ON events USING GIN (search_vector);
@@ -0,0 +1,53 @@
+DROP TRIGGER IF EXISTS events_search_vector_update ON events;
+
+DROP FUNCTION IF EXISTS event_search_vector(TEXT, TEXT, TEXT[], BIGINT);
+
+CREATE OR REPLACE FUNCTION event_search_vector(event_name TEXT, event_venue_id BIGINT) RETURNS TSVECTOR AS $$
+  SELECT
+    setweight(to_tsvector('english', COALESCE(event_name, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE((SELECT name || ' ' || address FROM venues WHERE id = event_venue_id), '')), 'C')
+$$ LANGUAGE SQL STABLE;
+
+CREATE OR REPLACE FUNCTION events_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  NEW.search_vector := event_search_vector(NEW.name, NEW.venue_id);
+  RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER events_search_vector_update
+BEFORE INSERT OR UPDATE OF name, venue_id ON events
+FOR EACH ROW EXECUTE FUNCTION events_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION venues_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  UPDATE events SET search_vector = event_search_vector(name, venue_id) WHERE venue_id = NEW.id;
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+UPDATE events SET search_vector = event_search_vector(name, venue_id);
+
+DROP INDEX IF EXISTS events_tags_idx;
+
+DROP INDEX IF EXISTS events_category_idx;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_min_age_check;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_category_check;
+
+ALTER TABLE events DROP COLUMN IF EXISTS organizer_website;
+
+ALTER TABLE events DROP COLUMN IF EXISTS organizer_email;
+
+ALTER TABLE events DROP COLUMN IF EXISTS organizer_name;
+
+ALTER TABLE events DROP COLUMN IF EXISTS min_age;
+
+ALTER TABLE events DROP COLUMN IF EXISTS tags;
+
+ALTER TABLE events DROP COLUMN IF EXISTS category;
+
+ALTER TABLE events DROP COLUMN IF EXISTS description_html;
+
+ALTER TABLE events DROP COLUMN IF EXISTS description;
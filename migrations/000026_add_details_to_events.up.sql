@@ -0,0 +1,57 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS description_html TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS category VARCHAR(255);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS min_age INT;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS organizer_name VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS organizer_email VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS organizer_website VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE events ADD CONSTRAINT events_category_check CHECK (
+  category IN ('concert', 'sport', 'theatre', 'comedy', 'festival', 'conference', 'exhibition', 'other')
+);
+
+ALTER TABLE events ADD CONSTRAINT events_min_age_check CHECK (min_age >= 0);
+
+CREATE INDEX IF NOT EXISTS events_category_idx ON events (category);
+
+CREATE INDEX IF NOT EXISTS events_tags_idx ON events USING GIN (tags);
+
+DROP TRIGGER IF EXISTS events_search_vector_update ON events;
+
+DROP FUNCTION IF EXISTS event_search_vector(TEXT, BIGINT);
+
+CREATE OR REPLACE FUNCTION event_search_vector(event_name TEXT, event_description TEXT, event_tags TEXT[], event_venue_id BIGINT) RETURNS TSVECTOR AS $$
+  SELECT
+    setweight(to_tsvector('english', COALESCE(event_name, '')), 'A') ||
+    setweight(to_tsvector('english', array_to_string(event_tags, ' ')), 'B') ||
+    setweight(to_tsvector('english', COALESCE((SELECT name || ' ' || address FROM venues WHERE id = event_venue_id), '')), 'C') ||
+    setweight(to_tsvector('english', COALESCE(event_description, '')), 'D')
+$$ LANGUAGE SQL STABLE;
+
+CREATE OR REPLACE FUNCTION events_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  NEW.search_vector := event_search_vector(NEW.name, NEW.description, NEW.tags, NEW.venue_id);
+  RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER events_search_vector_update
+BEFORE INSERT OR UPDATE OF name, description, tags, venue_id ON events
+FOR EACH ROW EXECUTE FUNCTION events_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION venues_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  UPDATE events SET search_vector = event_search_vector(name, description, tags, venue_id) WHERE venue_id = NEW.id;
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+UPDATE events SET search_vector = event_search_vector(name, description, tags, venue_id);
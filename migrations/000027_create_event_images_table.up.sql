@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS event_images (
+  id BIGSERIAL PRIMARY KEY,
+  event_id BIGINT NOT NULL,
+  key VARCHAR(255) UNIQUE NOT NULL,
+  content_type VARCHAR(255) NOT NULL,
+  width INT NOT NULL,
+  height INT NOT NULL,
+  size BIGINT NOT NULL,
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE event_images ADD CONSTRAINT event_images_fk_event_id_events_id FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE;
+
+ALTER TABLE event_images ADD CONSTRAINT event_images_content_type_check CHECK (content_type IN ('image/jpeg', 'image/png', 'image/gif'));
+
+CREATE INDEX IF NOT EXISTS event_images_event_id_idx ON event_images (event_id);
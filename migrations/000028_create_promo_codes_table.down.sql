@@ -0,0 +1,11 @@
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_fk_promo_code_id_promo_codes_id;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS promo_code_id;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS discount;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS subtotal;
+
+DROP TABLE IF EXISTS promo_code_redemptions;
+
+DROP TABLE IF EXISTS promo_codes;
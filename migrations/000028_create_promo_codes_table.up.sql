@@ -0,0 +1,56 @@
+CREATE TABLE IF NOT EXISTS promo_codes (
+  id BIGSERIAL PRIMARY KEY,
+  code VARCHAR(255) UNIQUE NOT NULL,
+  discount_type VARCHAR(255) NOT NULL,
+  discount_value NUMERIC NOT NULL,
+  event_id BIGINT,
+  max_redemptions INT,
+  max_per_customer INT,
+  valid_from TIMESTAMP(0) WITH TIME ZONE,
+  valid_until TIMESTAMP(0) WITH TIME ZONE,
+  times_redeemed INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE promo_codes ADD CONSTRAINT promo_codes_fk_event_id_events_id FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE;
+
+ALTER TABLE promo_codes ADD CONSTRAINT promo_codes_discount_type_check CHECK (discount_type IN ('percentage', 'fixed'));
+
+ALTER TABLE promo_codes ADD CONSTRAINT promo_codes_discount_value_check CHECK (
+  discount_value > 0 AND (discount_type <> 'percentage' OR discount_value <= 100)
+);
+
+ALTER TABLE promo_codes ADD CONSTRAINT promo_codes_max_redemptions_check CHECK (max_redemptions > 0);
+
+ALTER TABLE promo_codes ADD CONSTRAINT promo_codes_max_per_customer_check CHECK (max_per_customer > 0);
+
+ALTER TABLE promo_codes ADD CONSTRAINT promo_codes_validity_check CHECK (valid_from < valid_until);
+
+ALTER TABLE promo_codes ADD CONSTRAINT promo_codes_times_redeemed_check CHECK (times_redeemed >= 0);
+
+CREATE TABLE IF NOT EXISTS promo_code_redemptions (
+  id BIGSERIAL PRIMARY KEY,
+  promo_code_id BIGINT NOT NULL,
+  customer_id BIGINT NOT NULL,
+  order_id BIGINT UNIQUE NOT NULL,
+  discount NUMERIC NOT NULL,
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE promo_code_redemptions ADD CONSTRAINT promo_code_redemptions_fk_promo_code_id_promo_codes_id FOREIGN KEY (promo_code_id) REFERENCES promo_codes(id) ON DELETE CASCADE;
+
+ALTER TABLE promo_code_redemptions ADD CONSTRAINT promo_code_redemptions_fk_customer_id_customers_id FOREIGN KEY (customer_id) REFERENCES customers(id);
+
+ALTER TABLE promo_code_redemptions ADD CONSTRAINT promo_code_redemptions_fk_order_id_orders_id FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS promo_code_redemptions_promo_code_id_customer_id_idx ON promo_code_redemptions (promo_code_id, customer_id);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS subtotal NUMERIC NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount NUMERIC NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code_id BIGINT;
+
+ALTER TABLE orders ADD CONSTRAINT orders_fk_promo_code_id_promo_codes_id FOREIGN KEY (promo_code_id) REFERENCES promo_codes(id) ON DELETE SET NULL;
+
+UPDATE orders SET subtotal = total_price;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS order_item_prices;
+
+DROP TABLE IF EXISTS price_tiers;
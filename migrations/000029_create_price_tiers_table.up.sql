@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS price_tiers (
+  id BIGSERIAL PRIMARY KEY,
+  ticket_id BIGINT NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  price NUMERIC NOT NULL,
+  ends_at TIMESTAMP(0) WITH TIME ZONE,
+  max_sold INT,
+  sold INT NOT NULL DEFAULT 0,
+  position INT NOT NULL
+);
+
+ALTER TABLE price_tiers ADD CONSTRAINT price_tiers_fk_ticket_id_tickets_id FOREIGN KEY (ticket_id) REFERENCES tickets(id) ON DELETE CASCADE;
+
+ALTER TABLE price_tiers ADD CONSTRAINT price_tiers_price_check CHECK (price >= 0);
+
+ALTER TABLE price_tiers ADD CONSTRAINT price_tiers_max_sold_check CHECK (max_sold > 0);
+
+ALTER TABLE price_tiers ADD CONSTRAINT price_tiers_sold_check CHECK (sold >= 0 AND sold <= max_sold);
+
+ALTER TABLE price_tiers ADD CONSTRAINT price_tiers_end_check CHECK (ends_at IS NOT NULL OR max_sold IS NOT NULL);
+
+CREATE UNIQUE INDEX IF NOT EXISTS price_tiers_ticket_id_position_idx ON price_tiers (ticket_id, position);
+
+CREATE TABLE IF NOT EXISTS order_item_prices (
+  id BIGSERIAL PRIMARY KEY,
+  order_item_id BIGINT NOT NULL,
+  tier_id BIGINT,
+  name VARCHAR(255) NOT NULL,
+  quantity INT NOT NULL,
+  unit_price NUMERIC NOT NULL
+);
+
+ALTER TABLE order_item_prices ADD CONSTRAINT order_item_prices_fk_order_item_id_order_items_id FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE CASCADE;
+
+ALTER TABLE order_item_prices ADD CONSTRAINT order_item_prices_fk_tier_id_price_tiers_id FOREIGN KEY (tier_id) REFERENCES price_tiers(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS order_item_prices_order_item_id_idx ON order_item_prices (order_item_id);
@@ -0,0 +1,19 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_jurisdiction;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_rate;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS tax;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS booking_fee;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_booking_fee_percentage_check;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_booking_fee_fixed_check;
+
+ALTER TABLE events DROP COLUMN IF EXISTS booking_fee_percentage;
+
+ALTER TABLE events DROP COLUMN IF EXISTS booking_fee_fixed;
+
+ALTER TABLE venues DROP COLUMN IF EXISTS tax_jurisdiction;
+
+DROP TABLE IF EXISTS tax_rates;
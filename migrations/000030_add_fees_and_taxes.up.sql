@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS tax_rates (
+  id BIGSERIAL PRIMARY KEY,
+  jurisdiction VARCHAR(16) NOT NULL UNIQUE,
+  name VARCHAR(255) NOT NULL,
+  rate NUMERIC NOT NULL,
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE tax_rates ADD CONSTRAINT tax_rates_rate_check CHECK (rate >= 0 AND rate <= 100);
+
+ALTER TABLE venues ADD COLUMN IF NOT EXISTS tax_jurisdiction VARCHAR(16);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS booking_fee_fixed NUMERIC NOT NULL DEFAULT 0;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS booking_fee_percentage NUMERIC NOT NULL DEFAULT 0;
+
+ALTER TABLE events ADD CONSTRAINT events_booking_fee_fixed_check CHECK (booking_fee_fixed >= 0);
+
+ALTER TABLE events ADD CONSTRAINT events_booking_fee_percentage_check CHECK (booking_fee_percentage >= 0 AND booking_fee_percentage <= 100);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS booking_fee NUMERIC NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax NUMERIC NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_rate NUMERIC NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_jurisdiction VARCHAR(16);
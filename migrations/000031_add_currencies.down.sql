@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS charged_amount;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS exchange_rate;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS charged_currency;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE resale_listings DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE customers DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE ticket_types DROP COLUMN IF EXISTS currency;
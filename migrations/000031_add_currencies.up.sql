@@ -0,0 +1,29 @@
+ALTER TABLE ticket_types ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE resale_listings ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS charged_currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS exchange_rate NUMERIC NOT NULL DEFAULT 1;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS charged_amount NUMERIC NOT NULL DEFAULT 0;
+
+UPDATE orders SET charged_amount = total_price;
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+  id BIGSERIAL PRIMARY KEY,
+  base_currency CHAR(3) NOT NULL,
+  quote_currency CHAR(3) NOT NULL,
+  rate NUMERIC NOT NULL,
+  updated_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE exchange_rates ADD CONSTRAINT exchange_rates_rate_check CHECK (rate > 0);
+
+ALTER TABLE exchange_rates ADD CONSTRAINT exchange_rates_currencies_check CHECK (base_currency <> quote_currency);
+
+CREATE UNIQUE INDEX IF NOT EXISTS exchange_rates_base_currency_quote_currency_idx ON exchange_rates (base_currency, quote_currency);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS top_ups (
+  id BIGSERIAL PRIMARY KEY,
+  customer_id BIGINT NOT NULL,
+  amount NUMERIC NOT NULL,
+  currency CHAR(3) NOT NULL,
+  provider VARCHAR(255) NOT NULL,
+  intent_id VARCHAR(255) NOT NULL,
+  status VARCHAR(255) NOT NULL,
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  confirmed_at TIMESTAMP(0) WITH TIME ZONE,
+  refunded_at TIMESTAMP(0) WITH TIME ZONE
+);
+
+ALTER TABLE top_ups ADD CONSTRAINT top_ups_fk_customer_id_customers_id FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE;
+
+ALTER TABLE top_ups ADD CONSTRAINT top_ups_amount_check CHECK (amount > 0);
+
+ALTER TABLE top_ups ADD CONSTRAINT top_ups_status_check CHECK (status IN ('pending', 'succeeded', 'failed', 'refunded'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS top_ups_provider_intent_id_idx ON top_ups (provider, intent_id);
+
+CREATE INDEX IF NOT EXISTS top_ups_customer_id_idx ON top_ups (customer_id);
@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS issued_tickets_event_id_seat_id_valid_idx;
+
+CREATE UNIQUE INDEX IF NOT EXISTS issued_tickets_event_id_seat_id_valid_idx ON issued_tickets (event_id, seat_id) WHERE seat_id IS NOT NULL AND status = 'valid';
+
+DROP INDEX IF EXISTS orders_expires_at_pending_idx;
+
+DROP INDEX IF EXISTS orders_payment_provider_payment_intent_id_idx;
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_payment_method_check;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS expires_at;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_intent_id;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_provider;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_method;
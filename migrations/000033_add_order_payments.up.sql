@@ -0,0 +1,17 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_method VARCHAR(255) NOT NULL DEFAULT 'balance';
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_provider VARCHAR(255);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_intent_id VARCHAR(255);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP(0) WITH TIME ZONE;
+
+ALTER TABLE orders ADD CONSTRAINT orders_payment_method_check CHECK (payment_method IN ('balance', 'provider'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS orders_payment_provider_payment_intent_id_idx ON orders (payment_provider, payment_intent_id);
+
+CREATE INDEX IF NOT EXISTS orders_expires_at_pending_idx ON orders (expires_at) WHERE status = 'pending';
+
+DROP INDEX IF EXISTS issued_tickets_event_id_seat_id_valid_idx;
+
+CREATE UNIQUE INDEX IF NOT EXISTS issued_tickets_event_id_seat_id_valid_idx ON issued_tickets (event_id, seat_id) WHERE seat_id IS NOT NULL AND status IN ('valid', 'pending');
@@ -0,0 +1,9 @@
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_fk_gift_card_id_gift_cards_id;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS gift_card_amount;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS gift_card_id;
+
+DROP TABLE IF EXISTS gift_card_transactions;
+
+DROP TABLE IF EXISTS gift_cards;
@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS gift_cards (
+  id BIGSERIAL PRIMARY KEY,
+  code VARCHAR(255) NOT NULL,
+  value NUMERIC NOT NULL,
+  balance NUMERIC NOT NULL,
+  currency CHAR(3) NOT NULL,
+  purchaser_id BIGINT NOT NULL,
+  expires_at TIMESTAMP(0) WITH TIME ZONE,
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE gift_cards ADD CONSTRAINT gift_cards_fk_purchaser_id_customers_id FOREIGN KEY (purchaser_id) REFERENCES customers(id) ON DELETE CASCADE;
+
+ALTER TABLE gift_cards ADD CONSTRAINT gift_cards_value_check CHECK (value > 0);
+
+ALTER TABLE gift_cards ADD CONSTRAINT gift_cards_balance_check CHECK (balance >= 0 AND balance <= value);
+
+CREATE UNIQUE INDEX IF NOT EXISTS gift_cards_code_idx ON gift_cards (code);
+
+CREATE INDEX IF NOT EXISTS gift_cards_purchaser_id_idx ON gift_cards (purchaser_id);
+
+CREATE TABLE IF NOT EXISTS gift_card_transactions (
+  id BIGSERIAL PRIMARY KEY,
+  gift_card_id BIGINT NOT NULL,
+  customer_id BIGINT NOT NULL,
+  order_id BIGINT,
+  type VARCHAR(255) NOT NULL,
+  amount NUMERIC NOT NULL,
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE gift_card_transactions ADD CONSTRAINT gift_card_transactions_fk_gift_card_id_gift_cards_id FOREIGN KEY (gift_card_id) REFERENCES gift_cards(id) ON DELETE CASCADE;
+
+ALTER TABLE gift_card_transactions ADD CONSTRAINT gift_card_transactions_fk_customer_id_customers_id FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE CASCADE;
+
+ALTER TABLE gift_card_transactions ADD CONSTRAINT gift_card_transactions_fk_order_id_orders_id FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE;
+
+ALTER TABLE gift_card_transactions ADD CONSTRAINT gift_card_transactions_type_check CHECK (type IN ('purchase', 'issue', 'redemption', 'checkout', 'refund'));
+
+CREATE INDEX IF NOT EXISTS gift_card_transactions_gift_card_id_idx ON gift_card_transactions (gift_card_id);
+
+CREATE INDEX IF NOT EXISTS gift_card_transactions_order_id_idx ON gift_card_transactions (order_id);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS gift_card_id BIGINT;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS gift_card_amount NUMERIC NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD CONSTRAINT orders_fk_gift_card_id_gift_cards_id FOREIGN KEY (gift_card_id) REFERENCES gift_cards(id) ON DELETE SET NULL;
@@ -0,0 +1,12 @@
+ALTER TABLE event_cancellations ADD COLUMN IF NOT EXISTS refunded_amount NUMERIC NOT NULL DEFAULT 0;
+
+UPDATE event_cancellations EC
+SET refunded_amount = A.amount
+FROM (
+  SELECT event_cancellation_id, SUM(amount) AS amount
+  FROM event_cancellation_amounts
+  GROUP BY event_cancellation_id
+) A
+WHERE A.event_cancellation_id = EC.id;
+
+DROP TABLE IF EXISTS event_cancellation_amounts;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS event_cancellation_amounts (
+  event_cancellation_id BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  amount NUMERIC NOT NULL DEFAULT 0,
+  PRIMARY KEY (event_cancellation_id, currency)
+);
+
+ALTER TABLE event_cancellation_amounts ADD CONSTRAINT event_cancellation_amounts_fk_event_cancellation_id_event_cancellations_id FOREIGN KEY (event_cancellation_id) REFERENCES event_cancellations(id) ON DELETE CASCADE;
+
+INSERT INTO event_cancellation_amounts (event_cancellation_id, currency, amount)
+SELECT EC.id, O.currency, SUM(O.total_price)
+FROM event_cancellations EC
+JOIN orders O ON O.event_id = EC.event_id
+WHERE O.status = 'refunded' AND O.id <= EC.last_order_id
+GROUP BY EC.id, O.currency;
+
+ALTER TABLE event_cancellations DROP COLUMN IF EXISTS refunded_amount;
@@ -0,0 +1,9 @@
+ALTER TABLE ticket_types ALTER COLUMN currency SET DEFAULT 'USD';
+
+ALTER TABLE customers ALTER COLUMN currency SET DEFAULT 'USD';
+
+ALTER TABLE resale_listings ALTER COLUMN currency SET DEFAULT 'USD';
+
+ALTER TABLE orders ALTER COLUMN currency SET DEFAULT 'USD';
+
+ALTER TABLE orders ALTER COLUMN charged_currency SET DEFAULT 'USD';
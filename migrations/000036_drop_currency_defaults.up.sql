@@ -0,0 +1,9 @@
+ALTER TABLE ticket_types ALTER COLUMN currency DROP DEFAULT;
+
+ALTER TABLE customers ALTER COLUMN currency DROP DEFAULT;
+
+ALTER TABLE resale_listings ALTER COLUMN currency DROP DEFAULT;
+
+ALTER TABLE orders ALTER COLUMN currency DROP DEFAULT;
+
+ALTER TABLE orders ALTER COLUMN charged_currency DROP DEFAULT;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS payment_refunds (
+  id BIGSERIAL PRIMARY KEY,
+  order_id BIGINT NOT NULL UNIQUE,
+  provider VARCHAR(255) NOT NULL,
+  intent_id VARCHAR(255) NOT NULL,
+  amount NUMERIC NOT NULL,
+  currency CHAR(3) NOT NULL,
+  status VARCHAR(255) NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  last_error TEXT,
+  created_at TIMESTAMP(0) WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  refunded_at TIMESTAMP(0) WITH TIME ZONE
+);
+
+ALTER TABLE payment_refunds ADD CONSTRAINT payment_refunds_fk_order_id_orders_id FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE;
+
+ALTER TABLE payment_refunds ADD CONSTRAINT payment_refunds_amount_check CHECK (amount > 0);
+
+ALTER TABLE payment_refunds ADD CONSTRAINT payment_refunds_status_check CHECK (status IN ('pending', 'succeeded'));
+
+CREATE INDEX IF NOT EXISTS payment_refunds_pending_idx ON payment_refunds (provider, id) WHERE status = 'pending';
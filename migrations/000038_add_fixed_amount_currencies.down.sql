@@ -0,0 +1,7 @@
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_booking_fee_currency_check;
+
+ALTER TABLE promo_codes DROP CONSTRAINT IF EXISTS promo_codes_currency_check;
+
+ALTER TABLE events DROP COLUMN IF EXISTS booking_fee_currency;
+
+ALTER TABLE promo_codes DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,23 @@
+ALTER TABLE promo_codes ADD COLUMN IF NOT EXISTS currency CHAR(3);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS booking_fee_currency CHAR(3);
+
+UPDATE events E
+SET booking_fee_currency = COALESCE(
+  (SELECT MIN(TT.currency) FROM tickets T JOIN ticket_types TT ON TT.id = T.ticket_type_id WHERE T.event_id = E.id),
+  (SELECT currency FROM ticket_types ORDER BY id LIMIT 1),
+  'USD'
+)
+WHERE booking_fee_fixed > 0;
+
+UPDATE promo_codes P
+SET currency = COALESCE(
+  (SELECT MIN(TT.currency) FROM tickets T JOIN ticket_types TT ON TT.id = T.ticket_type_id WHERE T.event_id = P.event_id),
+  (SELECT currency FROM ticket_types ORDER BY id LIMIT 1),
+  'USD'
+)
+WHERE discount_type = 'fixed';
+
+ALTER TABLE promo_codes ADD CONSTRAINT promo_codes_currency_check CHECK ((discount_type = 'fixed') = (currency IS NOT NULL));
+
+ALTER TABLE events ADD CONSTRAINT events_booking_fee_currency_check CHECK (booking_fee_fixed = 0 OR booking_fee_currency IS NOT NULL);
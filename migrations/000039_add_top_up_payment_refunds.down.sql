@@ -0,0 +1,9 @@
+DELETE FROM payment_refunds WHERE top_up_id IS NOT NULL;
+
+ALTER TABLE payment_refunds DROP CONSTRAINT IF EXISTS payment_refunds_payment_check;
+
+ALTER TABLE payment_refunds DROP CONSTRAINT IF EXISTS payment_refunds_fk_top_up_id_top_ups_id;
+
+ALTER TABLE payment_refunds DROP COLUMN IF EXISTS top_up_id;
+
+ALTER TABLE payment_refunds ALTER COLUMN order_id SET NOT NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE payment_refunds ALTER COLUMN order_id DROP NOT NULL;
+
+ALTER TABLE payment_refunds ADD COLUMN IF NOT EXISTS top_up_id BIGINT UNIQUE;
+
+ALTER TABLE payment_refunds ADD CONSTRAINT payment_refunds_fk_top_up_id_top_ups_id FOREIGN KEY (top_up_id) REFERENCES top_ups(id) ON DELETE CASCADE;
+
+ALTER TABLE payment_refunds ADD CONSTRAINT payment_refunds_payment_check CHECK ((order_id IS NULL) <> (top_up_id IS NULL));
@@ -70,7 +70,6 @@ export function setup() {
 export default function () {
   const url = 'http://localhost:8080/api/orders';
   const payload = JSON.stringify({
-    customer_id: customerID,
     ticket_id: ticketID,
     quantity: 1,
   });
@@ -41,11 +41,10 @@ func ConcurrentOrderCreation(orderUsecase usecase.IOrderUsecase, ticketUsecase u
 
 			// Call your API to create an order
 			orderInput := &request.OrderRequest{
-				CustomerID: customerID,
-				TicketID:   ticketID,
-				Quantity:   1,
+				TicketID: ticketID,
+				Quantity: 1,
 			}
-			_, err := orderUsecase.Add(orderInput)
+			_, err := orderUsecase.Add(orderInput, customerID)
 			if err != nil {
 				errors <- err
 			}